@@ -1,9 +1,14 @@
 package sibyl2
 
 import (
+	"runtime"
+	"sort"
+	"sync"
+
 	"github.com/dominikbraun/graph"
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 )
 
 // These functions are designed on the top of query.go
@@ -20,143 +25,346 @@ func isFuncNameInvalid(funcName string) bool {
 	return tooShort
 }
 
+// funcVertex function with its descriptions, which are expensive to calc again and again
+type funcVertex struct {
+	*extractor.FunctionWithPath
+	// hash of vertex
+	key string
+}
+
+func newFuncVertex(f *extractor.Function, p string) *funcVertex {
+	fwp := extractor.WrapFuncWithPath(f, p)
+	return &funcVertex{
+		FunctionWithPath: fwp,
+		key:              fwp.GetDescWithPath(),
+	}
+}
+
+// funcRef a function referenced the name, with the count of arguments if it was called
+type funcRef struct {
+	caller *funcVertex
+	// -1 if unknown, eg: method references and languages without call extraction
+	argCount int
+}
+
+// fileRefs references found in one symbol file
+type fileRefs struct {
+	// func name -> references to it, without duplications
+	refs map[string][]funcRef
+	// func name -> count of references
+	counts map[string]int
+}
+
+/*
+AnalyzeFuncGraph
+
+link functions by references, see FuncGraph.
+
+	index: spans of functions per file, functions per name, built once
+	refs:  the innermost function of each symbol, files in parallel
+	edges: referenced functions -> the functions they appeared in, names in parallel
+
+Each symbol costs a map lookup and a binary search, so it is near-linear in symbols.
+*/
 func AnalyzeFuncGraph(funcFiles []*extractor.FunctionFileResult, symbolFiles []*extractor.SymbolFileResult) (*FuncGraph, error) {
-	reverseCallGraph := graph.New((*extractor.FunctionWithPath).GetDescWithPath, graph.Directed())
-	callGraph := graph.New((*extractor.FunctionWithPath).GetDescWithPath, graph.Directed())
+	return AnalyzeFuncGraphWithCalls(funcFiles, symbolFiles, nil)
+}
+
+/*
+AnalyzeFuncGraphWithCalls
+
+same as AnalyzeFuncGraph, and overloads will be told by counts of arguments from calls:
+
+	void process(String a)              process("a")     -> process(String a)
+	void process(String a, int b)       process("a", 1)  -> process(String a, int b)
+	void process(String a, int... b)    process(x::y)    -> all of them, ambiguous
+
+Edges to overloads which can not be told are flagged, see FuncGraph.IsAmbiguousCall.
+callFiles can be nil, then all the overloads are ambiguous.
+*/
+func AnalyzeFuncGraphWithCalls(funcFiles []*extractor.FunctionFileResult, symbolFiles []*extractor.SymbolFileResult, callFiles []*extractor.CallFileResult) (*FuncGraph, error) {
+	reverseCallGraph, callGraph, err := newFuncGraphs(funcFiles)
+	if err != nil {
+		return nil, err
+	}
 
-	// speed up cache
-	funcFileMap := make(map[string]*extractor.FunctionFileResult, len(funcFiles))
-	for _, each := range funcFiles {
-		funcFileMap[each.Path] = each
+	spanIndexes := make(map[string]*funcSpanIndex, len(funcFiles))
+	nameIndex := make(map[string][]*funcVertex)
+	for _, eachFile := range funcFiles {
+		vertices := make([]*funcVertex, 0, len(eachFile.Units))
+		for _, eachFunc := range eachFile.Units {
+			vertex := newFuncVertex(eachFunc, eachFile.Path)
+			vertices = append(vertices, vertex)
+			if !isFuncNameInvalid(eachFunc.GetIndexName()) {
+				nameIndex[eachFunc.GetIndexName()] = append(nameIndex[eachFunc.GetIndexName()], vertex)
+			}
+		}
+		spanIndexes[eachFile.Path] = newFuncSpanIndex(vertices)
+	}
+	callIndexes := make(map[string]map[string][]*extractor.Call, len(callFiles))
+	for _, eachFile := range callFiles {
+		calls := make(map[string][]*extractor.Call)
+		for _, eachCall := range eachFile.Units {
+			_, name := splitCaller(eachCall.Caller)
+			calls[name] = append(calls[name], eachCall)
+		}
+		callIndexes[eachFile.Path] = calls
 	}
+	core.Log.Infof("func index ready")
 
-	symbolMap := make(map[string]map[string][]*extractor.Symbol, len(symbolFiles))
-	for _, each := range symbolFiles {
-		functions, ok := funcFileMap[each.Path]
+	refsOfFiles := make([]*fileRefs, len(symbolFiles))
+	parallelEach(len(symbolFiles), func(i int) {
+		each := symbolFiles[i]
+		spanIndex, ok := spanIndexes[each.Path]
 		// this file only contains symbols
 		if !ok {
-			continue
+			return
 		}
-		// out of function scope
-		validSymbols := make([]*extractor.Symbol, 0)
-		for _, eachS := range each.Units {
-			for _, eachF := range functions.Units {
-				if eachF.BodySpan.HasInteraction(eachS.GetSpan()) {
-					validSymbols = append(validSymbols, eachS)
-					break
-				}
-			}
+		refsOfFiles[i] = collectFuncRefs(each.Units, spanIndex, nameIndex, callIndexes[each.Path])
+	})
+
+	refs := make(map[string][]funcRef)
+	counts := make(map[string]int)
+	for _, each := range refsOfFiles {
+		if each == nil {
+			continue
 		}
-		symbolMap[each.Path] = make(map[string][]*extractor.Symbol)
-		for _, eachSymbol := range validSymbols {
-			if symbolList, ok := symbolMap[each.Path][eachSymbol.GetIndexName()]; ok {
-				symbolMap[each.Path][eachSymbol.GetIndexName()] = append(symbolList, eachSymbol)
-			} else {
-				symbolList := make([]*extractor.Symbol, 0)
-				symbolList = append(symbolList, eachSymbol)
-				symbolMap[each.Path][eachSymbol.GetIndexName()] = symbolList
-			}
+		for name, eachRefs := range each.refs {
+			refs[name] = append(refs[name], eachRefs...)
+			counts[name] += each.counts[name]
 		}
 	}
-	core.Log.Infof("symbol clean up")
+	core.Log.Infof("symbol refs finished")
 
-	funcRefMap := make(map[string][]*extractor.SymbolWithPath, 0)
-	for symbolPath, symbolNameMap := range symbolMap {
-		for _, eachFuncFile := range funcFiles {
-			for _, eachFunc := range eachFuncFile.Units {
-				index := eachFunc.GetIndexName()
-				if isFuncNameInvalid(index) {
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		// in some languages (like java) which has `override`
+		// will create thousands of refs for some special methods (toString, etc.)
+		// which makes the final graph very, very large
+		// and at the most time these methods will not be analyzed
+		// limited by the count of edges it may create
+		if counts[name]*len(nameIndex[name]) > refLimit {
+			continue
+		}
+		names = append(names, name)
+	}
+	// callee referenced by caller
+	type refEdge struct {
+		callee    *funcVertex
+		caller    *funcVertex
+		ambiguous bool
+	}
+	edgesOfNames := make([][]*refEdge, len(names))
+	parallelEach(len(names), func(i int) {
+		var edges []*refEdge
+		// a caller can reach an overload with different counts of arguments,
+		// and functions with the same descriptions in a file share a vertex
+		edgeMap := make(map[[2]string]*refEdge)
+		picked := make(map[int][]*overloadMatch)
+		for _, eachRef := range refs[names[i]] {
+			matches, ok := picked[eachRef.argCount]
+			if !ok {
+				matches = pickOverloads(nameIndex[names[i]], eachRef.argCount)
+				picked[eachRef.argCount] = matches
+			}
+			for _, eachMatch := range matches {
+				// exclude itself
+				if eachMatch.key == eachRef.caller.key {
 					continue
 				}
-
-				funcName := eachFunc.GetIndexName()
-				refs, ok := symbolNameMap[funcName]
-				if !ok {
+				pair := [2]string{eachMatch.key, eachRef.caller.key}
+				if existed, ok := edgeMap[pair]; ok {
+					existed.ambiguous = existed.ambiguous && eachMatch.ambiguous
 					continue
 				}
-				refWithPaths := make([]*extractor.SymbolWithPath, 0, len(refs))
-				for _, eachRef := range refs {
-					swp := &extractor.SymbolWithPath{
-						Symbol: eachRef,
-						Path:   symbolPath,
-					}
-					refWithPaths = append(refWithPaths, swp)
-				}
-				funcRefMap[funcName] = append(funcRefMap[funcName], refWithPaths...)
+				edge := &refEdge{eachMatch.funcVertex, eachRef.caller, eachMatch.ambiguous}
+				edgeMap[pair] = edge
+				edges = append(edges, edge)
+			}
+		}
+		edgesOfNames[i] = edges
+	})
+
+	// graph is not thread safe
+	for _, edges := range edgesOfNames {
+		for _, each := range edges {
+			attrs := edgeAttributes("", each.ambiguous)
+			if err := reverseCallGraph.AddEdge(each.callee.key, each.caller.key, attrs...); err != nil {
+				return nil, err
+			}
+			if err := callGraph.AddEdge(each.caller.key, each.callee.key, attrs...); err != nil {
+				return nil, err
 			}
 		}
 	}
-	core.Log.Infof("symbol refs finished")
+	core.Log.Infof("edges filled")
+
+	fg := &FuncGraph{
+		ReverseCallGraph: WrapFuncGraph(reverseCallGraph),
+		CallGraph:        WrapFuncGraph(callGraph),
+	}
+	return fg, nil
+}
+
+// collectFuncRefs symbols which may reference functions, grouped by names
+func collectFuncRefs(symbols []*extractor.Symbol, spanIndex *funcSpanIndex, nameIndex map[string][]*funcVertex, calls map[string][]*extractor.Call) *fileRefs {
+	ret := &fileRefs{
+		refs:   make(map[string][]funcRef),
+		counts: make(map[string]int),
+	}
+	seen := make(map[string]map[funcRef]struct{})
+	for _, eachSymbol := range symbols {
+		// local variables and parameters, which shadow the functions with the same names
+		if eachSymbol.Resolution == object.ResolutionLocal {
+			continue
+		}
+		name := eachSymbol.GetIndexName()
+		if _, ok := nameIndex[name]; !ok {
+			continue
+		}
+		// out of function scope
+		caller := spanIndex.findInnermost(eachSymbol.GetSpan())
+		if caller == nil {
+			continue
+		}
+		ret.counts[name]++
+		ref := funcRef{caller, argCountOfSymbol(eachSymbol, calls[name])}
+		if _, ok := seen[name]; !ok {
+			seen[name] = make(map[funcRef]struct{})
+		}
+		if _, ok := seen[name][ref]; ok {
+			continue
+		}
+		seen[name][ref] = struct{}{}
+		ret.refs[name] = append(ret.refs[name], ref)
+	}
+	return ret
+}
+
+// argCountOfSymbol count of arguments of the innermost call with the same name around this symbol, -1 if none
+func argCountOfSymbol(symbol *extractor.Symbol, calls []*extractor.Call) int {
+	var innermost *extractor.Call
+	for _, each := range calls {
+		if !each.Span.Contain(symbol.GetSpan()) {
+			continue
+		}
+		if innermost == nil || innermost.Span.Contain(&each.Span) {
+			innermost = each
+		}
+	}
+	if innermost == nil {
+		return -1
+	}
+	return len(innermost.Arguments)
+}
+
+// parallelEach call f with 0 ... n-1, in all the cpus
+func parallelEach(n int, f func(i int)) {
+	workers := runtime.NumCPU()
+	if workers > n {
+		workers = n
+	}
+	indexes := make(chan int, n)
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
 
-	// fill graph with vertex
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				f(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// newFuncGraphs reverse call graph and call graph, filled with all the functions
+func newFuncGraphs(funcFiles []*extractor.FunctionFileResult) (graph.Graph[string, *extractor.FunctionWithPath], graph.Graph[string, *extractor.FunctionWithPath], error) {
+	reverseCallGraph := graph.New((*extractor.FunctionWithPath).GetDescWithPath, graph.Directed())
+	callGraph := graph.New((*extractor.FunctionWithPath).GetDescWithPath, graph.Directed())
 	for _, eachFuncFile := range funcFiles {
 		for _, eachFunc := range eachFuncFile.Units {
 			// multi graphs shared
-			fwp := &extractor.FunctionWithPath{
-				eachFunc,
-				eachFuncFile.Path,
-			}
+			fwp := extractor.WrapFuncWithPath(eachFunc, eachFuncFile.Path)
 			err := reverseCallGraph.AddVertex(fwp)
 			if err != nil {
 				core.Log.Errorf("add vertex failed: %v", fwp.GetDescWithPath())
-				return nil, err
+				return nil, nil, err
 			}
 			err = callGraph.AddVertex(fwp)
 			if err != nil {
 				core.Log.Errorf("add vertex failed: %v", fwp.GetDescWithPath())
-				return nil, err
+				return nil, nil, err
 			}
 		}
 	}
 	core.Log.Infof("vertex filled")
+	return reverseCallGraph, callGraph, nil
+}
 
-	// build relationship
-	for _, eachFuncFile := range funcFiles {
-		core.Log.Debugf("file %s , methods: %d", eachFuncFile.Path, len(eachFuncFile.Units))
-		for _, eachFunc := range eachFuncFile.Units {
-			refs, ok := funcRefMap[eachFunc.GetIndexName()]
-			if !ok {
-				continue
-			}
+/*
+funcSpanIndex interval index of function bodies in one file.
 
-			// in some languages (like java) which has `override`
-			// will create thousands of refs for some special methods (toString, etc.)
-			// which makes the final graph very, very large
-			// and at the most time these methods will not be analyzed
-			if len(refs) > refLimit {
-				// happen very easily in big repo, suppress it
-				// core.Log.Warnf("func %s exceed the ref limit %d, now %d", eachFunc.GetIndexName(), refLimit, len(refs))
-				continue
-			}
+Functions can be nested (closures, lambdas) but never cross each other,
+so they are sorted by starts, with a link to the nearest enclosing one:
 
-			for _, eachRefPoint := range refs {
-				// look up the file it appeared
-				targetFuncFile, ok := funcFileMap[eachRefPoint.Path]
-				if !ok {
-					continue
-				}
-				for _, eachMatchFunc := range targetFuncFile.Units {
-					if eachMatchFunc.BodySpan.HasInteraction(eachRefPoint.GetSpan()) {
-						// match
-						// exclude itself
-						if eachMatchFunc.GetDesc() == eachFunc.GetDesc() {
-							continue
-						}
-
-						// eachFunc referenced by eachMatchFunc
-						eachFuncWithPath := extractor.WrapFuncWithPath(eachFunc, eachFuncFile.Path)
-						eachMatchFuncWithPath := extractor.WrapFuncWithPath(eachMatchFunc, targetFuncFile.Path)
-						reverseCallGraph.AddEdge(eachFuncWithPath.GetDescWithPath(), eachMatchFuncWithPath.GetDescWithPath())
-						callGraph.AddEdge(eachMatchFuncWithPath.GetDescWithPath(), eachFuncWithPath.GetDescWithPath())
-						break
-					}
-				}
-			}
+	a {          0: a, parent -1
+	  b { }      1: b, parent 0
+	  c {        2: c, parent 0
+	    d { }    3: d, parent 2
+	  }
+	}
+*/
+type funcSpanIndex struct {
+	functions []*funcVertex
+	parents   []int
+}
+
+func newFuncSpanIndex(functions []*funcVertex) *funcSpanIndex {
+	sorted := make([]*funcVertex, len(functions))
+	copy(sorted, functions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].BodySpan, sorted[j].BodySpan
+		if a.Start != b.Start {
+			return b.Start.After(a.Start)
 		}
+		// outer first
+		return a.End.After(b.End)
+	})
+
+	parents := make([]int, len(sorted))
+	var stack []int
+	for i, each := range sorted {
+		for len(stack) != 0 && !sorted[stack[len(stack)-1]].BodySpan.Contain(&each.BodySpan) {
+			stack = stack[:len(stack)-1]
+		}
+		parents[i] = -1
+		if len(stack) != 0 {
+			parents[i] = stack[len(stack)-1]
+		}
+		stack = append(stack, i)
 	}
-	fg := &FuncGraph{
-		ReverseCallGraph: WrapFuncGraph(reverseCallGraph),
-		CallGraph:        WrapFuncGraph(callGraph),
+	return &funcSpanIndex{
+		functions: sorted,
+		parents:   parents,
 	}
-	return fg, nil
+}
+
+// findInnermost the innermost function which contains this span, nil if none
+func (index *funcSpanIndex) findInnermost(span *core.Span) *funcVertex {
+	// the last one which starts before this span
+	i := sort.Search(len(index.functions), func(i int) bool {
+		return index.functions[i].BodySpan.Start.After(span.Start)
+	}) - 1
+	for ; i >= 0; i = index.parents[i] {
+		if index.functions[i].BodySpan.HasInteraction(span) {
+			return index.functions[i]
+		}
+	}
+	return nil
 }
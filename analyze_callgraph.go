@@ -0,0 +1,323 @@
+package sibyl2
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor"
+)
+
+var calleeNameRegex = regexp.MustCompile(`^[\p{L}_$][\p{L}\p{N}_$]*$`)
+
+// callGraphIndex lookup tables shared by all the calls
+type callGraphIndex struct {
+	spanIndexes map[string]*funcSpanIndex
+	// name -> functions with this name
+	byName map[string][]*funcVertex
+	// path -> local name -> import
+	importMap map[string]map[string]*extractor.Import
+	goModules GoModules
+}
+
+/*
+AnalyzeCallGraph
+
+a more precise FuncGraph, based on real calls rather than references.
+Callees will be resolved with receivers and imports of the caller file:
+
+	s.helper()       s is the receiver       -> exact
+	util.Clean()     util is an import       -> exact, or dropped if it comes from outside the repo
+	req.Validate()   req is a `*Request`     -> receiver
+	x.Run()          nothing known about x   -> name
+
+Every edge carries its CallConfidence, see FuncGraph.GetCallConfidence.
+Go packages are imported by module paths, goModules (see ReadGoModules) is needed to resolve them.
+Only languages which support call extraction can be analyzed, and receiver types only come from golang and java.
+*/
+func AnalyzeCallGraph(funcFiles []*extractor.FunctionFileResult, callFiles []*extractor.CallFileResult, importFiles []*extractor.ImportFileResult, goModules GoModules) (*FuncGraph, error) {
+	reverseCallGraph, callGraph, err := newFuncGraphs(funcFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &callGraphIndex{
+		spanIndexes: make(map[string]*funcSpanIndex, len(funcFiles)),
+		byName:      make(map[string][]*funcVertex),
+		importMap:   make(map[string]map[string]*extractor.Import, len(importFiles)),
+		goModules:   goModules,
+	}
+	for _, eachFile := range funcFiles {
+		vertices := make([]*funcVertex, 0, len(eachFile.Units))
+		for _, eachFunc := range eachFile.Units {
+			vertex := newFuncVertex(eachFunc, eachFile.Path)
+			vertices = append(vertices, vertex)
+			index.byName[eachFunc.Name] = append(index.byName[eachFunc.Name], vertex)
+		}
+		index.spanIndexes[eachFile.Path] = newFuncSpanIndex(vertices)
+	}
+	for _, eachFile := range importFiles {
+		imports := make(map[string]*extractor.Import)
+		for _, eachImport := range eachFile.Units {
+			for _, eachName := range eachImport.LocalNames() {
+				imports[eachName] = eachImport
+			}
+		}
+		index.importMap[eachFile.Path] = imports
+	}
+	core.Log.Infof("call graph index finished")
+
+	// a function can call another one many times, keep the most confident one
+	type callPair struct {
+		caller *funcVertex
+		callee *funcVertex
+	}
+	type callEdge struct {
+		confidence CallConfidence
+		ambiguous  bool
+	}
+	edges := make(map[callPair]*callEdge)
+	for _, eachFile := range callFiles {
+		spanIndex, ok := index.spanIndexes[eachFile.Path]
+		if !ok {
+			continue
+		}
+		for _, eachCall := range eachFile.Units {
+			caller := spanIndex.findInnermost(eachCall.GetSpan())
+			if caller == nil {
+				continue
+			}
+			callees, confidence := index.resolve(caller, eachCall)
+			for _, eachCallee := range pickOverloads(callees, len(eachCall.Arguments)) {
+				// exclude itself
+				if eachCallee.funcVertex == caller {
+					continue
+				}
+				pair := callPair{caller, eachCallee.funcVertex}
+				existed, ok := edges[pair]
+				switch {
+				case !ok || higherConfidence(confidence, existed.confidence):
+					edges[pair] = &callEdge{confidence, eachCallee.ambiguous}
+				case confidence == existed.confidence:
+					existed.ambiguous = existed.ambiguous && eachCallee.ambiguous
+				}
+			}
+		}
+	}
+	core.Log.Infof("calls resolved")
+
+	for pair, edge := range edges {
+		attrs := edgeAttributes(edge.confidence, edge.ambiguous)
+		if err := callGraph.AddEdge(pair.caller.key, pair.callee.key, attrs...); err != nil {
+			return nil, err
+		}
+		if err := reverseCallGraph.AddEdge(pair.callee.key, pair.caller.key, attrs...); err != nil {
+			return nil, err
+		}
+	}
+	fg := &FuncGraph{
+		ReverseCallGraph: WrapFuncGraph(reverseCallGraph),
+		CallGraph:        WrapFuncGraph(callGraph),
+	}
+	return fg, nil
+}
+
+// resolve the callees of this call, and how confident we are
+func (index *callGraphIndex) resolve(caller *funcVertex, call *extractor.Call) ([]*funcVertex, CallConfidence) {
+	qualifier, name := splitCaller(call.Caller)
+	if !calleeNameRegex.MatchString(name) {
+		// eg: `func() {...}()`
+		return nil, ""
+	}
+	candidates := index.byName[name]
+	if len(candidates) == 0 {
+		// std libs and third parties
+		return nil, ""
+	}
+	imports := index.importMap[caller.Path]
+
+	switch qualifier {
+	case "":
+		// `clean()` from `import static com.b.Util.clean`
+		if imp, ok := imports[name]; ok {
+			return filterFunc(candidates, func(f *funcVertex) bool {
+				return index.isImportedFrom(imp, caller.Path, f.FunctionWithPath, name, name)
+			}), CallConfidenceExact
+		}
+		if ret := filterFunc(candidates, func(f *funcVertex) bool {
+			return isSameScope(caller.FunctionWithPath, f.FunctionWithPath)
+		}); len(ret) != 0 {
+			return ret, CallConfidenceExact
+		}
+	case "this", "self":
+		if ret := filterFunc(candidates, func(f *funcVertex) bool {
+			return f.Receiver != "" && isSameReceiver(caller.FunctionWithPath, f.FunctionWithPath)
+		}); len(ret) != 0 {
+			return ret, CallConfidenceExact
+		}
+	default:
+		if imp, ok := imports[qualifier]; ok && call.ReceiverType == "" {
+			// nothing in this repo means it comes from outside
+			return filterFunc(candidates, func(f *funcVertex) bool {
+				return index.isImportedFrom(imp, caller.Path, f.FunctionWithPath, qualifier, name)
+			}), CallConfidenceExact
+		}
+		if call.ReceiverType != "" {
+			receiverType := lastSegment(call.ReceiverType)
+			// receiver of golang methods, eg: `s.b()` in `func (s *S) a()`
+			if receiverType == lastSegment(caller.Receiver) {
+				if ret := filterFunc(candidates, func(f *funcVertex) bool {
+					return isSameReceiver(caller.FunctionWithPath, f.FunctionWithPath)
+				}); len(ret) != 0 {
+					return ret, CallConfidenceExact
+				}
+			}
+			if ret := filterFunc(candidates, func(f *funcVertex) bool {
+				return f.Receiver != "" && lastSegment(f.Receiver) == receiverType
+			}); len(ret) != 0 {
+				return ret, CallConfidenceReceiver
+			}
+			// interfaces, embedded types and inherited methods
+		}
+	}
+
+	// same as AnalyzeFuncGraph
+	if isFuncNameInvalid(name) || len(candidates) > refLimit {
+		return nil, ""
+	}
+	return candidates, CallConfidenceName
+}
+
+// splitCaller `a.b.c` -> `a.b`, `c`
+func splitCaller(caller string) (string, string) {
+	caller = strings.TrimSpace(caller)
+	if index := strings.LastIndex(caller, "."); index != -1 {
+		return caller[:index], caller[index+1:]
+	}
+	return "", caller
+}
+
+// lastSegment `com.a.Request` -> `Request`
+func lastSegment(name string) string {
+	if index := strings.LastIndex(name, "."); index != -1 {
+		return name[index+1:]
+	}
+	return name
+}
+
+func filterFunc(functions []*funcVertex, filter func(*funcVertex) bool) []*funcVertex {
+	var ret []*funcVertex
+	for _, each := range functions {
+		if filter(each) {
+			ret = append(ret, each)
+		}
+	}
+	return ret
+}
+
+// isSameScope a plain call `b()` can reach: package level functions in golang,
+// methods of the same class and functions in the same file in others
+func isSameScope(caller *extractor.FunctionWithPath, callee *extractor.FunctionWithPath) bool {
+	if caller.Lang == core.LangGo {
+		return callee.Receiver == "" && goPackageDir(caller.Path) == goPackageDir(callee.Path)
+	}
+	if callee.Receiver == "" {
+		return caller.Path == callee.Path
+	}
+	return isSameReceiver(caller, callee)
+}
+
+// isSameReceiver receivers of java are full qualified, others are only unique in files
+func isSameReceiver(caller *extractor.FunctionWithPath, callee *extractor.FunctionWithPath) bool {
+	if caller.Receiver != callee.Receiver {
+		return false
+	}
+	switch caller.Lang {
+	case core.LangJava:
+		return true
+	case core.LangGo:
+		return goPackageDir(caller.Path) == goPackageDir(callee.Path)
+	default:
+		return caller.Path == callee.Path
+	}
+}
+
+// isImportedFrom whether this function is the `name` brought by this import, which is called with `localName`:
+// `clean()` -> clean, clean; `util.clean()` -> util, clean
+func (index *callGraphIndex) isImportedFrom(imp *extractor.Import, importerPath string, f *extractor.FunctionWithPath, localName string, name string) bool {
+	switch f.Lang {
+	case core.LangGo:
+		// `github.com/x/repo/pkg/util` -> pkg/util/*.go in module `github.com/x/repo`
+		importPath := index.goModules.ImportPath(goPackageDir(f.Path))
+		return f.Receiver == "" && importPath != "" && imp.Source == importPath
+	case core.LangJava, core.LangKotlin:
+		// `import com.b.Util` or `import static com.b.Util.clean`
+		return f.Receiver == imp.Source || f.Receiver+"."+name == imp.Source
+	case core.LangPython:
+		// modules, functions on top level only
+		if f.Receiver != "" {
+			return false
+		}
+		source := imp.Source
+		// `from pkg import util` and `util.clean()`, util is a module
+		if original := importedName(imp, localName); original != "" && original != name {
+			if !strings.HasSuffix(source, ".") {
+				source += "."
+			}
+			source += original
+		}
+		modulePath := strings.TrimSuffix(strings.TrimSuffix(filepath.ToSlash(f.Path), path.Ext(f.Path)), "/__init__")
+		target, relative := resolvePythonModule(source, importerPath)
+		if relative {
+			return modulePath == target
+		}
+		return modulePath == target || strings.HasSuffix(modulePath, "/"+target)
+	default:
+		// js files and others, functions on top level only
+		if f.Receiver != "" {
+			return false
+		}
+		modulePath := strings.TrimSuffix(filepath.ToSlash(f.Path), path.Ext(f.Path))
+		source := imp.Source
+		if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+			// `./util` relative to the importer
+			source = path.Join(path.Dir(filepath.ToSlash(importerPath)), source)
+			return modulePath == source || modulePath == source+"/index"
+		}
+		source = strings.ReplaceAll(source, ".", "/")
+		return modulePath == source || strings.HasSuffix(modulePath, "/"+source)
+	}
+}
+
+// importedName the original name in this import which is brought as localName, empty if none
+func importedName(imp *extractor.Import, localName string) string {
+	for _, each := range imp.Names {
+		if alias, ok := imp.Aliases[each]; ok {
+			if alias == localName {
+				return each
+			}
+		} else if each == localName {
+			return each
+		}
+	}
+	return ""
+}
+
+// resolvePythonModule `..a.b` imported by `x/y/z.py` -> `x/a/b`, relative.
+// Absolute ones (`a.b` -> `a/b`) are relative to one of the source roots, which are unknown here.
+func resolvePythonModule(source string, importerPath string) (string, bool) {
+	rest := strings.TrimLeft(source, ".")
+	modulePath := strings.ReplaceAll(rest, ".", "/")
+	dots := len(source) - len(rest)
+	if dots == 0 {
+		return modulePath, false
+	}
+	// `.` is the package of the importer, and each extra dot goes one level up
+	dir := path.Dir(filepath.ToSlash(importerPath))
+	for i := 1; i < dots; i++ {
+		dir = path.Dir(dir)
+	}
+	return path.Join(dir, modulePath), true
+}
@@ -0,0 +1,262 @@
+package sibyl2
+
+import (
+	"sort"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type cloneCandidate struct {
+	*extractor.FunctionWithPath
+	bodyHash    string
+	renamedHash string
+	// parent kind > child kind -> count
+	shape      map[string]int
+	shapeTotal int
+}
+
+/*
+AnalyzeClones
+
+find copy-pasted functions in a repo. Each function will be put into one group per clone type at most.
+
+	exact:   same normalized bodies, ignoring formats and comments
+	renamed: same normalized bodies after identifiers renamed, `return a + b` == `return x + y`
+	similar: similarity of syntax tree shapes >= threshold
+
+Groups are nested rather than disjoint. A weaker group contains all the members of the stronger groups it covers,
+so one function can be found in an exact, a renamed and a similar group at the same time:
+
+	exact:   sum, sumCopied
+	renamed: sum, sumCopied, total
+	similar: sum, sumCopied, total, totalPositive
+
+A renamed group is only reported if it covers more than one body,
+and a similar group only if it covers more than one renamed group.
+Filter the groups by Type to get the strongest relations only.
+*/
+func AnalyzeClones(funcFiles []*extractor.FunctionFileResult, config *CloneConfig) ([]*CloneGroup, error) {
+	if config == nil {
+		config = DefaultCloneConfig()
+	}
+
+	var candidates []*cloneCandidate
+	for _, eachFile := range funcFiles {
+		for _, eachFunc := range eachFile.Units {
+			if eachFunc.Unit == nil || funcLoc(eachFunc) < config.MinLoc {
+				continue
+			}
+			langExtractor := extractor.GetExtractor(eachFunc.Lang)
+			if langExtractor == nil {
+				continue
+			}
+			span := eachFunc.GetBodySpan()
+			candidate := &cloneCandidate{
+				FunctionWithPath: extractor.WrapFuncWithPath(eachFunc, eachFile.Path),
+				bodyHash:         eachFunc.BodyHash,
+				renamedHash:      object.Hash(object.NormalizeIdentifiers(eachFunc.Unit, span, langExtractor.IsComment)),
+			}
+			if candidate.bodyHash == "" {
+				candidate.bodyHash = object.Hash(object.NormalizeContent(eachFunc.Unit, span, langExtractor.IsComment))
+			}
+			candidate.shape, candidate.shapeTotal = shapeOf(eachFunc.Unit, span, langExtractor.IsComment)
+			candidates = append(candidates, candidate)
+		}
+	}
+	core.Log.Infof("clone candidates: %d", len(candidates))
+
+	var ret []*CloneGroup
+	exactKeys, exactGroups := groupCandidates(candidates, func(c *cloneCandidate) string {
+		return c.bodyHash
+	})
+	for _, key := range exactKeys {
+		if group := exactGroups[key]; len(group) > 1 {
+			ret = append(ret, newCloneGroup(CloneExact, 1, group))
+		}
+	}
+
+	renamedKeys, renamedGroups := groupCandidates(candidates, func(c *cloneCandidate) string {
+		return c.renamedHash
+	})
+	for _, key := range renamedKeys {
+		group := renamedGroups[key]
+		// exact clones only
+		if countBodies(group) > 1 {
+			ret = append(ret, newCloneGroup(CloneRenamed, 1, group))
+		}
+	}
+
+	// similar, compare the representatives of renamed groups
+	reps := make([]*cloneCandidate, 0, len(renamedKeys))
+	for _, key := range renamedKeys {
+		reps = append(reps, renamedGroups[key][0])
+	}
+	for _, component := range linkSimilar(reps, config.Threshold) {
+		var members []*cloneCandidate
+		for _, each := range component.members {
+			members = append(members, renamedGroups[each.renamedHash]...)
+		}
+		ret = append(ret, newCloneGroup(CloneSimilar, component.similarity, members))
+	}
+	core.Log.Infof("clone groups: %d", len(ret))
+	return ret, nil
+}
+
+func funcLoc(f *extractor.Function) int {
+	if f.Metrics != nil {
+		return f.Metrics.Loc
+	}
+	return int(f.Span.End.Row-f.Span.Start.Row) + 1
+}
+
+// groupCandidates keys are ordered by their first appearances
+func groupCandidates(candidates []*cloneCandidate, keyOf func(*cloneCandidate) string) ([]string, map[string][]*cloneCandidate) {
+	var keys []string
+	groups := make(map[string][]*cloneCandidate)
+	for _, each := range candidates {
+		key := keyOf(each)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], each)
+	}
+	return keys, groups
+}
+
+func countBodies(candidates []*cloneCandidate) int {
+	bodies := make(map[string]struct{})
+	for _, each := range candidates {
+		bodies[each.bodyHash] = struct{}{}
+	}
+	return len(bodies)
+}
+
+func newCloneGroup(cloneType CloneType, similarity float64, candidates []*cloneCandidate) *CloneGroup {
+	ret := &CloneGroup{
+		Type:       cloneType,
+		Similarity: similarity,
+		Members:    make([]*extractor.FunctionWithPath, 0, len(candidates)),
+	}
+	for _, each := range candidates {
+		ret.Members = append(ret.Members, each.FunctionWithPath)
+	}
+	return ret
+}
+
+// shapeOf count the parent-child kind pairs inside span, which ignores names, literals and formats
+func shapeOf(unit *core.Unit, span core.Span, isComment func(*core.Unit) bool) (map[string]int, int) {
+	ret := make(map[string]int)
+	total := 0
+	var walk func(*core.Unit)
+	walk = func(cur *core.Unit) {
+		for _, each := range cur.SubUnits {
+			if isComment(each) {
+				continue
+			}
+			if span.Contain(&each.Span) {
+				ret[cur.Kind+">"+each.Kind]++
+				total++
+			}
+			walk(each)
+		}
+	}
+	walk(unit)
+	return ret, total
+}
+
+type similarComponent struct {
+	members []*cloneCandidate
+	// the lowest similarity of linked pairs
+	similarity float64
+}
+
+// linkSimilar connect the candidates whose shapes are similar enough, with dice coefficient
+func linkSimilar(candidates []*cloneCandidate, threshold float64) []*similarComponent {
+	sorted := make([]*cloneCandidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].shapeTotal < sorted[j].shapeTotal
+	})
+
+	// union find
+	parents := make([]int, len(sorted))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	lowest := make(map[int]float64)
+
+	for i, a := range sorted {
+		if a.shapeTotal == 0 {
+			continue
+		}
+		for j := i + 1; j < len(sorted); j++ {
+			b := sorted[j]
+			// upper bound of similarity, and it only gets lower in the rest
+			if float64(2*a.shapeTotal)/float64(a.shapeTotal+b.shapeTotal) < threshold {
+				break
+			}
+			similarity := dice(a, b)
+			if similarity < threshold {
+				continue
+			}
+			rootA, rootB := find(i), find(j)
+			low := similarity
+			for _, root := range []int{rootA, rootB} {
+				if v, ok := lowest[root]; ok && v < low {
+					low = v
+				}
+			}
+			delete(lowest, rootA)
+			delete(lowest, rootB)
+			parents[rootB] = rootA
+			lowest[rootA] = low
+		}
+	}
+
+	var roots []int
+	components := make(map[int]*similarComponent)
+	for i, each := range sorted {
+		root := find(i)
+		similarity, ok := lowest[root]
+		if !ok {
+			continue
+		}
+		component, ok := components[root]
+		if !ok {
+			component = &similarComponent{similarity: similarity}
+			components[root] = component
+			roots = append(roots, root)
+		}
+		component.members = append(component.members, each)
+	}
+	ret := make([]*similarComponent, 0, len(roots))
+	for _, root := range roots {
+		ret = append(ret, components[root])
+	}
+	return ret
+}
+
+func dice(a *cloneCandidate, b *cloneCandidate) float64 {
+	small, large := a.shape, b.shape
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	common := 0
+	for k, v := range small {
+		if other := large[k]; other < v {
+			common += other
+		} else {
+			common += v
+		}
+	}
+	return float64(2*common) / float64(a.shapeTotal+b.shapeTotal)
+}
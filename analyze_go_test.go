@@ -56,3 +56,469 @@ func TestAnalyzeGolang(t *testing.T) {
 	assert.Empty(t, ctx.Calls)
 	assert.Len(t, ctx.ReverseCalls, 1)
 }
+
+var goTypesForMethodSet = `
+package abc
+
+type Runner interface {
+	Run(name string) error
+}
+
+type Named interface {
+	Name() string
+}
+
+type RunnerWithName interface {
+	Runner
+	Named
+}
+
+type Base struct {
+}
+
+type Job struct {
+	*Base
+	id int
+}
+`
+
+var goMethodsForMethodSet = `
+package abc
+
+func (b *Base) Name() string {
+	return "base"
+}
+
+func (b *Base) Run(name string) int {
+	return 0
+}
+
+func (j *Job) Run(name string) error {
+	return nil
+}
+`
+
+func TestAnalyzeGoMethodSets(t *testing.T) {
+	t.Parallel()
+	extractor := &golang.Extractor{}
+
+	typeUnits, err := core.NewParser(core.LangGo).Parse([]byte(goTypesForMethodSet))
+	assert.Nil(t, err)
+	clazzes, err := extractor.ExtractClasses(typeUnits)
+	assert.Nil(t, err)
+	funcUnits, err := core.NewParser(core.LangGo).Parse([]byte(goMethodsForMethodSet))
+	assert.Nil(t, err)
+	functions, err := extractor.ExtractFunctions(funcUnits)
+	assert.Nil(t, err)
+
+	clazzFile := &extractor2.ClazzFileResult{Path: "abc/types.go", Units: clazzes}
+	funcFile := &extractor2.FunctionFileResult{Path: "abc/methods.go", Units: functions}
+	sets, err := AnalyzeGoMethodSets([]*extractor2.ClazzFileResult{clazzFile}, []*extractor2.FunctionFileResult{funcFile}, nil, nil)
+	assert.Nil(t, err)
+
+	job := sets.Query("abc", "Job")
+	assert.NotNil(t, job)
+	assert.Len(t, job.Methods, 1)
+	assert.Len(t, job.Extras.(*golang.ClassExtras).Methods, 1)
+	// Base.Run is shadowed
+	assert.Len(t, job.Promoted, 1)
+	assert.Equal(t, "Name", job.Promoted[0].Name)
+	assert.Equal(t, "Name", job.Extras.(*golang.ClassExtras).Promoted[0].Name)
+	// not in any module, qualified by dirs
+	assert.ElementsMatch(t, []string{"abc.Runner", "abc.Named", "abc.RunnerWithName"}, job.Extras.(*golang.ClassExtras).Implements)
+	// inputs are untouched
+	assert.Empty(t, clazzes[4].Extras.(*golang.ClassExtras).Methods)
+	assert.NotSame(t, clazzes[4], job.Clazz)
+
+	filled := sets.FillClazzFiles([]*extractor2.ClazzFileResult{clazzFile})
+	assert.Same(t, job.Clazz, filled[0].Units[4])
+	assert.Same(t, clazzes[4], clazzFile.Units[4])
+
+	var implements []string
+	for _, each := range job.Implements {
+		implements = append(implements, each.Name)
+	}
+	assert.ElementsMatch(t, []string{"Runner", "Named", "RunnerWithName"}, implements)
+
+	// Base.Run has a different signature
+	base := sets.QueryByClazz(&extractor2.ClazzWithPath{Clazz: clazzes[3], Path: clazzFile.Path})
+	assert.Equal(t, "Base", base.Name)
+	assert.Len(t, base.Methods, 2)
+	assert.Len(t, base.Implements, 1)
+	assert.Equal(t, "Named", base.Implements[0].Name)
+}
+
+var goFilesForMethodSet = map[string]string{
+	"a/util/base.go": `
+package util
+
+type Base struct{}
+
+func (b *Base) Close() error {
+	return nil
+}
+`,
+	"b/util/base.go": `
+package util
+
+type Base struct{}
+
+func (b *Base) Open() error {
+	return nil
+}
+`,
+	"svc/server.go": `
+package svc
+
+import (
+	"github.com/x/repo/b/util"
+	io2 "github.com/x/repo/io"
+)
+
+type Server struct {
+	util.Base
+	io2.Flusher
+}
+`,
+	"io/io.go": `
+package io
+
+type Opener interface {
+	Open() error
+}
+
+type Flusher interface {
+	Flush() error
+}
+`,
+}
+
+func TestAnalyzeGoMethodSetsAcrossPackages(t *testing.T) {
+	t.Parallel()
+	extractor := &golang.Extractor{}
+
+	var clazzFiles []*extractor2.ClazzFileResult
+	var funcFiles []*extractor2.FunctionFileResult
+	var importFiles []*extractor2.ImportFileResult
+	for p, code := range goFilesForMethodSet {
+		units, err := core.NewParser(core.LangGo).Parse([]byte(code))
+		assert.Nil(t, err)
+		clazzes, err := extractor.ExtractClasses(units)
+		assert.Nil(t, err)
+		functions, err := extractor.ExtractFunctions(units)
+		assert.Nil(t, err)
+		imports, err := extractor.ExtractImports(units)
+		assert.Nil(t, err)
+		clazzFiles = append(clazzFiles, &extractor2.ClazzFileResult{Path: p, Units: clazzes})
+		funcFiles = append(funcFiles, &extractor2.FunctionFileResult{Path: p, Units: functions})
+		importFiles = append(importFiles, &extractor2.ImportFileResult{Path: p, Units: imports})
+	}
+
+	modules := GoModules{".": "github.com/x/repo"}
+	sets, err := AnalyzeGoMethodSets(clazzFiles, funcFiles, importFiles, modules)
+	assert.Nil(t, err)
+
+	// `util` is b/util by the import path, a/util has the same package name
+	server := sets.Query("svc", "Server")
+	assert.NotNil(t, server)
+	assert.Len(t, server.Promoted, 1)
+	assert.Equal(t, "Open", server.Promoted[0].Name)
+	assert.Equal(t, "b/util/base.go", server.Promoted[0].Path)
+	assert.ElementsMatch(t, []string{"github.com/x/repo/io.Opener", "github.com/x/repo/io.Flusher"}, server.Extras.(*golang.ClassExtras).Implements)
+
+	// types of other packages can not be resolved without imports
+	sets, err = AnalyzeGoMethodSets(clazzFiles, funcFiles, nil, modules)
+	assert.Nil(t, err)
+	assert.Empty(t, sets.Query("svc", "Server").Promoted)
+}
+
+func TestGoModules(t *testing.T) {
+	t.Parallel()
+	modules, err := ReadGoModules(".")
+	assert.Nil(t, err)
+	assert.Equal(t, "github.com/opensibyl/sibyl2", modules["."])
+	assert.Equal(t, "github.com/opensibyl/sibyl2", modules.ImportPath("."))
+	assert.Equal(t, "github.com/opensibyl/sibyl2/pkg/core", modules.ImportPath("pkg/core"))
+
+	modules = GoModules{".": "github.com/x/repo", "tools": "github.com/x/tools"}
+	assert.Equal(t, "github.com/x/tools/gen", modules.ImportPath("tools/gen"))
+	assert.Equal(t, "github.com/x/repo/toolsx", modules.ImportPath("toolsx"))
+	assert.Empty(t, GoModules{"sub": "github.com/x/sub"}.ImportPath("pkg"))
+}
+
+var goLiteralForAnalyze = `
+package abc
+
+func register() {
+	handle(func() {
+		called()
+	})
+}
+
+func called() {
+	return "hello"
+}
+`
+
+func TestAnalyzeGolangFuncLiteral(t *testing.T) {
+	t.Parallel()
+	units, err := core.NewParser(core.LangGo).Parse([]byte(goLiteralForAnalyze))
+	assert.Nil(t, err)
+
+	extractor := &golang.Extractor{}
+	symbols, err := extractor.ExtractSymbols(units)
+	assert.Nil(t, err)
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	symbolWrap := &extractor2.SymbolFileResult{Units: symbols}
+	functionWrap := &extractor2.FunctionFileResult{Units: functions}
+
+	g, err := AnalyzeFuncGraph([]*extractor2.FunctionFileResult{functionWrap}, []*extractor2.SymbolFileResult{symbolWrap})
+	assert.Nil(t, err)
+
+	var target *extractor2.Function
+	for _, each := range functions {
+		if each.Name == "called" {
+			target = each
+		}
+	}
+	ctx := g.FindRelated(extractor2.WrapFuncWithPath(target, ""))
+	assert.Len(t, ctx.ReverseCalls, 1)
+	// called by the literal, not the outer one
+	assert.Equal(t, "register.func1", ctx.ReverseCalls[0].Name)
+}
+
+var goCodeForClones = `
+package abc
+
+func sum(items []int) int {
+	ret := 0
+	for _, each := range items {
+		ret += each
+	}
+	return ret
+}
+
+func sumCopied(items []int) int {
+	// copied
+	ret := 0
+	for _, each := range items {
+		ret  +=  each
+	}
+	return ret
+}
+
+func total(values []int) int {
+	result := 0
+	for _, v := range values {
+		result += v
+	}
+	return result
+}
+
+func totalPositive(values []int) int {
+	result := 0
+	for _, v := range values {
+		if v > 0 {
+			result += v
+		}
+	}
+	return result
+}
+
+func hello() {
+	fmt.Println("hello")
+	fmt.Println("world")
+	fmt.Println("hello")
+	fmt.Println("world")
+}
+`
+
+func TestAnalyzeClones(t *testing.T) {
+	t.Parallel()
+	fileResult, err := ExtractFromString(goCodeForClones, &ExtractConfig{
+		LangType:    core.LangGo,
+		ExtractType: extractor2.TypeExtractFunction,
+	})
+	assert.Nil(t, err)
+	functions := make([]*extractor2.Function, 0, len(fileResult.Units))
+	for _, each := range fileResult.Units {
+		functions = append(functions, each.(*extractor2.Function))
+	}
+	funcFile := &extractor2.FunctionFileResult{Path: "abc/sum.go", Units: functions}
+
+	config := DefaultCloneConfig()
+	config.Threshold = 0.7
+	groups, err := AnalyzeClones([]*extractor2.FunctionFileResult{funcFile}, config)
+	assert.Nil(t, err)
+
+	names := func(group *CloneGroup) []string {
+		var ret []string
+		for _, each := range group.Members {
+			ret = append(ret, each.Name)
+		}
+		return ret
+	}
+	assert.Len(t, groups, 3)
+	assert.Equal(t, CloneExact, groups[0].Type)
+	assert.Equal(t, []string{"sum", "sumCopied"}, names(groups[0]))
+	assert.Equal(t, CloneRenamed, groups[1].Type)
+	assert.Equal(t, []string{"sum", "sumCopied", "total"}, names(groups[1]))
+	assert.Equal(t, CloneSimilar, groups[2].Type)
+	assert.ElementsMatch(t, []string{"sum", "sumCopied", "total", "totalPositive"}, names(groups[2]))
+	assert.Less(t, groups[2].Similarity, 1.0)
+	assert.Equal(t, "abc/sum.go", groups[2].Members[0].Path)
+}
+
+var goCodeForShadowing = `
+package abc
+
+func handle() {}
+
+func shadowed(handle int) int {
+	return handle + 1
+}
+
+func local() {
+	handle := 1
+	_ = handle
+}
+
+func caller() {
+	handle()
+}
+`
+
+func TestAnalyzeGolangShadowing(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goCodeForShadowing))
+	if err != nil {
+		panic(err)
+	}
+
+	extractor := &golang.Extractor{}
+	symbols, err := extractor.ExtractSymbols(units)
+	assert.Nil(t, err)
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	symbolWrap := &extractor2.SymbolFileResult{}
+	symbolWrap.Units = symbols
+	functionWrap := &extractor2.FunctionFileResult{}
+	functionWrap.Units = functions
+
+	g, err := AnalyzeFuncGraph([]*extractor2.FunctionFileResult{functionWrap}, []*extractor2.SymbolFileResult{symbolWrap})
+	assert.Nil(t, err)
+
+	// only the real call
+	ctx := g.FindRelated(extractor2.WrapFuncWithPath(functions[0], ""))
+	assert.Equal(t, "handle", ctx.Name)
+	assert.Len(t, ctx.ReverseCalls, 1)
+	assert.Equal(t, "caller", ctx.ReverseCalls[0].Name)
+}
+
+var goFilesForCallGraph = map[string]string{
+	"svc/server.go": `
+package svc
+
+import (
+	"fmt"
+
+	ext "github.com/other/util"
+	"github.com/x/repo/util"
+)
+
+type Server struct{}
+
+func (s *Server) Handle(req *Request) {
+	s.helper()
+	req.Validate()
+	util.Clean()
+	fmt.Println()
+	local()
+	x := build()
+	x.Execute()
+}
+
+func (s *Server) helper() {}
+
+func local() {}
+
+func purge() {
+	ext.Clean()
+}
+`,
+	"svc/request.go": `
+package svc
+
+func (r *Request) Validate() {}
+
+func (j *Job) Execute() {}
+
+func build() *Job {
+	return nil
+}
+`,
+	"util/util.go": `
+package util
+
+func Clean() {}
+
+func (o *Other) Validate() {}
+
+func Println() {}
+`,
+	"other/other.go": `
+package other
+
+func local() {}
+`,
+}
+
+func TestAnalyzeGolangCallGraph(t *testing.T) {
+	t.Parallel()
+	extractor := &golang.Extractor{}
+
+	var funcFiles []*extractor2.FunctionFileResult
+	var callFiles []*extractor2.CallFileResult
+	var importFiles []*extractor2.ImportFileResult
+	for p, code := range goFilesForCallGraph {
+		units, err := core.NewParser(core.LangGo).Parse([]byte(code))
+		assert.Nil(t, err)
+		functions, err := extractor.ExtractFunctions(units)
+		assert.Nil(t, err)
+		calls, err := extractor.ExtractCalls(units)
+		assert.Nil(t, err)
+		imports, err := extractor.ExtractImports(units)
+		assert.Nil(t, err)
+		funcFiles = append(funcFiles, &extractor2.FunctionFileResult{Path: p, Units: functions})
+		callFiles = append(callFiles, &extractor2.CallFileResult{Path: p, Units: calls})
+		importFiles = append(importFiles, &extractor2.ImportFileResult{Path: p, Units: imports})
+	}
+
+	g, err := AnalyzeCallGraph(funcFiles, callFiles, importFiles, GoModules{".": "github.com/x/repo"})
+	assert.Nil(t, err)
+
+	handle := QueryUnitsByIndexNamesInFiles(funcFiles, "Handle")[0]
+	handleWithPath := extractor2.WrapFuncWithPath(handle, "svc/server.go")
+	calls := g.FindCalls(handleWithPath)
+	confidences := make(map[string]CallConfidence)
+	for _, each := range calls {
+		confidences[each.Path+":"+each.Name] = g.GetCallConfidence(handleWithPath, each)
+	}
+	// fmt.Println, other.local and util.Other.Validate are excluded
+	assert.Equal(t, map[string]CallConfidence{
+		"svc/server.go:helper":    CallConfidenceExact,
+		"svc/request.go:Validate": CallConfidenceReceiver,
+		"util/util.go:Clean":      CallConfidenceExact,
+		"svc/server.go:local":     CallConfidenceExact,
+		"svc/request.go:build":    CallConfidenceExact,
+		"svc/request.go:Execute":  CallConfidenceName,
+	}, confidences)
+
+	// `github.com/other/util` is not util/util.go
+	clean := QueryUnitsByIndexNamesInFiles(funcFiles, "Clean")[0]
+	reverseCalls := g.FindReverseCalls(extractor2.WrapFuncWithPath(clean, "util/util.go"))
+	assert.Len(t, reverseCalls, 1)
+	assert.Equal(t, "Handle", reverseCalls[0].Name)
+}
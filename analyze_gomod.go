@@ -0,0 +1,101 @@
+package sibyl2
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const goModFile = "go.mod"
+
+// GoModules module paths of the go.mod files in a repo, dir (relative to the repo, "." for the root) -> module path.
+// Packages are imported by module paths, which can not be told from the file paths only:
+//
+//	go.mod:  module github.com/x/repo
+//	import   github.com/x/repo/pkg/util    -> pkg/util/*.go
+type GoModules map[string]string
+
+// ReadGoModules all the go.mod files under srcDir, vendor and testdata excluded
+func ReadGoModules(srcDir string) (GoModules, error) {
+	ret := make(GoModules)
+	err := filepath.WalkDir(srcDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case "vendor", "testdata", ".git":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != goModFile {
+			return nil
+		}
+		modulePath, err := readGoModulePath(p)
+		if err != nil {
+			return err
+		}
+		if modulePath == "" {
+			return nil
+		}
+		rel, err := filepath.Rel(srcDir, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		ret[filepath.ToSlash(rel)] = modulePath
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// readGoModulePath the `module` directive of a go.mod file, empty if none
+func readGoModulePath(goModPath string) (string, error) {
+	f, err := os.Open(goModPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if index := strings.Index(line, "//"); index != -1 {
+			line = strings.TrimSpace(line[:index])
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+		modulePath := fields[1]
+		// `module "github.com/x/repo"`
+		if unquoted, err := strconv.Unquote(modulePath); err == nil {
+			modulePath = unquoted
+		}
+		return modulePath, nil
+	}
+	return "", scanner.Err()
+}
+
+// ImportPath of the package in this dir, by the nearest module which contains it. Empty if none.
+func (m GoModules) ImportPath(pkgDir string) string {
+	pkgDir = path.Clean(filepath.ToSlash(pkgDir))
+	for dir := pkgDir; ; dir = path.Dir(dir) {
+		if modulePath, ok := m[dir]; ok {
+			if dir == "." {
+				return path.Join(modulePath, pkgDir)
+			}
+			return path.Join(modulePath, strings.TrimPrefix(pkgDir, dir))
+		}
+		if dir == "." || dir == "/" {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package sibyl2
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/opensibyl/sibyl2/pkg/extractor/java"
+	"golang.org/x/exp/slices"
+)
+
+/*
+AnalyzeJavaHierarchy
+
+build the class hierarchy of a repo, based on the resolved super types of java classes.
+
+	            java.lang.Runnable
+	                    |
+	com.x.Base     com.x.Job.run()  overrides  Runnable.run()
+	     \             /
+	      com.x.Job
+
+Classes with the same full name can be found in different files, eg: modules of a multi-module project.
+A super type is linked to the one nearest to its sub type by path.
+*/
+func AnalyzeJavaHierarchy(clazzFiles []*extractor.ClazzFileResult, funcFiles []*extractor.FunctionFileResult) (*ClassHierarchy, error) {
+	ret := &ClassHierarchy{
+		classes:  make(map[string]*extractor.ClazzWithPath),
+		byName:   make(map[string][]string),
+		parents:  make(map[string][]string),
+		children: make(map[string][]string),
+		methods:  make(map[string][]*extractor.FunctionWithPath),
+	}
+	// key -> full names of super types
+	superTypes := make(map[string][]string)
+	for _, eachFile := range clazzFiles {
+		for _, eachClazz := range eachFile.Units {
+			extras, ok := eachClazz.Extras.(*java.ClassExtras)
+			if !ok {
+				continue
+			}
+			key := classKey(eachFile.Path, eachClazz.GetSignature())
+			if _, ok := ret.classes[key]; !ok {
+				ret.byName[eachClazz.GetSignature()] = append(ret.byName[eachClazz.GetSignature()], key)
+			}
+			ret.classes[key] = &extractor.ClazzWithPath{
+				Clazz: eachClazz,
+				Path:  eachFile.Path,
+			}
+			superTypes[key] = extras.SuperTypes
+		}
+	}
+	for _, eachFile := range funcFiles {
+		for _, eachFunc := range eachFile.Units {
+			extras, ok := eachFunc.Extras.(*java.FunctionExtras)
+			if !ok {
+				continue
+			}
+			key := classKey(eachFile.Path, eachFunc.Receiver)
+			ret.methods[key] = append(ret.methods[key], extractor.WrapFuncWithPath(eachFunc, eachFile.Path))
+
+			// anonymous classes are not extracted as classes
+			if _, ok := superTypes[key]; ok || extras.ClassInfo == nil {
+				continue
+			}
+			superTypes[key] = extras.ClassInfo.SuperTypes
+		}
+	}
+
+	// sorted, children are in a stable order
+	keys := make([]string, 0, len(superTypes))
+	for key := range superTypes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, eachSuper := range superTypes[key] {
+			superKey := ret.resolve(eachSuper, key)
+			ret.parents[key] = append(ret.parents[key], superKey)
+			ret.children[superKey] = append(ret.children[superKey], key)
+		}
+	}
+	core.Log.Infof("class hierarchy ready, classes: %d", len(ret.classes))
+	return ret, nil
+}
+
+// classKey classes are unique in files, `path#com.x.Job`
+func classKey(p string, className string) string {
+	return p + "#" + className
+}
+
+// classNameOfKey `path#com.x.Job` -> `com.x.Job`, full names of classes outside this repo are kept
+func classNameOfKey(key string) string {
+	return key[strings.LastIndex(key, "#")+1:]
+}
+
+// resolve the key of class `className` referenced by class `from`, the nearest one by path if many.
+// Full name itself if it is not in this repo.
+func (h *ClassHierarchy) resolve(className string, from string) string {
+	candidates := h.byName[className]
+	if len(candidates) == 0 {
+		return className
+	}
+	fromPath := h.pathOfKey(from)
+	ret := candidates[0]
+	for _, each := range candidates[1:] {
+		if commonPrefixLen(h.pathOfKey(each), fromPath) > commonPrefixLen(h.pathOfKey(ret), fromPath) {
+			ret = each
+		}
+	}
+	return ret
+}
+
+func (h *ClassHierarchy) pathOfKey(key string) string {
+	if clazz, ok := h.classes[key]; ok {
+		return clazz.Path
+	}
+	// anonymous classes
+	return key[:strings.LastIndex(key, "#")]
+}
+
+// commonPrefixLen count of the same leading dirs
+func commonPrefixLen(a string, b string) int {
+	aParts := strings.Split(filepath.ToSlash(a), "/")
+	bParts := strings.Split(filepath.ToSlash(b), "/")
+	ret := 0
+	for ret < len(aParts) && ret < len(bParts) && aParts[ret] == bParts[ret] {
+		ret++
+	}
+	return ret
+}
+
+// isOverride check if sub can override target, without checking their classes
+func (h *ClassHierarchy) isOverride(sub *extractor.FunctionWithPath, target *extractor.FunctionWithPath) bool {
+	if sub.Name != target.Name || len(sub.Parameters) != len(target.Parameters) {
+		return false
+	}
+	if isConstructor(sub) || isConstructor(target) {
+		return false
+	}
+	if extras, ok := target.Extras.(*java.FunctionExtras); ok {
+		if slices.Contains(extras.Modifiers, "private") || slices.Contains(extras.Modifiers, "static") {
+			return false
+		}
+	}
+
+	// generic params in super class can be replaced by anything
+	var typeParams []string
+	if clazz, ok := h.classes[classKey(target.Path, target.Receiver)]; ok {
+		for _, each := range clazz.Extras.(*java.ClassExtras).TypeParameters {
+			typeParams = append(typeParams, strings.Fields(each)[0])
+		}
+	}
+	for i, each := range target.Parameters {
+		targetType := eraseTypeArgs(each.Type)
+		if slices.Contains(typeParams, targetType) {
+			continue
+		}
+		if targetType != eraseTypeArgs(sub.Parameters[i].Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// isConstructor constructors are never overridden
+func isConstructor(f *extractor.FunctionWithPath) bool {
+	extras, ok := f.Extras.(*java.FunctionExtras)
+	return ok && extras.IsConstructor
+}
+
+func eraseTypeArgs(typeName string) string {
+	if i := strings.Index(typeName, "<"); i != -1 {
+		if j := strings.LastIndex(typeName, ">"); j > i {
+			typeName = typeName[:i] + typeName[j+1:]
+		}
+	}
+	return strings.Join(strings.Fields(typeName), "")
+}
@@ -54,3 +54,294 @@ func TestAnalyzeJava(t *testing.T) {
 	assert.Empty(t, ctx.Calls)
 	assert.Len(t, ctx.ReverseCalls, 1)
 }
+
+var javaBaseCodeForHierarchy = `
+package com.x.base;
+
+public abstract class Base<T> implements Runnable {
+	public void run() {}
+
+	public abstract void handle(T t, String name);
+
+	private void secret() {}
+
+	// legal but confusing, a method named after its subclass
+	public void Job() {}
+}
+`
+
+var javaCodeForHierarchy = `
+package com.x;
+
+import com.x.base.Base;
+
+public class Job extends Base<Integer> {
+	public Job() {}
+
+	public void run() {}
+
+	public void handle(Integer t, String name) {}
+
+	public void handle(Integer t) {}
+
+	private void secret() {}
+
+	public static class Sub extends Job {
+		public void run() {}
+	}
+}
+`
+
+func TestAnalyzeJavaHierarchy(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	extractor := &java.Extractor{}
+
+	var clazzFiles []*extractor2.ClazzFileResult
+	var funcFiles []*extractor2.FunctionFileResult
+	for p, code := range map[string]string{"Base.java": javaBaseCodeForHierarchy, "Job.java": javaCodeForHierarchy} {
+		units, err := parser.Parse([]byte(code))
+		assert.Nil(t, err)
+		classes, err := extractor.ExtractClasses(units)
+		assert.Nil(t, err)
+		functions, err := extractor.ExtractFunctions(units)
+		assert.Nil(t, err)
+		clazzFiles = append(clazzFiles, &extractor2.ClazzFileResult{Path: p, Units: classes})
+		funcFiles = append(funcFiles, &extractor2.FunctionFileResult{Path: p, Units: functions})
+	}
+
+	h, err := AnalyzeJavaHierarchy(clazzFiles, funcFiles)
+	assert.Nil(t, err)
+	assert.Len(t, h.Query("com.x.Job"), 1)
+	assert.Empty(t, h.Query("java.lang.Runnable"))
+	assert.Equal(t, []string{"com.x.Job", "com.x.base.Base", "java.lang.Runnable"}, h.Ancestors(h.Query("com.x.Job.Sub")[0]))
+	assert.Equal(t, []string{"com.x.Job", "com.x.Job.Sub"}, h.Descendants(h.Query("com.x.base.Base")[0]))
+
+	methods := make(map[string]*extractor2.FunctionWithPath)
+	for _, eachFile := range funcFiles {
+		for _, each := range eachFile.Units {
+			methods[each.GetSignature()] = extractor2.WrapFuncWithPath(each, eachFile.Path)
+		}
+	}
+	find := func(receiver string, name string, paramCount int) *extractor2.FunctionWithPath {
+		for _, each := range methods {
+			if each.Receiver == receiver && each.Name == name && len(each.Parameters) == paramCount {
+				return each
+			}
+		}
+		return nil
+	}
+
+	// generic param `T` accepts `Integer`
+	handle := find("com.x.Job", "handle", 2)
+	overrides := h.Overrides(handle)
+	assert.Len(t, overrides, 1)
+	assert.Equal(t, "com.x.base.Base", overrides[0].Receiver)
+	assert.Empty(t, h.Overrides(find("com.x.Job", "handle", 1)))
+	// private methods can not be overridden
+	assert.Empty(t, h.Overrides(find("com.x.Job", "secret", 0)))
+	// constructors never override
+	constructor := find("com.x.Job", "Job", 0)
+	assert.True(t, constructor.Extras.(*java.FunctionExtras).IsConstructor)
+	assert.Empty(t, h.Overrides(constructor))
+	assert.Empty(t, h.OverriddenBy(find("com.x.base.Base", "Job", 0)))
+
+	assert.Len(t, h.Overrides(find("com.x.Job.Sub", "run", 0)), 2)
+	assert.Len(t, h.OverriddenBy(find("com.x.base.Base", "run", 0)), 2)
+}
+
+var javaFilesForSameNames = map[string]string{
+	"a/src/com/x/Base.java": `
+package com.x;
+
+public class Base {
+	public void run() {}
+}
+`,
+	"a/src/com/x/Job.java": `
+package com.x;
+
+public class Job extends Base {
+	public void run() {}
+}
+`,
+	"b/src/com/x/Base.java": `
+package com.x;
+
+public class Base {
+	public void run() {}
+}
+`,
+	"b/src/com/x/Worker.java": `
+package com.x;
+
+public class Worker extends Base {
+	public void run() {}
+}
+`,
+}
+
+func TestAnalyzeJavaHierarchySameNames(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	extractor := &java.Extractor{}
+
+	var clazzFiles []*extractor2.ClazzFileResult
+	var funcFiles []*extractor2.FunctionFileResult
+	methods := make(map[string]*extractor2.FunctionWithPath)
+	for p, code := range javaFilesForSameNames {
+		units, err := parser.Parse([]byte(code))
+		assert.Nil(t, err)
+		classes, err := extractor.ExtractClasses(units)
+		assert.Nil(t, err)
+		functions, err := extractor.ExtractFunctions(units)
+		assert.Nil(t, err)
+		clazzFiles = append(clazzFiles, &extractor2.ClazzFileResult{Path: p, Units: classes})
+		funcFiles = append(funcFiles, &extractor2.FunctionFileResult{Path: p, Units: functions})
+		methods[p] = extractor2.WrapFuncWithPath(functions[0], p)
+	}
+
+	h, err := AnalyzeJavaHierarchy(clazzFiles, funcFiles)
+	assert.Nil(t, err)
+	// two modules, both have com.x.Base
+	bases := h.Query("com.x.Base")
+	assert.Len(t, bases, 2)
+	for _, each := range bases {
+		if each.Path == "a/src/com/x/Base.java" {
+			assert.Equal(t, []string{"com.x.Job"}, h.Descendants(each))
+		} else {
+			assert.Equal(t, []string{"com.x.Worker"}, h.Descendants(each))
+		}
+	}
+
+	overrides := h.Overrides(methods["a/src/com/x/Job.java"])
+	assert.Len(t, overrides, 1)
+	assert.Equal(t, "a/src/com/x/Base.java", overrides[0].Path)
+	overriddenBy := h.OverriddenBy(methods["b/src/com/x/Base.java"])
+	assert.Len(t, overriddenBy, 1)
+	assert.Equal(t, "b/src/com/x/Worker.java", overriddenBy[0].Path)
+}
+
+var javaCodeForOverload = `
+package com.x;
+
+public class Worker {
+	public void process(String a) {
+	}
+
+	public void process(String a, int b) {
+		process(a);
+	}
+
+	public void process(Object a, int b) {
+	}
+
+	public void process(String a, String... b) {
+	}
+
+	public void runOne() {
+		process("a");
+	}
+
+	public void runTwo() {
+		process("a", 1);
+	}
+
+	public void runThree() {
+		process("a", "b", "c");
+	}
+
+	public void runRef() {
+		list.forEach(this::process);
+	}
+}
+`
+
+func TestAnalyzeJavaOverload(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaCodeForOverload))
+	assert.Nil(t, err)
+
+	extractor := &java.Extractor{}
+	symbols, err := extractor.ExtractSymbols(units)
+	assert.Nil(t, err)
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	funcFiles := []*extractor2.FunctionFileResult{{Path: "Worker.java", Units: functions}}
+	symbolFiles := []*extractor2.SymbolFileResult{{Path: "Worker.java", Units: symbols}}
+	callFiles := []*extractor2.CallFileResult{{Path: "Worker.java", Units: calls}}
+
+	// find by types of params
+	find := func(name string, paramTypes ...string) *extractor2.FunctionWithPath {
+		for _, each := range functions {
+			if each.Name != name || len(each.Parameters) != len(paramTypes) {
+				continue
+			}
+			matched := true
+			for i, eachParam := range each.Parameters {
+				matched = matched && eachParam.Type == paramTypes[i]
+			}
+			if matched {
+				return extractor2.WrapFuncWithPath(each, "Worker.java")
+			}
+		}
+		return nil
+	}
+	one := find("process", "String")
+	two := find("process", "String", "int")
+	twoObject := find("process", "Object", "int")
+	variadic := find("process", "String", "String...")
+	runOne, runTwo, runThree, runRef := find("runOne"), find("runTwo"), find("runThree"), find("runRef")
+	reverseCalls := func(g *FuncGraph, f *extractor2.FunctionWithPath) []string {
+		var ret []string
+		for _, each := range g.FindReverseCalls(f) {
+			ret = append(ret, each.Name)
+		}
+		return ret
+	}
+
+	for _, analyze := range []func() (*FuncGraph, error){
+		func() (*FuncGraph, error) {
+			return AnalyzeFuncGraphWithCalls(funcFiles, symbolFiles, callFiles)
+		},
+		func() (*FuncGraph, error) {
+			imports := []*extractor2.ImportFileResult{{Path: "Worker.java"}}
+			return AnalyzeCallGraph(funcFiles, callFiles, imports, nil)
+		},
+	} {
+		g, err := analyze()
+		assert.Nil(t, err)
+
+		// `process(a)` in another overload is not a recursion
+		assert.Contains(t, reverseCalls(g, one), "runOne")
+		assert.Contains(t, reverseCalls(g, one), "process")
+		assert.False(t, g.IsAmbiguousCall(runOne, one))
+
+		// both of them accept `process("a", 1)`
+		assert.Contains(t, reverseCalls(g, two), "runTwo")
+		assert.Contains(t, reverseCalls(g, twoObject), "runTwo")
+		assert.True(t, g.IsAmbiguousCall(runTwo, two))
+		assert.True(t, g.IsAmbiguousCall(runTwo, twoObject))
+
+		assert.Contains(t, reverseCalls(g, variadic), "runThree")
+		assert.NotContains(t, reverseCalls(g, variadic), "runOne")
+		assert.False(t, g.IsAmbiguousCall(runThree, variadic))
+		assert.NotContains(t, reverseCalls(g, two), "runOne")
+		assert.NotContains(t, reverseCalls(g, two), "runThree")
+	}
+
+	// method references can not be told
+	g, err := AnalyzeFuncGraphWithCalls(funcFiles, symbolFiles, callFiles)
+	assert.Nil(t, err)
+	assert.Contains(t, reverseCalls(g, two), "runRef")
+	assert.True(t, g.IsAmbiguousCall(runRef, two))
+
+	// all the overloads without calls
+	g, err = AnalyzeFuncGraph(funcFiles, symbolFiles)
+	assert.Nil(t, err)
+	assert.Contains(t, reverseCalls(g, two), "runOne")
+	assert.True(t, g.IsAmbiguousCall(runOne, one))
+}
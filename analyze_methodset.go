@@ -0,0 +1,316 @@
+package sibyl2
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/opensibyl/sibyl2/pkg/extractor/golang"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+// goPackage all the named types and methods in one package (dir)
+type goPackage struct {
+	name string
+	// import path, or dir if it is not in any module
+	importPath string
+	types      map[string]*extractor.ClazzWithPath
+	methods    map[string][]*extractor.FunctionWithPath
+}
+
+// qualify type name with the import path of this package, eg: `github.com/x/repo/pkg/io.Reader`
+func (pkg *goPackage) qualify(typeName string) string {
+	return pkg.importPath + "." + typeName
+}
+
+// goPackages packages by dirs, and the imports of their files which qualified type names come from
+type goPackages struct {
+	byDir map[string]*goPackage
+	// import path -> dir
+	dirs map[string]string
+	// file path -> imports
+	imports map[string][]*extractor.Import
+}
+
+func goPackageDir(p string) string {
+	return path.Dir(filepath.ToSlash(p))
+}
+
+/*
+AnalyzeGoMethodSets
+
+link go types to their methods across all the files of a package.
+
+	files:    a.go          b.go
+	          type A struct  func (a *A) Run()
+	                   \       /
+	              A: {Run} -> satisfies `Runner`
+
+Types of other packages (`pkg.Name`) are resolved by the imports of the file where they appeared,
+so importFiles and modules are needed for embedded types and interfaces across packages.
+Both can be nil, then only the types in the same package will be linked.
+
+Inputs are never modified. Method sets carry copies of the types,
+whose `golang.ClassExtras` are filled with Methods, Promoted and Implements.
+*/
+func AnalyzeGoMethodSets(clazzFiles []*extractor.ClazzFileResult, funcFiles []*extractor.FunctionFileResult, importFiles []*extractor.ImportFileResult, modules GoModules) (*GoMethodSets, error) {
+	packages := &goPackages{
+		byDir:   make(map[string]*goPackage),
+		dirs:    make(map[string]string),
+		imports: make(map[string][]*extractor.Import, len(importFiles)),
+	}
+	getPackage := func(filePath string, name string) *goPackage {
+		dir := goPackageDir(filePath)
+		pkg, ok := packages.byDir[dir]
+		if !ok {
+			pkg = &goPackage{
+				name:       name,
+				importPath: modules.ImportPath(dir),
+				types:      make(map[string]*extractor.ClazzWithPath),
+				methods:    make(map[string][]*extractor.FunctionWithPath),
+			}
+			if pkg.importPath == "" {
+				pkg.importPath = dir
+			} else {
+				packages.dirs[pkg.importPath] = dir
+			}
+			packages.byDir[dir] = pkg
+		}
+		return pkg
+	}
+
+	for _, eachFile := range clazzFiles {
+		for _, eachClazz := range eachFile.Units {
+			if eachClazz.Lang != core.LangGo {
+				continue
+			}
+			extras, ok := eachClazz.Extras.(*golang.ClassExtras)
+			if !ok {
+				continue
+			}
+			clazz := *eachClazz
+			extrasCopy := *extras
+			clazz.Extras = &extrasCopy
+			pkg := getPackage(eachFile.Path, eachClazz.Module)
+			pkg.types[eachClazz.Name] = &extractor.ClazzWithPath{
+				Clazz: &clazz,
+				Path:  eachFile.Path,
+			}
+		}
+	}
+	for _, eachFile := range funcFiles {
+		for _, eachFunc := range eachFile.Units {
+			if eachFunc.Lang != core.LangGo || eachFunc.Receiver == "" {
+				continue
+			}
+			pkg := getPackage(eachFile.Path, eachFunc.Namespace)
+			pkg.methods[eachFunc.Receiver] = append(pkg.methods[eachFunc.Receiver], extractor.WrapFuncWithPath(eachFunc, eachFile.Path))
+		}
+	}
+	for _, eachFile := range importFiles {
+		packages.imports[eachFile.Path] = eachFile.Units
+	}
+	core.Log.Infof("go packages collected: %d", len(packages.byDir))
+
+	// link declared methods
+	for _, pkg := range packages.byDir {
+		for typeName, eachType := range pkg.types {
+			extras := eachType.Extras.(*golang.ClassExtras)
+			if extras.Kind == golang.ClassKindInterface {
+				continue
+			}
+			extras.Methods = nil
+			for _, eachMethod := range pkg.methods[typeName] {
+				extras.Methods = append(extras.Methods, func2Method(eachMethod.Function))
+			}
+		}
+	}
+
+	var interfaces []*extractor.ClazzWithPath
+	for dir, pkg := range packages.byDir {
+		for _, eachType := range pkg.types {
+			extras := eachType.Extras.(*golang.ClassExtras)
+			// constraints and `any` are not interesting
+			if extras.Kind != golang.ClassKindInterface || len(extras.Constraints) != 0 {
+				continue
+			}
+			if len(interfaceMethods(packages, dir, eachType, make(map[string]bool))) == 0 {
+				continue
+			}
+			interfaces = append(interfaces, eachType)
+		}
+	}
+
+	ret := &GoMethodSets{data: make(map[string]map[string]*GoMethodSet, len(packages.byDir))}
+	for dir, pkg := range packages.byDir {
+		ret.data[dir] = make(map[string]*GoMethodSet, len(pkg.types))
+		for typeName, eachType := range pkg.types {
+			methodSet, signatures := collectMethodSet(packages, dir, eachType)
+			for _, eachInterface := range interfaces {
+				if eachInterface == eachType {
+					continue
+				}
+				if satisfies(signatures, interfaceMethods(packages, goPackageDir(eachInterface.Path), eachInterface, make(map[string]bool))) {
+					methodSet.Implements = append(methodSet.Implements, eachInterface)
+				}
+			}
+			ret.data[dir][typeName] = methodSet
+		}
+	}
+
+	// promoted methods and interfaces are only known after all the method sets collected
+	for _, types := range ret.data {
+		for _, methodSet := range types {
+			extras := methodSet.Extras.(*golang.ClassExtras)
+			extras.Promoted = nil
+			for _, each := range methodSet.Promoted {
+				extras.Promoted = append(extras.Promoted, func2Method(each.Function))
+			}
+			extras.Implements = nil
+			for _, each := range methodSet.Implements {
+				extras.Implements = append(extras.Implements, packages.byDir[goPackageDir(each.Path)].qualify(each.Name))
+			}
+		}
+	}
+	return ret, nil
+}
+
+func func2Method(f *extractor.Function) *golang.Method {
+	return &golang.Method{
+		Name:       f.Name,
+		Parameters: f.Parameters,
+		Returns:    f.Returns,
+	}
+}
+
+// resolveGoType find the type referenced by `Name` or `pkg.Name` in the file of `from`
+func resolveGoType(packages *goPackages, from *extractor.ClazzWithPath, ref string) (string, *extractor.ClazzWithPath) {
+	pkgName, typeName, qualified := strings.Cut(ref, ".")
+	if !qualified {
+		dir := goPackageDir(from.Path)
+		if pkg, ok := packages.byDir[dir]; ok {
+			return dir, pkg.types[ref]
+		}
+		return "", nil
+	}
+	// `import b "github.com/x/repo/a"` -> b.Name
+	for _, eachImport := range packages.imports[from.Path] {
+		dir, ok := packages.dirs[eachImport.Source]
+		if !ok {
+			continue
+		}
+		pkg := packages.byDir[dir]
+		localName := eachImport.Alias
+		if localName == "" {
+			localName = pkg.name
+		}
+		if localName != pkgName {
+			continue
+		}
+		if t, ok := pkg.types[typeName]; ok {
+			return dir, t
+		}
+		return "", nil
+	}
+	return "", nil
+}
+
+// collectMethodSet declared methods first, and then the promoted ones level by level.
+func collectMethodSet(packages *goPackages, dir string, target *extractor.ClazzWithPath) (*GoMethodSet, map[string]*golang.Method) {
+	ret := &GoMethodSet{ClazzWithPath: target}
+	signatures := make(map[string]*golang.Method)
+	extras := target.Extras.(*golang.ClassExtras)
+	if extras.Kind == golang.ClassKindInterface {
+		for _, each := range interfaceMethods(packages, dir, target, make(map[string]bool)) {
+			signatures[each.Name] = each
+		}
+		return ret, signatures
+	}
+
+	for _, each := range packages.byDir[dir].methods[target.Name] {
+		ret.Methods = append(ret.Methods, each)
+		signatures[each.Name] = func2Method(each.Function)
+	}
+
+	visited := map[*extractor.ClazzWithPath]bool{target: true}
+	current := []*extractor.ClazzWithPath{target}
+	for len(current) != 0 {
+		var next []*extractor.ClazzWithPath
+		// methods in the same depth do not shadow each other
+		found := make(map[string]*golang.Method)
+		for _, eachLevel := range current {
+			for _, eachEmbedded := range eachLevel.Extras.(*golang.ClassExtras).Embedded {
+				embeddedDir, embedded := resolveGoType(packages, eachLevel, eachEmbedded)
+				if embedded == nil || visited[embedded] {
+					continue
+				}
+				visited[embedded] = true
+				next = append(next, embedded)
+
+				if embedded.Extras.(*golang.ClassExtras).Kind == golang.ClassKindInterface {
+					for _, each := range interfaceMethods(packages, embeddedDir, embedded, make(map[string]bool)) {
+						if _, ok := signatures[each.Name]; !ok {
+							found[each.Name] = each
+						}
+					}
+					continue
+				}
+				for _, each := range packages.byDir[embeddedDir].methods[embedded.Name] {
+					if _, ok := signatures[each.Name]; ok {
+						continue
+					}
+					ret.Promoted = append(ret.Promoted, each)
+					found[each.Name] = func2Method(each.Function)
+				}
+			}
+		}
+		for k, v := range found {
+			signatures[k] = v
+		}
+		current = next
+	}
+	return ret, signatures
+}
+
+// interfaceMethods all the methods required by an interface, including embedded interfaces
+func interfaceMethods(packages *goPackages, dir string, target *extractor.ClazzWithPath, visited map[string]bool) []*golang.Method {
+	key := dir + "|" + target.Name
+	if visited[key] {
+		return nil
+	}
+	visited[key] = true
+
+	extras := target.Extras.(*golang.ClassExtras)
+	ret := append([]*golang.Method{}, extras.Methods...)
+	for _, eachEmbedded := range extras.Embedded {
+		embeddedDir, embedded := resolveGoType(packages, target, eachEmbedded)
+		if embedded == nil || embedded.Extras.(*golang.ClassExtras).Kind != golang.ClassKindInterface {
+			continue
+		}
+		ret = append(ret, interfaceMethods(packages, embeddedDir, embedded, visited)...)
+	}
+	return ret
+}
+
+func satisfies(signatures map[string]*golang.Method, required []*golang.Method) bool {
+	for _, each := range required {
+		impl, ok := signatures[each.Name]
+		if !ok {
+			return false
+		}
+		if typesOf(impl.Parameters) != typesOf(each.Parameters) || typesOf(impl.Returns) != typesOf(each.Returns) {
+			return false
+		}
+	}
+	return true
+}
+
+func typesOf(values []*object.ValueUnit) string {
+	types := make([]string, len(values))
+	for i, each := range values {
+		types[i] = strings.Join(strings.Fields(each.Type), "")
+	}
+	return strings.Join(types, ",")
+}
@@ -0,0 +1,85 @@
+package sibyl2
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+// overloadMatch a function picked from its overloads
+type overloadMatch struct {
+	*funcVertex
+	// other overloads match as well, or none of them matches
+	ambiguous bool
+}
+
+// overloadKey functions with the same key are overloads of each other
+func overloadKey(f *funcVertex) string {
+	// receivers of java and kotlin are full qualified
+	if f.Receiver != "" && (f.Lang == core.LangJava || f.Lang == core.LangKotlin) {
+		return f.Receiver + "|" + f.Name
+	}
+	return f.Path + "|" + f.Receiver + "|" + f.Name
+}
+
+// arityScore how well it matches this count of arguments: 2 exactly, 1 with defaults or varargs, 0 not at all
+func arityScore(f *funcVertex, argCount int) int {
+	if !f.AcceptArgs(argCount) {
+		return 0
+	}
+	if minCount, maxCount := f.Arity(); minCount == maxCount {
+		return 2
+	}
+	return 1
+}
+
+/*
+pickOverloads the best matching ones by count of arguments, in each group of overloads
+
+	process(String a)            process("a")      -> process(String a)
+	process(String a, int b)     process("a", 1)   -> both of process(String a, int b) and process(Object a, int b), ambiguous
+	process(Object a, int b)     process()         -> all of them, ambiguous
+
+argCount < 0 means unknown, eg: method references, then all of them are ambiguous.
+*/
+func pickOverloads(candidates []*funcVertex, argCount int) []*overloadMatch {
+	groups := make(map[string][]*funcVertex)
+	var keys []string
+	for _, each := range candidates {
+		key := overloadKey(each)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], each)
+	}
+
+	ret := make([]*overloadMatch, 0, len(candidates))
+	for _, key := range keys {
+		overloads := groups[key]
+		if len(overloads) == 1 {
+			ret = append(ret, &overloadMatch{overloads[0], false})
+			continue
+		}
+
+		var best []*funcVertex
+		if argCount >= 0 {
+			bestScore := 0
+			for _, each := range overloads {
+				score := arityScore(each, argCount)
+				switch {
+				case score == 0 || score < bestScore:
+				case score > bestScore:
+					bestScore = score
+					best = []*funcVertex{each}
+				default:
+					best = append(best, each)
+				}
+			}
+		}
+		if len(best) == 0 {
+			best = overloads
+		}
+		for _, each := range best {
+			ret = append(ret, &overloadMatch{each, len(best) != 1})
+		}
+	}
+	return ret
+}
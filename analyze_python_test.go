@@ -0,0 +1,97 @@
+package sibyl2
+
+import (
+	"testing"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	extractor2 "github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/opensibyl/sibyl2/pkg/extractor/python"
+	"github.com/stretchr/testify/assert"
+)
+
+var pythonFilesForCallGraph = map[string]string{
+	"app/service/handler.py": `
+from . import util
+from .helpers import clean
+from ..core.db import connect
+
+def handle():
+    util.run_all()
+    clean()
+    connect()
+`,
+	"app/service/util.py": `
+def run_all():
+    pass
+`,
+	"app/service/helpers.py": `
+def clean():
+    pass
+`,
+	"app/core/db/__init__.py": `
+def connect():
+    pass
+`,
+	// same names somewhere else
+	"util.py": `
+def run_all():
+    pass
+`,
+	"other/service/helpers.py": `
+def clean():
+    pass
+`,
+	"core/db.py": `
+def connect():
+    pass
+`,
+}
+
+func TestAnalyzePythonCallGraph(t *testing.T) {
+	t.Parallel()
+	extractor := &python.Extractor{}
+
+	var funcFiles []*extractor2.FunctionFileResult
+	var callFiles []*extractor2.CallFileResult
+	var importFiles []*extractor2.ImportFileResult
+	for p, code := range pythonFilesForCallGraph {
+		units, err := core.NewParser(core.LangPython).Parse([]byte(code))
+		assert.Nil(t, err)
+		functions, err := extractor.ExtractFunctions(units)
+		assert.Nil(t, err)
+		imports, err := extractor.ExtractImports(units)
+		assert.Nil(t, err)
+		funcFiles = append(funcFiles, &extractor2.FunctionFileResult{Path: p, Units: functions})
+		importFiles = append(importFiles, &extractor2.ImportFileResult{Path: p, Units: imports})
+	}
+
+	// calls of python can not be extracted yet
+	var calls []*extractor2.Call
+	for i, each := range []string{"util.run_all", "clean", "connect"} {
+		row := uint32(6 + i)
+		calls = append(calls, &extractor2.Call{
+			Caller: each,
+			Span: core.Span{
+				Start: core.Point{Row: row, Column: 4},
+				End:   core.Point{Row: row, Column: uint32(6 + len(each))},
+			},
+		})
+	}
+	callFiles = append(callFiles, &extractor2.CallFileResult{Path: "app/service/handler.py", Units: calls})
+
+	g, err := AnalyzeCallGraph(funcFiles, callFiles, importFiles, nil)
+	assert.Nil(t, err)
+
+	handle := QueryUnitsByIndexNamesInFiles(funcFiles, "handle")[0]
+	handleWithPath := extractor2.WrapFuncWithPath(handle, "app/service/handler.py")
+	confidences := make(map[string]CallConfidence)
+	for _, each := range g.FindCalls(handleWithPath) {
+		confidences[each.Path+":"+each.Name] = g.GetCallConfidence(handleWithPath, each)
+	}
+	// relative to the package of handler.py
+	assert.Equal(t, map[string]CallConfidence{
+		"app/service/util.py:run_all":     CallConfidenceExact,
+		"app/service/helpers.py:clean":    CallConfidenceExact,
+		"app/core/db/__init__.py:connect": CallConfidenceExact,
+	}, confidences)
+}
@@ -2,10 +2,12 @@ package sibyl2
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAnalyzeFuncGraph(t *testing.T) {
@@ -59,3 +61,116 @@ func TestAnalyzeFuncGraph2(t *testing.T) {
 		core.Log.Infof("ref: %s", each.Name)
 	}
 }
+
+const (
+	benchFuncsPerFile = 20
+	benchRefsPerFunc  = 5
+)
+
+// newFuncGraphBenchData files of functions, each one references the next `benchRefsPerFunc` functions
+func newFuncGraphBenchData(funcCount int) ([]*extractor.FunctionFileResult, []*extractor.SymbolFileResult) {
+	var funcFiles []*extractor.FunctionFileResult
+	var symbolFiles []*extractor.SymbolFileResult
+	for fileIndex := 0; fileIndex*benchFuncsPerFile < funcCount; fileIndex++ {
+		p := fmt.Sprintf("pkg%d/file%d.go", fileIndex%100, fileIndex)
+		funcFile := &extractor.FunctionFileResult{Path: p, Language: core.LangGo}
+		symbolFile := &extractor.SymbolFileResult{Path: p, Language: core.LangGo}
+		for i := 0; i < benchFuncsPerFile && fileIndex*benchFuncsPerFile+i < funcCount; i++ {
+			id := fileIndex*benchFuncsPerFile + i
+			start := uint32(i * (benchRefsPerFunc + 2))
+			span := core.Span{
+				Start: core.Point{Row: start},
+				End:   core.Point{Row: start + benchRefsPerFunc + 1, Column: 1},
+			}
+			funcFile.Units = append(funcFile.Units, &extractor.Function{
+				Name:      fmt.Sprintf("func%d", id),
+				Namespace: "bench",
+				Span:      span,
+				BodySpan:  span,
+				Lang:      core.LangGo,
+			})
+			for j := 1; j <= benchRefsPerFunc; j++ {
+				row := start + uint32(j)
+				symbolFile.Units = append(symbolFile.Units, &extractor.Symbol{
+					Symbol: fmt.Sprintf("func%d", (id+j)%funcCount),
+					Span: core.Span{
+						Start: core.Point{Row: row, Column: 4},
+						End:   core.Point{Row: row, Column: 12},
+					},
+				})
+			}
+		}
+		funcFiles = append(funcFiles, funcFile)
+		symbolFiles = append(symbolFiles, symbolFile)
+	}
+	return funcFiles, symbolFiles
+}
+
+func TestAnalyzeFuncGraphIndex(t *testing.T) {
+	t.Parallel()
+	functions, symbols := newFuncGraphBenchData(1000)
+	g, err := AnalyzeFuncGraph(functions, symbols)
+	assert.Nil(t, err)
+
+	for _, eachFile := range functions {
+		for _, eachFunc := range eachFile.Units {
+			ctx := g.FindRelated(extractor.WrapFuncWithPath(eachFunc, eachFile.Path))
+			assert.Len(t, ctx.Calls, benchRefsPerFunc)
+			assert.Len(t, ctx.ReverseCalls, benchRefsPerFunc)
+		}
+	}
+}
+
+func TestAnalyzeFuncGraphDuplicatedFuncs(t *testing.T) {
+	t.Parallel()
+	functions, symbols := newFuncGraphBenchData(100)
+	// functions with the same descriptions, eg: a file extracted twice
+	functions = append(functions, functions[0])
+	g, err := AnalyzeFuncGraph(functions, symbols)
+	assert.Nil(t, err)
+
+	for _, eachFunc := range functions[0].Units {
+		ctx := g.FindRelated(extractor.WrapFuncWithPath(eachFunc, functions[0].Path))
+		assert.Len(t, ctx.Calls, benchRefsPerFunc)
+		assert.Len(t, ctx.ReverseCalls, benchRefsPerFunc)
+	}
+}
+
+func BenchmarkAnalyzeFuncGraph(b *testing.B) {
+	symbols, _ := ExtractSymbol(".", DefaultConfig())
+	functions, _ := ExtractFunction(".", DefaultConfig())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := AnalyzeFuncGraph(functions, symbols)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+/*
+BenchmarkAnalyzeFuncGraphScale graph building should be near-linear in symbols
+
+	funcs-1000:     34078068 ns/op     93338 allocs/op
+	funcs-3000:    107295315 ns/op    279462 allocs/op
+	funcs-10000:   417522544 ns/op    931124 allocs/op
+	funcs-30000:  1533978749 ns/op   2793493 allocs/op
+	funcs-100000: 5000428472 ns/op   9306999 allocs/op
+
+Allocations are linear, and so are the index and refs stages (x65 from 1k to 100k).
+The rest (x170) is spent in AddEdge of the graph library, whose maps of edges keyed by
+long descriptions keep growing and missing the cpu caches.
+*/
+func BenchmarkAnalyzeFuncGraphScale(b *testing.B) {
+	for _, funcCount := range []int{1000, 3000, 10000, 30000, 100000} {
+		functions, symbols := newFuncGraphBenchData(funcCount)
+		b.Run(fmt.Sprintf("funcs-%d", funcCount), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := AnalyzeFuncGraph(functions, symbols)
+				if err != nil {
+					panic(err)
+				}
+			}
+		})
+	}
+}
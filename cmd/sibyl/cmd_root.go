@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/opensibyl/sibyl2"
+	"github.com/opensibyl/sibyl2/cmd/sibyl/subs/clones"
 	"github.com/opensibyl/sibyl2/cmd/sibyl/subs/diff"
 	"github.com/opensibyl/sibyl2/cmd/sibyl/subs/extract"
 	"github.com/opensibyl/sibyl2/cmd/sibyl/subs/frontend"
@@ -53,4 +54,7 @@ func init() {
 
 	frontendCmd := frontend.NewFrontendCmd()
 	rootCmd.AddCommand(frontendCmd)
+
+	clonesCmd := clones.NewClonesCmd()
+	rootCmd.AddCommand(clonesCmd)
 }
@@ -0,0 +1,64 @@
+package clones
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opensibyl/sibyl2"
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/spf13/cobra"
+)
+
+var clonesSrc string
+var clonesThreshold float64
+var clonesMinLoc int
+var clonesOutputFile string
+
+func NewClonesCmd() *cobra.Command {
+	clonesCmd := &cobra.Command{
+		Use:    "clones",
+		Short:  "find duplicated functions",
+		Long:   `find exact, identifier-renamed and similar functions in a repo`,
+		Hidden: false,
+		Run: func(cmd *cobra.Command, args []string) {
+			srcDir, err := filepath.Abs(clonesSrc)
+			if err != nil {
+				panic(err)
+			}
+			functions, err := sibyl2.ExtractFunction(srcDir, sibyl2.DefaultConfig())
+			if err != nil {
+				panic(err)
+			}
+
+			config := sibyl2.DefaultCloneConfig()
+			config.Threshold = clonesThreshold
+			config.MinLoc = clonesMinLoc
+			groups, err := sibyl2.AnalyzeClones(functions, config)
+			if err != nil {
+				panic(err)
+			}
+
+			output, err := json.MarshalIndent(groups, "", "  ")
+			if err != nil {
+				panic(err)
+			}
+			if clonesOutputFile == "" {
+				clonesOutputFile = fmt.Sprintf("sibyl-clones-%d.json", time.Now().Unix())
+			}
+			err = os.WriteFile(clonesOutputFile, output, 0644)
+			if err != nil {
+				panic(err)
+			}
+			core.Log.Infof("file has been saved to: %s", clonesOutputFile)
+		},
+	}
+	defaultConfig := sibyl2.DefaultCloneConfig()
+	clonesCmd.PersistentFlags().StringVar(&clonesSrc, "src", ".", "src dir path")
+	clonesCmd.PersistentFlags().Float64Var(&clonesThreshold, "threshold", defaultConfig.Threshold, "similarity (0-1) of near clones")
+	clonesCmd.PersistentFlags().IntVar(&clonesMinLoc, "minLoc", defaultConfig.MinLoc, "ignore functions smaller than this")
+	clonesCmd.PersistentFlags().StringVar(&clonesOutputFile, "output", "", "output json file")
+	return clonesCmd
+}
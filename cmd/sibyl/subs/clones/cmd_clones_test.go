@@ -0,0 +1,95 @@
+package clones
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opensibyl/sibyl2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClones(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "clones.json")
+	cmd := NewClonesCmd()
+	cmd.SetArgs([]string{"--src", "../../../../pkg/extractor", "--output", output})
+	err := cmd.Execute()
+	if err != nil {
+		panic(err)
+	}
+
+	data, err := os.ReadFile(output)
+	assert.Nil(t, err)
+	var groups []*sibyl2.CloneGroup
+	err = json.Unmarshal(data, &groups)
+	assert.Nil(t, err)
+	// extractors of different languages have similar functions
+	assert.NotEmpty(t, groups)
+	for _, each := range groups {
+		assert.GreaterOrEqual(t, len(each.Members), 2)
+		assert.NotEmpty(t, each.Members[0].Path)
+	}
+}
+
+var sumCode = `package a
+
+func sum(items []int) int {
+	ret := 0
+	for _, each := range items {
+		ret += each
+	}
+	return ret
+}
+`
+
+var totalCode = `package b
+
+import "fmt"
+
+// total copied from a.sum
+func total(values []int) int {
+	result := 0
+	for _, v := range values {
+		result += v
+	}
+	return result
+}
+
+func hello() {
+	fmt.Println("hello")
+}
+`
+
+func TestClonesOutput(t *testing.T) {
+	src := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(src, "a"), 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(src, "b"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(src, "a", "sum.go"), []byte(sumCode), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(src, "b", "total.go"), []byte(totalCode), 0644))
+
+	output := filepath.Join(t.TempDir(), "clones.json")
+	cmd := NewClonesCmd()
+	cmd.SetArgs([]string{"--src", src, "--output", output})
+	assert.Nil(t, cmd.Execute())
+
+	data, err := os.ReadFile(output)
+	assert.Nil(t, err)
+	var groups []*sibyl2.CloneGroup
+	assert.Nil(t, json.Unmarshal(data, &groups))
+	assert.Len(t, groups, 1)
+	assert.Equal(t, sibyl2.CloneRenamed, groups[0].Type)
+	assert.Equal(t, 1.0, groups[0].Similarity)
+
+	// paths relative to src, and spans of the whole functions, rows start from 0
+	members := groups[0].Members
+	assert.Len(t, members, 2)
+	assert.Equal(t, "a/sum.go", filepath.ToSlash(members[0].Path))
+	assert.Equal(t, "sum", members[0].Name)
+	assert.Equal(t, uint32(2), members[0].Span.Start.Row)
+	assert.Equal(t, uint32(8), members[0].Span.End.Row)
+	assert.Equal(t, "b/total.go", filepath.ToSlash(members[1].Path))
+	assert.Equal(t, "total", members[1].Name)
+	assert.Equal(t, uint32(5), members[1].Span.Start.Row)
+	assert.Equal(t, uint32(11), members[1].Span.End.Row)
+}
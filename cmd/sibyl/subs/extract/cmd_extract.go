@@ -18,12 +18,16 @@ var userSrc string
 var userLangType string
 var userExtractType string
 var userOutputFile string
+var userWithLocalVariable bool
 
 var allowExtractType = []string{
 	extractor.TypeExtractSymbol,
 	extractor.TypeExtractFunction,
 	extractor.TypeExtractCall,
 	extractor.TypeExtractClazz,
+	extractor.TypeExtractImport,
+	extractor.TypeExtractComment,
+	extractor.TypeExtractVariable,
 }
 
 func NewExtractCmd() *cobra.Command {
@@ -43,8 +47,9 @@ func NewExtractCmd() *cobra.Command {
 			}
 
 			config := &sibyl2.ExtractConfig{
-				LangType:    langType,
-				ExtractType: userExtractType,
+				LangType:          langType,
+				ExtractType:       userExtractType,
+				WithLocalVariable: userWithLocalVariable,
 			}
 			results, err := sibyl2.Extract(userSrc, config)
 			if err != nil {
@@ -66,5 +71,6 @@ func NewExtractCmd() *cobra.Command {
 	extractCmd.PersistentFlags().StringVar(&userLangType, "lang", "", "lang type of your source code")
 	extractCmd.PersistentFlags().StringVar(&userExtractType, "type", extractor.TypeExtractFunction, "what kind of data you want")
 	extractCmd.PersistentFlags().StringVar(&userOutputFile, "output", "", "output file")
+	extractCmd.PersistentFlags().BoolVar(&userWithLocalVariable, "withLocalVariable", false, "also extract local variables")
 	return extractCmd
 }
@@ -36,3 +36,27 @@ func Test_ExecuteCommand_Call_Python(t *testing.T) {
 	cmd.SetArgs([]string{"--lang", "PYTHON", "--type", "func"})
 	cmd.Execute()
 }
+
+func Test_ExecuteCommand_Import(t *testing.T) {
+	cmd := NewExtractCmd()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--lang", "GOLANG", "--type", "import"})
+	cmd.Execute()
+}
+
+func Test_ExecuteCommand_Comment(t *testing.T) {
+	cmd := NewExtractCmd()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--lang", "GOLANG", "--type", "comment"})
+	cmd.Execute()
+}
+
+func Test_ExecuteCommand_Variable(t *testing.T) {
+	cmd := NewExtractCmd()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--lang", "GOLANG", "--type", "variable", "--withLocalVariable"})
+	cmd.Execute()
+}
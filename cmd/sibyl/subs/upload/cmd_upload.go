@@ -14,7 +14,9 @@ func NewUploadCmd() *cobra.Command {
 	var uploadLangType []string
 	var uploadUrl string
 	var uploadWithCtx bool
+	var uploadCtxMode string
 	var uploadWithClass bool
+	var uploadWithImport bool
 	var uploadBatchLimit int
 	var uploadDryRun bool
 	var uploadDepth int
@@ -73,9 +75,15 @@ func NewUploadCmd() *cobra.Command {
 			if uploadWithCtx != defaultConf.WithCtx {
 				config.WithCtx = uploadWithCtx
 			}
+			if uploadCtxMode != defaultConf.CtxMode {
+				config.CtxMode = uploadCtxMode
+			}
 			if uploadWithClass != defaultConf.WithClass {
 				config.WithClass = uploadWithClass
 			}
+			if uploadWithImport != defaultConf.WithImport {
+				config.WithImport = uploadWithImport
+			}
 			if uploadBatchLimit != defaultConf.Batch {
 				config.Batch = uploadBatchLimit
 			}
@@ -116,7 +124,9 @@ func NewUploadCmd() *cobra.Command {
 	uploadCmd.PersistentFlags().StringSliceVar(&uploadLangType, "lang", config.Lang, "lang type of your source code")
 	uploadCmd.PersistentFlags().StringVar(&uploadUrl, "url", config.Url, "backend url")
 	uploadCmd.PersistentFlags().BoolVar(&uploadWithCtx, "withCtx", config.WithCtx, "with func context")
+	uploadCmd.PersistentFlags().StringVar(&uploadCtxMode, "ctxMode", config.CtxMode, "how to build func context, symbol or call")
 	uploadCmd.PersistentFlags().BoolVar(&uploadWithClass, "withClass", config.WithClass, "with class")
+	uploadCmd.PersistentFlags().BoolVar(&uploadWithImport, "withImport", config.WithImport, "with import")
 	uploadCmd.PersistentFlags().IntVar(&uploadBatchLimit, "batch", config.Batch, "each batch size")
 	uploadCmd.PersistentFlags().BoolVar(&uploadDryRun, "dry", config.Dry, "dry run without upload")
 	uploadCmd.PersistentFlags().IntVar(&uploadDepth, "depth", config.Depth, "upload with history")
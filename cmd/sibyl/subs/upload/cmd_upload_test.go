@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/opensibyl/sibyl2"
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/golang"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,3 +18,46 @@ func TestUpload(t *testing.T) {
 	err := sibylUploader.Execute()
 	assert.Nil(t, err)
 }
+
+func TestUploadWithCallCtx(t *testing.T) {
+	sibylUploader := NewUploadCmd()
+	b := bytes.NewBufferString("")
+	sibylUploader.SetOut(b)
+	sibylUploader.SetArgs([]string{"--src", "../../../..", "--dry", "--ctxMode", CtxModeCall})
+	err := sibylUploader.Execute()
+	assert.Nil(t, err)
+}
+
+func TestUploadWithInvalidCtxMode(t *testing.T) {
+	config := DefaultConfig()
+	config.Src = "../../../.."
+	config.CtxMode = "abc"
+	// fails before anything uploaded, url is never touched
+	config.Url = "http://127.0.0.1:1"
+	err := ExecWithConfig(config)
+	assert.NotNil(t, err)
+}
+
+func TestFillGoMethodSets(t *testing.T) {
+	src := "../../../.."
+	config := &sibyl2.ExtractConfig{LangType: core.LangGo}
+	clazzFiles, err := sibyl2.ExtractClazz(src, config)
+	assert.Nil(t, err)
+	funcFiles, err := sibyl2.ExtractFunction(src, config)
+	assert.Nil(t, err)
+
+	filled, err := fillGoMethodSets(src, nil, clazzFiles, funcFiles, nil)
+	assert.Nil(t, err)
+	for _, eachFile := range filled {
+		if eachFile.Path != "object_analyze_funcgraph.go" {
+			continue
+		}
+		for _, each := range eachFile.Units {
+			if each.Name == "FuncGraph" {
+				assert.NotEmpty(t, each.Extras.(*golang.ClassExtras).Methods)
+				return
+			}
+		}
+	}
+	assert.Fail(t, "FuncGraph not found")
+}
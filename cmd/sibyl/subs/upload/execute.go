@@ -10,7 +10,9 @@ import (
 	object2 "github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/opensibyl/sibyl2"
 	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor"
 	"github.com/opensibyl/sibyl2/pkg/server/object"
+	"golang.org/x/exp/slices"
 )
 
 type ExecuteCache struct {
@@ -24,6 +26,9 @@ func ExecWithConfig(c *Config) error {
 		core.Log.Infof("upload total cost: %d ms", time.Since(startTime).Milliseconds())
 	}()
 
+	if err := c.Verify(); err != nil {
+		return err
+	}
 	configStr, err := c.ToJson()
 	if err != nil {
 		return err
@@ -180,6 +185,7 @@ func execCurRevCurLangWithConfig(uploadSrc string, lang core.LangType, filterFun
 	funcUrl := c.GetFuncUploadUrl()
 	funcCtxUrl := c.GetFuncCtxUploadUrl()
 	clazzUrl := c.GetClazzUploadUrl()
+	importUrl := c.GetImportUploadUrl()
 
 	core.Log.Infof("upload backend: %s", funcUrl)
 	if !c.Dry {
@@ -187,19 +193,24 @@ func execCurRevCurLangWithConfig(uploadSrc string, lang core.LangType, filterFun
 	}
 	core.Log.Infof("upload functions finished, file count: %d", len(f))
 
-	// building edges can be expensive
-	// by default disabled
-	if c.WithCtx {
-		s, err := sibyl2.ExtractSymbol(uploadSrc, &sibyl2.ExtractConfig{
+	// imports are also used by func graph in call mode
+	var imports []*extractor.ImportFileResult
+	if c.WithImport || (c.WithCtx && c.CtxMode == CtxModeCall) {
+		imports, err = sibyl2.ExtractImport(uploadSrc, &sibyl2.ExtractConfig{
 			FileFilter: filterFunc,
 			LangType:   lang,
 		})
 		if err != nil {
 			return nil, err
 		}
+		core.Log.Infof("imports ready")
+	}
 
-		core.Log.Infof("start calculating func graph")
-		g, err := sibyl2.AnalyzeFuncGraph(f, s)
+	// building edges can be expensive
+	// by default disabled
+	if c.WithCtx {
+		core.Log.Infof("start calculating func graph, mode: %s", c.CtxMode)
+		g, err := analyzeFuncGraph(uploadSrc, lang, filterFunc, f, imports, c.CtxMode)
 		if err != nil {
 			return nil, err
 		}
@@ -221,10 +232,21 @@ func execCurRevCurLangWithConfig(uploadSrc string, lang core.LangType, filterFun
 			return nil, err
 		}
 		core.Log.Infof("classes ready")
+		if lang == core.LangGo {
+			s, err = fillGoMethodSets(uploadSrc, filterFunc, s, f, imports)
+			if err != nil {
+				return nil, err
+			}
+			core.Log.Infof("go method sets ready")
+		}
 		if !c.Dry {
 			uploadClazz(clazzUrl, wc, s, c.Batch)
 		}
 	}
+
+	if c.WithImport && !c.Dry {
+		uploadImports(importUrl, wc, imports, c.Batch)
+	}
 	return cache, nil
 }
 
@@ -279,3 +301,69 @@ func loadRepo(gitDir string) (*git.Repository, error) {
 	}
 	return repo, nil
 }
+
+func analyzeFuncGraph(uploadSrc string, lang core.LangType, filterFunc func(path string) bool, f []*extractor.FunctionFileResult, imports []*extractor.ImportFileResult, ctxMode string) (*sibyl2.FuncGraph, error) {
+	if ctxMode == CtxModeCall {
+		// call extraction is not supported by all the languages
+		calls, err := sibyl2.ExtractCall(uploadSrc, &sibyl2.ExtractConfig{
+			FileFilter: filterFunc,
+			LangType:   lang,
+		})
+		if err == nil {
+			var goModules sibyl2.GoModules
+			if lang == core.LangGo {
+				goModules, err = sibyl2.ReadGoModules(uploadSrc)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return sibyl2.AnalyzeCallGraph(f, calls, imports, goModules)
+		}
+		core.Log.Warnf("failed to extract calls of %s, fallback to symbol mode: %v", lang, err)
+	}
+
+	s, err := sibyl2.ExtractSymbol(uploadSrc, &sibyl2.ExtractConfig{
+		FileFilter: filterFunc,
+		LangType:   lang,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(overloadLangs, lang) {
+		return sibyl2.AnalyzeFuncGraph(f, s)
+	}
+
+	// overloads can only be told by calls
+	calls, err := sibyl2.ExtractCall(uploadSrc, &sibyl2.ExtractConfig{
+		FileFilter: filterFunc,
+		LangType:   lang,
+	})
+	if err != nil {
+		core.Log.Warnf("failed to extract calls of %s, overloads are ambiguous: %v", lang, err)
+		calls = nil
+	}
+	return sibyl2.AnalyzeFuncGraphWithCalls(f, s, calls)
+}
+
+// fillGoMethodSets go types with their methods, which can only be linked with the whole repo
+func fillGoMethodSets(uploadSrc string, filterFunc func(path string) bool, clazzFiles []*extractor.ClazzFileResult, f []*extractor.FunctionFileResult, imports []*extractor.ImportFileResult) ([]*extractor.ClazzFileResult, error) {
+	if imports == nil {
+		var err error
+		imports, err = sibyl2.ExtractImport(uploadSrc, &sibyl2.ExtractConfig{
+			FileFilter: filterFunc,
+			LangType:   core.LangGo,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	modules, err := sibyl2.ReadGoModules(uploadSrc)
+	if err != nil {
+		return nil, err
+	}
+	methodSets, err := sibyl2.AnalyzeGoMethodSets(clazzFiles, f, imports, modules)
+	if err != nil {
+		return nil, err
+	}
+	return methodSets.FillClazzFiles(clazzFiles), nil
+}
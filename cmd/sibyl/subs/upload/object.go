@@ -6,6 +6,7 @@ import (
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/opensibyl/sibyl2"
+	"github.com/opensibyl/sibyl2/pkg/core"
 )
 
 const (
@@ -13,13 +14,31 @@ const (
 	configType = "json"
 )
 
+// ways to build func contexts
+const (
+	// CtxModeSymbol by references, see sibyl2.AnalyzeFuncGraph
+	CtxModeSymbol = "symbol"
+	// CtxModeCall by calls, more precise, see sibyl2.AnalyzeCallGraph
+	CtxModeCall = "call"
+)
+
+// overloadLangs languages with overloads, which can only be told by calls in symbol mode
+var overloadLangs = []core.LangType{
+	core.LangJava,
+	core.LangKotlin,
+	core.LangCpp,
+	core.LangCSharp,
+}
+
 type SrcConfigPart struct {
 	RepoId       string   `mapstructure:"repoId"`
 	RevHash      string   `mapstructure:"revHash"`
 	Src          string   `mapstructure:"src"`
 	Lang         []string `mapstructure:"lang"`
 	WithCtx      bool     `mapstructure:"withCtx"`
+	CtxMode      string   `mapstructure:"ctxMode"`
 	WithClass    bool     `mapstructure:"withClass"`
+	WithImport   bool     `mapstructure:"withImport"`
 	IncludeRegex string   `mapstructure:"includeRegex"`
 	ExcludeRegex string   `mapstructure:"excludeRegex"`
 }
@@ -57,6 +76,15 @@ func (config *Config) ToJson() ([]byte, error) {
 	return json.Marshal(toMap)
 }
 
+// Verify config before uploading anything
+func (config *Config) Verify() error {
+	switch config.CtxMode {
+	case CtxModeSymbol, CtxModeCall, "":
+		return nil
+	}
+	return fmt.Errorf("invalid ctx mode: %s", config.CtxMode)
+}
+
 func (config *Config) GetFuncUploadUrl() string {
 	return fmt.Sprintf("%s/api/v1/func", config.Url)
 }
@@ -65,6 +93,10 @@ func (config *Config) GetClazzUploadUrl() string {
 	return fmt.Sprintf("%s/api/v1/clazz", config.Url)
 }
 
+func (config *Config) GetImportUploadUrl() string {
+	return fmt.Sprintf("%s/api/v1/import", config.Url)
+}
+
 func (config *Config) GetFuncCtxUploadUrl() string {
 	return fmt.Sprintf("%s/api/v1/funcctx", config.Url)
 }
@@ -77,7 +109,9 @@ func DefaultConfig() *Config {
 			Src:          ".",
 			Lang:         []string{},
 			WithCtx:      true,
+			CtxMode:      CtxModeSymbol,
 			WithClass:    true,
+			WithImport:   false,
 			IncludeRegex: "",
 			ExcludeRegex: "",
 		},
@@ -222,3 +222,66 @@ func uploadClazzUnits(url string, units []*object.ClazzUploadUnit) {
 	}
 	wg.Wait()
 }
+
+func uploadImports(url string, wc *object.WorkspaceConfig, imports []*extractor.ImportFileResult, batch int) {
+	core.Log.Infof("uploading %v with files %d ...", wc, len(imports))
+
+	// pack
+	fullUnits := make([]*object.ImportUploadUnit, 0, len(imports))
+	for _, each := range imports {
+		unit := &object.ImportUploadUnit{
+			WorkspaceConfig:  wc,
+			ImportFileResult: each,
+		}
+		fullUnits = append(fullUnits, unit)
+	}
+	// submit
+	ptr := 0
+	for ptr < len(fullUnits) {
+		core.Log.Infof("upload batch: %d - %d", ptr, ptr+batch)
+
+		newPtr := ptr + batch
+		if newPtr < len(fullUnits) {
+			uploadImportUnits(url, fullUnits[ptr:ptr+batch])
+		} else {
+			uploadImportUnits(url, fullUnits[ptr:])
+		}
+
+		ptr = newPtr
+	}
+}
+
+func uploadImportUnits(url string, units []*object.ImportUploadUnit) {
+	var wg sync.WaitGroup
+	for _, unit := range units {
+		if unit == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(uploadUnit *object.ImportUploadUnit, waitGroup *sync.WaitGroup) {
+			defer waitGroup.Done()
+			uploadData, err := msgpack2bytes(uploadUnit)
+			if err != nil {
+				core.Log.Errorf("error when upload: %v", err)
+				return
+			}
+			resp, err := httpClient.Post(
+				url,
+				object.BodyTypeMsgpack,
+				uploadData)
+			if err != nil {
+				core.Log.Errorf("error when upload: %v", err)
+				return
+			}
+			data, err := io.ReadAll(resp.Body)
+			if err != nil {
+				core.Log.Errorf("error when upload: %v", err)
+				return
+			}
+			if resp.StatusCode != http.StatusOK {
+				core.Log.Errorf("upload failed: %v", string(data))
+			}
+		}(unit, &wg)
+	}
+	wg.Wait()
+}
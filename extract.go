@@ -7,6 +7,7 @@ import (
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 )
 
 // ExtractConfig todo: should not use config ptr for parallel running
@@ -14,6 +15,8 @@ type ExtractConfig struct {
 	LangType    core.LangType
 	ExtractType extractor.ExtractType
 	FileFilter  func(path string) bool
+	// WithLocalVariable also extract local variables, only for TypeExtractVariable
+	WithLocalVariable bool
 }
 
 func DefaultConfig() *ExtractConfig {
@@ -53,13 +56,36 @@ func ExtractFromBytes(content []byte, config *ExtractConfig) (*extractor.FileRes
 		if err != nil {
 			return nil, err
 		}
-		datas = extractor.DataTypeOf(functions)
+		entries, err := extractor.DetectEntryPoints(lang, units)
+		if err != nil {
+			return nil, err
+		}
+		extractor.BindEntryPoints(entries, functions)
+		datas = extractor.DataTypeOf(fillFunctionHashes(functions, langExtractor))
 	case extractor.TypeExtractCall:
 		calls, err := langExtractor.ExtractCalls(units)
 		if err != nil {
 			return nil, err
 		}
 		datas = extractor.DataTypeOf(calls)
+	case extractor.TypeExtractImport:
+		imports, err := langExtractor.ExtractImports(units)
+		if err != nil {
+			return nil, err
+		}
+		datas = extractor.DataTypeOf(imports)
+	case extractor.TypeExtractComment:
+		comments, err := langExtractor.ExtractComments(units)
+		if err != nil {
+			return nil, err
+		}
+		datas = extractor.DataTypeOf(comments)
+	case extractor.TypeExtractVariable:
+		variables, err := langExtractor.ExtractVariables(units)
+		if err != nil {
+			return nil, err
+		}
+		datas = extractor.DataTypeOf(filterVariables(variables, config))
 	}
 	result := &extractor.FileResult{
 		Language: lang,
@@ -68,3 +94,46 @@ func ExtractFromBytes(content []byte, config *ExtractConfig) (*extractor.FileRes
 	}
 	return result, nil
 }
+
+func filterVariables(variables []*extractor.Variable, config *ExtractConfig) []*extractor.Variable {
+	if config.WithLocalVariable {
+		return variables
+	}
+	ret := make([]*extractor.Variable, 0, len(variables))
+	for _, each := range variables {
+		if each.Scope != object.VariableScopeLocal {
+			ret = append(ret, each)
+		}
+	}
+	return ret
+}
+
+// fillFunctionHashes body and signature hashes, for detecting changes across revisions
+func fillFunctionHashes(functions []*extractor.Function, langExtractor extractor.Extractor) []*extractor.Function {
+	for _, each := range functions {
+		each.FillHashes(langExtractor.IsComment)
+	}
+	return functions
+}
+
+// bindEntryPoints handlers in other files, eg: `r.GET("/users", handler.List)`
+func bindEntryPoints(entries []*extractor.EntryPoint, results []*extractor.FileResult) {
+	var functions []*extractor.Function
+	for _, eachFile := range results {
+		for _, each := range eachFile.Units {
+			if f, ok := each.(*extractor.Function); ok {
+				functions = append(functions, f)
+			}
+		}
+	}
+	for _, each := range extractor.BindEntryPoints(entries, functions) {
+		core.Log.Debugf("no handler found for entry point: %s %s %s", each.Method, each.Route, each.Handler)
+	}
+}
+
+func fillClazzHashes(classes []*extractor.Clazz, langExtractor extractor.Extractor) []*extractor.Clazz {
+	for _, each := range classes {
+		each.FillHashes(langExtractor.IsComment)
+	}
+	return classes
+}
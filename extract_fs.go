@@ -101,6 +101,126 @@ func ExtractClazz(targetFile string, config *ExtractConfig) ([]*extractor.ClazzF
 	return final, nil
 }
 
+func ExtractCall(targetFile string, config *ExtractConfig) ([]*extractor.CallFileResult, error) {
+	config.ExtractType = extractor.TypeExtractCall
+	results, err := Extract(targetFile, config)
+	if err != nil {
+		return nil, err
+	}
+
+	final := make([]*extractor.CallFileResult, 0)
+	for _, each := range results {
+		var newUnits = make([]*extractor.Call, len(each.Units))
+		for i, v := range each.Units {
+			// should not error
+			if call, ok := v.(*extractor.Call); ok {
+				newUnits[i] = call
+			} else {
+				return nil, errors.New(fmt.Sprintf("failed to cast %v to call", v))
+			}
+		}
+
+		newEach := &extractor.CallFileResult{
+			Path:     each.Path,
+			Language: each.Language,
+			Type:     each.Type,
+			Units:    newUnits,
+		}
+		final = append(final, newEach)
+	}
+	return final, nil
+}
+
+func ExtractImport(targetFile string, config *ExtractConfig) ([]*extractor.ImportFileResult, error) {
+	config.ExtractType = extractor.TypeExtractImport
+	results, err := Extract(targetFile, config)
+	if err != nil {
+		return nil, err
+	}
+
+	final := make([]*extractor.ImportFileResult, 0)
+	for _, each := range results {
+		var newUnits = make([]*extractor.Import, len(each.Units))
+		for i, v := range each.Units {
+			// should not error
+			if imp, ok := v.(*extractor.Import); ok {
+				newUnits[i] = imp
+			} else {
+				return nil, errors.New(fmt.Sprintf("failed to cast %v to import", v))
+			}
+		}
+
+		newEach := &extractor.ImportFileResult{
+			Path:     each.Path,
+			Language: each.Language,
+			Type:     each.Type,
+			Units:    newUnits,
+		}
+		final = append(final, newEach)
+	}
+	return final, nil
+}
+
+func ExtractComment(targetFile string, config *ExtractConfig) ([]*extractor.CommentFileResult, error) {
+	config.ExtractType = extractor.TypeExtractComment
+	results, err := Extract(targetFile, config)
+	if err != nil {
+		return nil, err
+	}
+
+	final := make([]*extractor.CommentFileResult, 0)
+	for _, each := range results {
+		var newUnits = make([]*extractor.Comment, len(each.Units))
+		for i, v := range each.Units {
+			// should not error
+			if comment, ok := v.(*extractor.Comment); ok {
+				newUnits[i] = comment
+			} else {
+				return nil, errors.New(fmt.Sprintf("failed to cast %v to comment", v))
+			}
+		}
+
+		newEach := &extractor.CommentFileResult{
+			Path:     each.Path,
+			Language: each.Language,
+			Type:     each.Type,
+			Units:    newUnits,
+		}
+		final = append(final, newEach)
+	}
+	return final, nil
+}
+
+func ExtractVariable(targetFile string, config *ExtractConfig) ([]*extractor.VariableFileResult, error) {
+	config.ExtractType = extractor.TypeExtractVariable
+	results, err := Extract(targetFile, config)
+	if err != nil {
+		return nil, err
+	}
+
+	final := make([]*extractor.VariableFileResult, 0)
+	for _, each := range results {
+		var newUnits = make([]*extractor.Variable, len(each.Units))
+		for i, v := range each.Units {
+			// should not error
+			if variable, ok := v.(*extractor.Variable); ok {
+				newUnits[i] = variable
+			} else {
+				return nil, errors.New(fmt.Sprintf("failed to cast %v to variable", v))
+			}
+		}
+
+		newEach := &extractor.VariableFileResult{
+			Path:     each.Path,
+			Language: each.Language,
+			Type:     each.Type,
+			Units:    newUnits,
+		}
+		final = append(final, newEach)
+	}
+	return final, nil
+}
+
 func Extract(targetFile string, config *ExtractConfig) ([]*extractor.FileResult, error) {
 	startTime := time.Now()
 	defer func() {
@@ -142,6 +262,8 @@ func Extract(targetFile string, config *ExtractConfig) ([]*extractor.FileResult,
 		return nil, fmt.Errorf("no extractor found for %s", config.LangType)
 	}
 	var results []*extractor.FileResult
+	// entry points whose handlers are not in the same file
+	var unboundEntries []*extractor.EntryPoint
 	for _, eachFileUnit := range fileUnits {
 		fileResult := &extractor.FileResult{
 			Path:     eachFileUnit.Path,
@@ -161,7 +283,13 @@ func Extract(targetFile string, config *ExtractConfig) ([]*extractor.FileResult,
 			if err != nil {
 				return nil, err
 			}
-			fileResult.Units = extractor.DataTypeOf(functions)
+			functions = extractor.HookFileFunctions(config.LangType, eachFileUnit.Path, functions)
+			entries, err := extractor.DetectEntryPoints(config.LangType, eachFileUnit.Units)
+			if err != nil {
+				return nil, err
+			}
+			unboundEntries = append(unboundEntries, extractor.BindEntryPoints(entries, functions)...)
+			fileResult.Units = extractor.DataTypeOf(fillFunctionHashes(functions, langExtractor))
 		case extractor.TypeExtractCall:
 			calls, err := langExtractor.ExtractCalls(eachFileUnit.Units)
 			if err != nil {
@@ -173,12 +301,33 @@ func Extract(targetFile string, config *ExtractConfig) ([]*extractor.FileResult,
 			if err != nil {
 				return nil, err
 			}
-			fileResult.Units = extractor.DataTypeOf(classes)
+			fileResult.Units = extractor.DataTypeOf(fillClazzHashes(classes, langExtractor))
+		case extractor.TypeExtractImport:
+			imports, err := langExtractor.ExtractImports(eachFileUnit.Units)
+			if err != nil {
+				return nil, err
+			}
+			fileResult.Units = extractor.DataTypeOf(imports)
+		case extractor.TypeExtractComment:
+			comments, err := langExtractor.ExtractComments(eachFileUnit.Units)
+			if err != nil {
+				return nil, err
+			}
+			fileResult.Units = extractor.DataTypeOf(comments)
+		case extractor.TypeExtractVariable:
+			variables, err := langExtractor.ExtractVariables(eachFileUnit.Units)
+			if err != nil {
+				return nil, err
+			}
+			fileResult.Units = extractor.DataTypeOf(filterVariables(variables, config))
 		default:
 			return nil, errors.New("no specific extract type")
 		}
 		results = append(results, fileResult)
 	}
+	if len(unboundEntries) != 0 {
+		bindEntryPoints(unboundEntries, results)
+	}
 	// path
 	err = extractor.PathStandardize(results, targetFile)
 	if err != nil {
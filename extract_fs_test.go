@@ -1,10 +1,14 @@
 package sibyl2
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestExtract(t *testing.T) {
@@ -74,7 +78,61 @@ func TestExtractClazz(t *testing.T) {
 	}
 	for _, each := range fileResult {
 		core.Log.Infof("path: %v, %v", each.Path, each.Units)
+		for _, eachClazz := range each.Units {
+			assert.NotEmpty(t, eachClazz.BodyHash)
+			assert.NotEmpty(t, eachClazz.SignatureHash)
+		}
+	}
+}
+
+func TestExtractImport(t *testing.T) {
+	fileResult, err := ExtractImport("./extract.go", &ExtractConfig{
+		LangType: core.LangGo,
+	})
+	if err != nil {
+		panic(err)
+	}
+	assert.Len(t, fileResult, 1)
+	assert.Equal(t, "context", fileResult[0].Units[0].Source)
+}
+
+func TestExtractComment(t *testing.T) {
+	fileResult, err := ExtractComment("./extract.go", &ExtractConfig{
+		LangType: core.LangGo,
+	})
+	if err != nil {
+		panic(err)
+	}
+	assert.Len(t, fileResult, 1)
+	first := fileResult[0].Units[0]
+	assert.Equal(t, "ExtractConfig todo: should not use config ptr for parallel running", first.Text)
+	assert.Equal(t, "sibyl2.ExtractConfig", first.Owner)
+}
+
+func TestExtractVariable(t *testing.T) {
+	fileResult, err := ExtractVariable("./extract.go", &ExtractConfig{
+		LangType: core.LangGo,
+	})
+	if err != nil {
+		panic(err)
 	}
+	assert.Len(t, fileResult, 1)
+	for _, each := range fileResult[0].Units {
+		assert.NotEqual(t, object.VariableScopeLocal, each.Scope)
+	}
+	first := fileResult[0].Units[0]
+	assert.Equal(t, "LangType", first.Name)
+	assert.Equal(t, "core.LangType", first.Type)
+	assert.Equal(t, "ExtractConfig", first.Receiver)
+
+	withLocal, err := ExtractVariable("./extract.go", &ExtractConfig{
+		LangType:          core.LangGo,
+		WithLocalVariable: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	assert.Greater(t, len(withLocal[0].Units), len(fileResult[0].Units))
 }
 
 func BenchmarkExtract(b *testing.B) {
@@ -93,3 +151,55 @@ func BenchmarkExtract(b *testing.B) {
 		}
 	}
 }
+
+func TestExtractGoTests(t *testing.T) {
+	dir := t.TempDir()
+	code := "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n"
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "a_test.go"), []byte(code), 0644))
+	// helpers outside _test.go, which will never run
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte(code), 0644))
+
+	fileResult, err := ExtractFunction(dir, &ExtractConfig{LangType: core.LangGo})
+	assert.Nil(t, err)
+	assert.Len(t, fileResult, 2)
+	for _, each := range fileResult {
+		assert.Len(t, each.Units, 1)
+		assert.Equal(t, each.Path == "a_test.go", each.Units[0].IsTest())
+	}
+}
+
+func TestExtractEntryPoints(t *testing.T) {
+	dir := t.TempDir()
+	routes := `package a
+
+import "github.com/gin-gonic/gin"
+
+func Register(r *gin.Engine, h *Handler) {
+	api := r.Group("/api")
+	api.GET("/users", h.List)
+}
+`
+	handler := `package a
+
+import "github.com/gin-gonic/gin"
+
+type Handler struct{}
+
+func (h *Handler) List(c *gin.Context) {}
+`
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "routes.go"), []byte(routes), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "handler.go"), []byte(handler), 0644))
+
+	fileResult, err := ExtractFunction(dir, &ExtractConfig{LangType: core.LangGo})
+	assert.Nil(t, err)
+	for _, eachFile := range fileResult {
+		for _, each := range eachFile.Units {
+			assert.Equal(t, each.Name == "List", each.IsEntryPoint())
+			if each.Name == "List" {
+				assert.Equal(t, "GET", each.EntryPoints[0].Method)
+				assert.Equal(t, "/api/users", each.EntryPoints[0].Route)
+				assert.Equal(t, "h.List", each.EntryPoints[0].Handler)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/stretchr/testify/assert"
 )
 
 var javaCodeForExtract = `
@@ -45,6 +46,55 @@ func TestExtractString(t *testing.T) {
 	}
 }
 
+var goCodeForHash = `
+package abc
+
+func Add(a, b int) int {
+	return a + b
+}
+`
+
+var goCodeForHashFormatted = `
+package abc
+
+// Add sums.
+func Add(a, b int) int {
+	// plus
+	return a  +  b
+}
+`
+
+var goCodeForHashChanged = `
+package abc
+
+func Add(a, b int) int {
+	return a - b
+}
+`
+
+func TestExtractHashes(t *testing.T) {
+	extractAdd := func(code string) *extractor.Function {
+		fileResult, err := ExtractFromString(code, &ExtractConfig{
+			LangType:    core.LangGo,
+			ExtractType: extractor.TypeExtractFunction,
+		})
+		assert.Nil(t, err)
+		return fileResult.Units[0].(*extractor.Function)
+	}
+	origin := extractAdd(goCodeForHash)
+	formatted := extractAdd(goCodeForHashFormatted)
+	changed := extractAdd(goCodeForHashChanged)
+
+	assert.NotEmpty(t, origin.BodyHash)
+	assert.NotEmpty(t, origin.SignatureHash)
+	// comments and formats
+	assert.Equal(t, origin.BodyHash, formatted.BodyHash)
+	assert.Equal(t, origin.SignatureHash, formatted.SignatureHash)
+	// body only
+	assert.NotEqual(t, origin.BodyHash, changed.BodyHash)
+	assert.Equal(t, origin.SignatureHash, changed.SignatureHash)
+}
+
 func BenchmarkExtractFromString(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		// no   cache: 499267 ns/op
@@ -0,0 +1,38 @@
+package sibyl2
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/extractor"
+)
+
+type CloneType = string
+
+const (
+	// CloneExact identical bodies, ignoring formats and comments
+	CloneExact CloneType = "exact"
+	// CloneRenamed identical bodies after identifiers renamed
+	CloneRenamed CloneType = "renamed"
+	// CloneSimilar similar syntax tree shapes
+	CloneSimilar CloneType = "similar"
+)
+
+type CloneConfig struct {
+	// functions smaller than this (lines of code) will be ignored
+	MinLoc int
+	// similarity (0-1) of syntax tree shapes, for CloneSimilar
+	Threshold float64
+}
+
+func DefaultCloneConfig() *CloneConfig {
+	return &CloneConfig{
+		MinLoc:    5,
+		Threshold: 0.9,
+	}
+}
+
+// CloneGroup functions which are clones of each other, it contains the stronger groups it covers, see AnalyzeClones
+type CloneGroup struct {
+	Type CloneType `json:"type"`
+	// the lowest similarity between members, 1 for exact and renamed clones
+	Similarity float64                       `json:"similarity"`
+	Members    []*extractor.FunctionWithPath `json:"members"`
+}
@@ -41,6 +41,63 @@ type FuncGraph struct {
 	CallGraph        *FuncGraphType
 }
 
+// CallConfidence how sure we are about an edge of graphs built by AnalyzeCallGraph
+type CallConfidence = string
+
+const (
+	// CallConfidenceExact callee resolved by imports, or the receiver of caller itself, eg: `this.b()`
+	CallConfidenceExact CallConfidence = "exact"
+	// CallConfidenceReceiver callee resolved by the type of receiver, eg: `a.b()` and `a` is a `*A`
+	CallConfidenceReceiver CallConfidence = "receiver"
+	// CallConfidenceName callee only has the same name
+	CallConfidenceName CallConfidence = "name"
+)
+
+const (
+	callConfidenceKey = "confidence"
+	// callAmbiguousKey the callee is one of several overloads which match the call equally
+	callAmbiguousKey = "ambiguous"
+)
+
+var callConfidenceLevels = map[CallConfidence]int{
+	CallConfidenceName:     1,
+	CallConfidenceReceiver: 2,
+	CallConfidenceExact:    3,
+}
+
+func higherConfidence(c CallConfidence, other CallConfidence) bool {
+	return callConfidenceLevels[c] > callConfidenceLevels[other]
+}
+
+func edgeAttributes(confidence CallConfidence, ambiguous bool) []func(*graph.EdgeProperties) {
+	var ret []func(*graph.EdgeProperties)
+	if confidence != "" {
+		ret = append(ret, graph.EdgeAttribute(callConfidenceKey, confidence))
+	}
+	if ambiguous {
+		ret = append(ret, graph.EdgeAttribute(callAmbiguousKey, "true"))
+	}
+	return ret
+}
+
+// IsAmbiguousCall whether the callee can not be told from its overloads, false if no such edge
+func (fg *FuncGraph) IsAmbiguousCall(caller *extractor.FunctionWithPath, callee *extractor.FunctionWithPath) bool {
+	edge, err := fg.CallGraph.Edge(caller.GetDescWithPath(), callee.GetDescWithPath())
+	if err != nil {
+		return false
+	}
+	return edge.Properties.Attributes[callAmbiguousKey] == "true"
+}
+
+// GetCallConfidence confidence of the edge caller -> callee, empty if no such edge or graph built by AnalyzeFuncGraph
+func (fg *FuncGraph) GetCallConfidence(caller *extractor.FunctionWithPath, callee *extractor.FunctionWithPath) CallConfidence {
+	edge, err := fg.CallGraph.Edge(caller.GetDescWithPath(), callee.GetDescWithPath())
+	if err != nil {
+		return ""
+	}
+	return edge.Properties.Attributes[callConfidenceKey]
+}
+
 func (fg *FuncGraph) FindReverseCalls(f *extractor.FunctionWithPath) []*extractor.FunctionWithPath {
 	return fg.bfs(fg.ReverseCallGraph, f)
 }
@@ -0,0 +1,96 @@
+package sibyl2
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/extractor"
+)
+
+// ClassHierarchy class inheritance of a whole repo.
+// Classes are named by their full names (clazz signatures), eg: `com.x.Outer.Inner`,
+// and indexed by their paths and full names, see AnalyzeJavaHierarchy.
+type ClassHierarchy struct {
+	// path#full name -> class
+	classes map[string]*extractor.ClazzWithPath
+	// full name -> keys of the classes with this name
+	byName map[string][]string
+	// key -> keys of direct super types, full names for the ones not in this repo (eg: java.util.List)
+	parents map[string][]string
+	// key -> keys of direct sub types
+	children map[string][]string
+	// key of receiver -> methods
+	methods map[string][]*extractor.FunctionWithPath
+}
+
+// Query classes by their full name, empty if it is not in this repo
+func (h *ClassHierarchy) Query(className string) []*extractor.ClazzWithPath {
+	var ret []*extractor.ClazzWithPath
+	for _, each := range h.byName[className] {
+		ret = append(ret, h.classes[each])
+	}
+	return ret
+}
+
+// Ancestors full names of all the super types, the closest first
+func (h *ClassHierarchy) Ancestors(clazz *extractor.ClazzWithPath) []string {
+	return h.walk(classKey(clazz.Path, clazz.GetSignature()), h.parents)
+}
+
+// Descendants full names of all the sub types in this repo, the closest first
+func (h *ClassHierarchy) Descendants(clazz *extractor.ClazzWithPath) []string {
+	return h.walk(classKey(clazz.Path, clazz.GetSignature()), h.children)
+}
+
+// Overrides methods in ancestors which are overridden by this method
+func (h *ClassHierarchy) Overrides(method *extractor.FunctionWithPath) []*extractor.FunctionWithPath {
+	var ret []*extractor.FunctionWithPath
+	for _, eachAncestor := range h.walkKeys(classKey(method.Path, method.Receiver), h.parents) {
+		for _, each := range h.methods[eachAncestor] {
+			if h.isOverride(method, each) {
+				ret = append(ret, each)
+			}
+		}
+	}
+	return ret
+}
+
+// OverriddenBy methods in descendants which override this method
+func (h *ClassHierarchy) OverriddenBy(method *extractor.FunctionWithPath) []*extractor.FunctionWithPath {
+	var ret []*extractor.FunctionWithPath
+	for _, eachDescendant := range h.walkKeys(classKey(method.Path, method.Receiver), h.children) {
+		for _, each := range h.methods[eachDescendant] {
+			if h.isOverride(each, method) {
+				ret = append(ret, each)
+			}
+		}
+	}
+	return ret
+}
+
+func (h *ClassHierarchy) walk(key string, edges map[string][]string) []string {
+	keys := h.walkKeys(key, edges)
+	ret := make([]string, 0, len(keys))
+	for _, each := range keys {
+		ret = append(ret, classNameOfKey(each))
+	}
+	return ret
+}
+
+func (h *ClassHierarchy) walkKeys(key string, edges map[string][]string) []string {
+	var ret []string
+	visited := map[string]bool{key: true}
+	current := []string{key}
+	for len(current) != 0 {
+		var next []string
+		for _, each := range current {
+			for _, eachNext := range edges[each] {
+				if visited[eachNext] {
+					continue
+				}
+				visited[eachNext] = true
+				ret = append(ret, eachNext)
+				next = append(next, eachNext)
+			}
+		}
+		current = next
+	}
+	return ret
+}
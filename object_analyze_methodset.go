@@ -0,0 +1,63 @@
+package sibyl2
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/extractor"
+)
+
+// GoMethodSet full method set of a named go type, collected from all the files of its package.
+// Methods with pointer receivers are included, so it is the method set of `*T` actually.
+type GoMethodSet struct {
+	*extractor.ClazzWithPath
+	// Methods declared with this type as receiver
+	Methods []*extractor.FunctionWithPath `json:"methods" bson:"methods"`
+	// Promoted methods from embedded fields, which are not shadowed
+	Promoted []*extractor.FunctionWithPath `json:"promoted" bson:"promoted"`
+	// Implements interfaces which can be structurally satisfied by this type
+	Implements []*extractor.ClazzWithPath `json:"implements" bson:"implements"`
+}
+
+type GoMethodSets struct {
+	// package dir -> type name -> method set
+	data map[string]map[string]*GoMethodSet
+}
+
+// Query method set by its package dir and type name
+func (s *GoMethodSets) Query(pkgDir string, typeName string) *GoMethodSet {
+	types, ok := s.data[pkgDir]
+	if !ok {
+		return nil
+	}
+	return types[typeName]
+}
+
+// QueryByClazz method set of a type which comes from a go file
+func (s *GoMethodSets) QueryByClazz(clazz *extractor.ClazzWithPath) *GoMethodSet {
+	return s.Query(goPackageDir(clazz.Path), clazz.Name)
+}
+
+func (s *GoMethodSets) All() []*GoMethodSet {
+	var ret []*GoMethodSet
+	for _, types := range s.data {
+		for _, each := range types {
+			ret = append(ret, each)
+		}
+	}
+	return ret
+}
+
+// FillClazzFiles copies of these files, with go types replaced by the ones carrying their method sets in extras
+func (s *GoMethodSets) FillClazzFiles(clazzFiles []*extractor.ClazzFileResult) []*extractor.ClazzFileResult {
+	ret := make([]*extractor.ClazzFileResult, 0, len(clazzFiles))
+	for _, eachFile := range clazzFiles {
+		fileCopy := *eachFile
+		fileCopy.Units = make([]*extractor.Clazz, 0, len(eachFile.Units))
+		for _, eachClazz := range eachFile.Units {
+			if methodSet := s.Query(goPackageDir(eachFile.Path), eachClazz.Name); methodSet != nil && methodSet.Path == eachFile.Path {
+				eachClazz = methodSet.Clazz
+			}
+			fileCopy.Units = append(fileCopy.Units, eachClazz)
+		}
+		ret = append(ret, &fileCopy)
+	}
+	return ret
+}
@@ -5,11 +5,19 @@ import (
 	"sync"
 
 	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/c"
+	"github.com/smacker/go-tree-sitter/cpp"
+	"github.com/smacker/go-tree-sitter/csharp"
 	"github.com/smacker/go-tree-sitter/golang"
 	"github.com/smacker/go-tree-sitter/java"
 	"github.com/smacker/go-tree-sitter/javascript"
 	"github.com/smacker/go-tree-sitter/kotlin"
+	"github.com/smacker/go-tree-sitter/php"
 	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
+	"github.com/smacker/go-tree-sitter/scala"
+	"github.com/smacker/go-tree-sitter/swift"
 	"golang.org/x/exp/slices"
 )
 
@@ -21,6 +29,14 @@ const (
 	LangPython     LangType = "PYTHON"
 	LangKotlin     LangType = "KOTLIN"
 	LangJavaScript LangType = "JAVASCRIPT"
+	LangC          LangType = "C"
+	LangCpp        LangType = "CPP"
+	LangRust       LangType = "RUST"
+	LangCSharp     LangType = "CSHARP"
+	LangPhp        LangType = "PHP"
+	LangRuby       LangType = "RUBY"
+	LangScala      LangType = "SCALA"
+	LangSwift      LangType = "SWIFT"
 	LangUnknown    LangType = "UNKNOWN"
 )
 
@@ -30,6 +46,14 @@ var SupportedLangs = []LangType{
 	LangPython,
 	LangKotlin,
 	LangJavaScript,
+	LangC,
+	LangCpp,
+	LangRust,
+	LangCSharp,
+	LangPhp,
+	LangRuby,
+	LangScala,
+	LangSwift,
 }
 
 type auxiliaryLang struct {
@@ -62,6 +86,22 @@ func LangTypeValueOf(raw string) LangType {
 		return LangKotlin
 	case LangJavaScript.GetValue():
 		return LangJavaScript
+	case LangC.GetValue():
+		return LangC
+	case LangCpp.GetValue():
+		return LangCpp
+	case LangRust.GetValue():
+		return LangRust
+	case LangCSharp.GetValue():
+		return LangCSharp
+	case LangPhp.GetValue():
+		return LangPhp
+	case LangRuby.GetValue():
+		return LangRuby
+	case LangScala.GetValue():
+		return LangScala
+	case LangSwift.GetValue():
+		return LangSwift
 	}
 	if _, ok := additionalLangs[LangType(raw)]; ok {
 		return LangType(raw)
@@ -82,6 +122,22 @@ func (langType LangType) GetLanguage() *sitter.Language {
 		return kotlin.GetLanguage()
 	case LangJavaScript:
 		return javascript.GetLanguage()
+	case LangC:
+		return c.GetLanguage()
+	case LangCpp:
+		return cpp.GetLanguage()
+	case LangRust:
+		return rust.GetLanguage()
+	case LangCSharp:
+		return csharp.GetLanguage()
+	case LangPhp:
+		return php.GetLanguage()
+	case LangRuby:
+		return ruby.GetLanguage()
+	case LangScala:
+		return scala.GetLanguage()
+	case LangSwift:
+		return swift.GetLanguage()
 	}
 	if l, ok := additionalLangs[langType]; ok {
 		return l.lang
@@ -89,24 +145,61 @@ func (langType LangType) GetLanguage() *sitter.Language {
 	return nil
 }
 
+// GetFileSuffix returns the main suffix of this language
 func (langType LangType) GetFileSuffix() string {
+	suffixes := langType.GetFileSuffixes()
+	if len(suffixes) == 0 {
+		return ""
+	}
+	return suffixes[0]
+}
+
+// GetFileSuffixes returns all the suffixes which should be routed to this language.
+// A suffix can be shared by more than one language, for example, `.h` in C and C++.
+func (langType LangType) GetFileSuffixes() []string {
 	switch langType {
 	case LangJava:
-		return ".java"
+		return []string{".java"}
 	case LangGo:
-		return ".go"
+		return []string{".go"}
 	case LangPython:
-		return ".py"
+		return []string{".py"}
 	case LangKotlin:
-		return ".kt"
+		return []string{".kt"}
 	case LangJavaScript:
-		return ".js"
+		return []string{".js"}
+	case LangC:
+		return []string{".c", ".h"}
+	case LangCpp:
+		return []string{".cpp", ".cc", ".cxx", ".hpp", ".hh", ".hxx", ".h"}
+	case LangRust:
+		return []string{".rs"}
+	case LangCSharp:
+		return []string{".cs"}
+	case LangPhp:
+		return []string{".php"}
+	case LangRuby:
+		return []string{".rb"}
+	case LangScala:
+		return []string{".scala"}
+	case LangSwift:
+		return []string{".swift"}
 	}
-	return ""
+	langMu.RLock()
+	defer langMu.RUnlock()
+	if l, ok := additionalLangs[langType]; ok {
+		return []string{l.suffix}
+	}
+	return nil
 }
 
 func (langType LangType) MatchName(name string) bool {
-	return strings.HasSuffix(name, langType.GetFileSuffix())
+	for _, each := range langType.GetFileSuffixes() {
+		if strings.HasSuffix(name, each) {
+			return true
+		}
+	}
+	return false
 }
 
 func RegisterLang(langType LangType, lang *sitter.Language, suffix string) {
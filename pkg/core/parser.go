@@ -69,9 +69,13 @@ func (p *Parser) node2Units(data []byte, curRootNode *sitter.Node, fieldName str
 	}
 	ret = append(ret, curRootUnit)
 
-	count := int(curRootNode.NamedChildCount())
+	// field names are indexed by all the children, not only the named ones
+	count := int(curRootNode.ChildCount())
 	for i := 0; i < count; i++ {
-		curChild := curRootNode.NamedChild(i)
+		curChild := curRootNode.Child(i)
+		if !curChild.IsNamed() {
+			continue
+		}
 		curChildName := curRootNode.FieldNameForChild(i)
 
 		subUnits, err := p.node2Units(data, curChild, curChildName, curRootUnit)
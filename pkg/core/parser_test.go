@@ -1,7 +1,10 @@
 package core
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 var javaCode = `
@@ -58,3 +61,29 @@ func TestParser_Parse_Golang(t *testing.T) {
 		panic(err)
 	}
 }
+
+func TestParser_Parse_FieldNames(t *testing.T) {
+	t.Parallel()
+	parser := NewParser(LangJava)
+	units, err := parser.Parse([]byte(javaCode))
+	assert.Nil(t, err)
+
+	// anonymous `.` between the fields should not shift their names
+	var call *Unit
+	for _, each := range units {
+		if each.Kind == "method_invocation" && strings.HasPrefix(each.Content, "this.storage.save") {
+			call = each
+			break
+		}
+	}
+	assert.NotNil(t, call)
+	fields := make(map[string]string)
+	for _, each := range call.SubUnits {
+		fields[each.FieldName] = each.Content
+	}
+	assert.Equal(t, map[string]string{
+		"object":    "this.storage",
+		"name":      "save",
+		"arguments": "(curMethodStack.peekLast())",
+	}, fields)
+}
@@ -101,11 +101,20 @@ func (r *Runner) parseFile(filePath string, parser *Parser, ctx context.Context)
 	return parsed, nil
 }
 
+/*
+GuessLangFromDir the language with the most files in this dir.
+
+Files with shared suffixes (`.h` of C and C++) only count toward languages which
+own some files exclusively, and ties are broken by the order of SupportedLangs:
+
+	a.c, b.h         -> C
+	a.cpp, b.h, c.h  -> CPP
+	a.h              -> C
+*/
 func (r *Runner) GuessLangFromDir(dir string, fileFilter func(string) bool) (LangType, error) {
-	countMap := make(map[LangType]int, len(SupportedLangs))
-	for _, each := range SupportedLangs {
-		countMap[each] = 0
-	}
+	exclusiveMap := make(map[LangType]int, len(SupportedLangs))
+	sharedMap := make(map[LangType]int, len(SupportedLangs))
+	hasExclusive := false
 
 	handleFunc := func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -116,11 +125,20 @@ func (r *Runner) GuessLangFromDir(dir string, fileFilter func(string) bool) (Lan
 				return nil
 			}
 		}
-		for k := range countMap {
-			if k.MatchName(path) {
-				countMap[k]++
+		var matched []LangType
+		for _, each := range SupportedLangs {
+			if each.MatchName(path) {
+				matched = append(matched, each)
 			}
 		}
+		if len(matched) == 1 {
+			exclusiveMap[matched[0]]++
+			hasExclusive = true
+			return nil
+		}
+		for _, each := range matched {
+			sharedMap[each]++
+		}
 		return nil
 	}
 	err := filepath.Walk(dir, handleFunc)
@@ -130,10 +148,14 @@ func (r *Runner) GuessLangFromDir(dir string, fileFilter func(string) bool) (Lan
 
 	ret := LangUnknown
 	max := 0
-	for k, v := range countMap {
-		if v > max {
-			ret = k
-			max = v
+	for _, each := range SupportedLangs {
+		count := exclusiveMap[each]
+		if count > 0 || !hasExclusive {
+			count += sharedMap[each]
+		}
+		if count > max {
+			ret = each
+			max = count
 		}
 	}
 	return ret, nil
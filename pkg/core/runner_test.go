@@ -1,7 +1,11 @@
 package core
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRunner_HandleFile_Golang(t *testing.T) {
@@ -12,3 +16,31 @@ func TestRunner_HandleFile_Golang(t *testing.T) {
 		panic(err)
 	}
 }
+
+func TestRunner_GuessLangFromDir(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		files []string
+		lang  LangType
+	}{
+		{[]string{"a.c", "b.h"}, LangC},
+		{[]string{"a.cpp", "b.h", "c.h"}, LangCpp},
+		{[]string{"a.c", "b.cc", "c.hpp", "d.h"}, LangCpp},
+		// headers only, C comes first in SupportedLangs
+		{[]string{"a.h", "b.h"}, LangC},
+		{[]string{"a.go", "b.h"}, LangGo},
+	}
+	runner := &Runner{}
+	for _, each := range cases {
+		dir := t.TempDir()
+		for _, name := range each.files {
+			assert.Nil(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+		}
+		// the same result for each run
+		for i := 0; i < 10; i++ {
+			lang, err := runner.GuessLangFromDir(dir, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, each.lang, lang, each.files)
+		}
+	}
+}
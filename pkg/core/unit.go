@@ -51,6 +51,11 @@ func (s *Span) HasInteraction(another *Span) bool {
 	return true
 }
 
+// Contain another span is fully inside this one
+func (s *Span) Contain(another *Span) bool {
+	return !s.Start.After(another.Start) && !another.End.After(s.End)
+}
+
 func (s *Span) String() string {
 	return fmt.Sprintf("%d:%d,%d:%d", s.Start.Row, s.Start.Column, s.End.Row, s.End.Column)
 }
@@ -59,6 +64,11 @@ func (s *Span) MatchAny(target *Span) bool {
 	return !(target.End.Row < s.Start.Row || target.Start.Row > s.End.Row)
 }
 
+// After this point is behind the target
+func (p Point) After(target Point) bool {
+	return p.Row > target.Row || (p.Row == target.Row && p.Column > target.Column)
+}
+
 type KindRepr = string
 
 /*
@@ -69,6 +69,19 @@ func FindFirstByFieldInSubs(unit *Unit, fieldName string) *Unit {
 	return nil
 }
 
+func FindFirstByKindInSubs(unit *Unit, kind KindRepr) *Unit {
+	if unit == nil {
+		return nil
+	}
+
+	for _, each := range unit.SubUnits {
+		if each.Kind == kind {
+			return each
+		}
+	}
+	return nil
+}
+
 func FindAllByKindInSubs(unit *Unit, kind string) []*Unit {
 	var ret []*Unit
 	if unit == nil {
@@ -97,6 +110,34 @@ func FindAllByKindsInSubs(unit *Unit, kinds ...string) []*Unit {
 	return ret
 }
 
+// FindNextSibling the unit right after this one in the same parent, nil if it is the last one
+func FindNextSibling(unit *Unit) *Unit {
+	if unit == nil || unit.ParentUnit == nil {
+		return nil
+	}
+	subs := unit.ParentUnit.SubUnits
+	for i, each := range subs {
+		if each == unit && i+1 < len(subs) {
+			return subs[i+1]
+		}
+	}
+	return nil
+}
+
+// FindPrevSibling the unit right before this one in the same parent, nil if it is the first one
+func FindPrevSibling(unit *Unit) *Unit {
+	if unit == nil || unit.ParentUnit == nil {
+		return nil
+	}
+	subs := unit.ParentUnit.SubUnits
+	for i, each := range subs {
+		if each == unit && i > 0 {
+			return subs[i-1]
+		}
+	}
+	return nil
+}
+
 type Query struct {
 	target       *Unit
 	IsDfs        bool
@@ -0,0 +1,287 @@
+package c
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+// https://github.com/tree-sitter/tree-sitter-c/blob/master/src/node-types.json
+const (
+	KindCTranslationUnit         core.KindRepr = "translation_unit"
+	KindCFunctionDefinition      core.KindRepr = "function_definition"
+	KindCFunctionDeclarator      core.KindRepr = "function_declarator"
+	KindCPointerDeclarator       core.KindRepr = "pointer_declarator"
+	KindCReferenceDeclarator     core.KindRepr = "reference_declarator"
+	KindCInitDeclarator          core.KindRepr = "init_declarator"
+	KindCParameterList           core.KindRepr = "parameter_list"
+	KindCParameterDecl           core.KindRepr = "parameter_declaration"
+	KindCOptionalParameterDecl   core.KindRepr = "optional_parameter_declaration"
+	KindCVariadicParameter       core.KindRepr = "variadic_parameter"
+	KindCVariadicParameterDecl   core.KindRepr = "variadic_parameter_declaration"
+	KindCCompoundStatement       core.KindRepr = "compound_statement"
+	KindCStructSpecifier         core.KindRepr = "struct_specifier"
+	KindCUnionSpecifier          core.KindRepr = "union_specifier"
+	KindCEnumSpecifier           core.KindRepr = "enum_specifier"
+	KindCFieldDeclList           core.KindRepr = "field_declaration_list"
+	KindCFieldDecl               core.KindRepr = "field_declaration"
+	KindCEnumeratorList          core.KindRepr = "enumerator_list"
+	KindCEnumerator              core.KindRepr = "enumerator"
+	KindCTypeDefinition          core.KindRepr = "type_definition"
+	KindCTypeIdentifier          core.KindRepr = "type_identifier"
+	KindCIdentifier              core.KindRepr = "identifier"
+	KindCFieldIdentifier         core.KindRepr = "field_identifier"
+	KindCPrimitiveType           core.KindRepr = "primitive_type"
+	KindCStorageClassSpecifier   core.KindRepr = "storage_class_specifier"
+	KindCTypeQualifier           core.KindRepr = "type_qualifier"
+	KindCAttributeSpecifier      core.KindRepr = "attribute_specifier"
+	KindCAttributeDeclaration    core.KindRepr = "attribute_declaration"
+	KindCMsDeclspecModifier      core.KindRepr = "ms_declspec_modifier"
+	KindCCallExpression          core.KindRepr = "call_expression"
+	KindCArgumentList            core.KindRepr = "argument_list"
+	KindCQualifiedIdentifier     core.KindRepr = "qualified_identifier"
+	KindCDestructorName          core.KindRepr = "destructor_name"
+	KindCOperatorName            core.KindRepr = "operator_name"
+	KindCTemplateFunction        core.KindRepr = "template_function"
+	KindCVirtual                 core.KindRepr = "virtual"
+	KindCExplicitFunctionSpec    core.KindRepr = "explicit_function_specifier"
+	KindCVirtualSpecifier        core.KindRepr = "virtual_specifier"
+	KindCNoexcept                core.KindRepr = "noexcept"
+	KindCTrailingReturnType      core.KindRepr = "trailing_return_type"
+	KindCParenthesizedDeclarator core.KindRepr = "parenthesized_declarator"
+	KindCPreprocInclude          core.KindRepr = "preproc_include"
+	KindCComment                 core.KindRepr = "comment"
+	KindCDeclaration             core.KindRepr = "declaration"
+	KindCIfStatement             core.KindRepr = "if_statement"
+	KindCForStatement            core.KindRepr = "for_statement"
+	KindCWhileStatement          core.KindRepr = "while_statement"
+	KindCDoStatement             core.KindRepr = "do_statement"
+	KindCCaseStatement           core.KindRepr = "case_statement"
+	KindCConditionalExpression   core.KindRepr = "conditional_expression"
+	KindCSwitchStatement         core.KindRepr = "switch_statement"
+	KindCBinaryExpression        core.KindRepr = "binary_expression"
+	KindCReturnStatement         core.KindRepr = "return_statement"
+)
+
+// specifierKinds can appear around the type part of a declaration
+var specifierKinds = []core.KindRepr{
+	KindCStorageClassSpecifier,
+	KindCTypeQualifier,
+	KindCAttributeSpecifier,
+	KindCAttributeDeclaration,
+	KindCMsDeclspecModifier,
+	KindCVirtual,
+	KindCExplicitFunctionSpec,
+}
+
+// nameKinds are the leaves which can name a declarator
+var nameKinds = []core.KindRepr{
+	KindCIdentifier,
+	KindCFieldIdentifier,
+	KindCTypeIdentifier,
+	KindCQualifiedIdentifier,
+	KindCDestructorName,
+	KindCOperatorName,
+	KindCTemplateFunction,
+}
+
+type Extractor struct {
+}
+
+func (extractor *Extractor) GetLang() core.LangType {
+	return core.LangC
+}
+
+func isDeclaratorOnly(unit *core.Unit) bool {
+	switch unit.Kind {
+	case KindCIdentifier, KindCFieldIdentifier, KindCDestructorName, KindCOperatorName:
+		return true
+	}
+	return strings.HasSuffix(unit.Kind, "_declarator")
+}
+
+func isDeclarator(unit *core.Unit) bool {
+	return isDeclaratorOnly(unit) || slices.Contains(nameKinds, unit.Kind)
+}
+
+/*
+Declaration
+
+a flatten view of c-like declarations, such as:
+
+	static const char *name = "abc";
+	|----------| |--| |-------------|
+	specifiers   type  declarators
+*/
+type Declaration struct {
+	Specifiers  []*core.Unit
+	Type        *core.Unit
+	Declarators []*core.Unit
+	Others      []*core.Unit
+}
+
+func SplitDeclaration(unit *core.Unit) *Declaration {
+	ret := &Declaration{}
+	for _, each := range unit.SubUnits {
+		switch {
+		case slices.Contains(specifierKinds, each.Kind):
+			ret.Specifiers = append(ret.Specifiers, each)
+		case ret.Type == nil && len(ret.Declarators) == 0 && !isDeclaratorOnly(each):
+			ret.Type = each
+		case isDeclarator(each):
+			ret.Declarators = append(ret.Declarators, each)
+		default:
+			ret.Others = append(ret.Others, each)
+		}
+	}
+	return ret
+}
+
+// TypePrefix type part with its qualifiers, without storage classes
+func (d *Declaration) TypePrefix() string {
+	var parts []string
+	for _, each := range d.Specifiers {
+		if each.Kind == KindCTypeQualifier {
+			parts = append(parts, each.Content)
+		}
+	}
+	if d.Type != nil {
+		parts = append(parts, d.Type.Content)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (d *Declaration) SpecifierContents() []string {
+	var ret []string
+	for _, each := range d.Specifiers {
+		ret = append(ret, each.Content)
+	}
+	return ret
+}
+
+// FindDeclaratorName dig into the declarator and find its name unit
+func FindDeclaratorName(declarator *core.Unit) *core.Unit {
+	if declarator == nil {
+		return nil
+	}
+	if slices.Contains(nameKinds, declarator.Kind) {
+		return declarator
+	}
+	for _, each := range declarator.SubUnits {
+		if isDeclarator(each) {
+			return FindDeclaratorName(each)
+		}
+	}
+	return nil
+}
+
+// FindFuncDeclarator the innermost function declarator which holds the name
+func FindFuncDeclarator(declarator *core.Unit) *core.Unit {
+	if declarator == nil {
+		return nil
+	}
+	for _, each := range declarator.SubUnits {
+		if !isDeclarator(each) {
+			continue
+		}
+		if inner := FindFuncDeclarator(each); inner != nil {
+			return inner
+		}
+		break
+	}
+	if declarator.Kind == KindCFunctionDeclarator {
+		return declarator
+	}
+	return nil
+}
+
+// TypeOfDeclarator the type which declared by `prefix` and `declarator`
+// eg: `char` + `**argv` -> `char **`
+func TypeOfDeclarator(prefix string, declarator *core.Unit) string {
+	if declarator == nil {
+		return prefix
+	}
+	if declarator.Kind == KindCInitDeclarator && len(declarator.SubUnits) != 0 {
+		// value part is not a part of type
+		declarator = declarator.SubUnits[0]
+	}
+	suffix := declarator.Content
+	if name := FindDeclaratorName(declarator); name != nil {
+		suffix = strings.Replace(suffix, name.Content, "", 1)
+	}
+	suffix = strings.Join(strings.Fields(suffix), "")
+	if suffix == "" {
+		return prefix
+	}
+	return prefix + " " + suffix
+}
+
+// Unit2ValueUnit convert param or field declaration to value unit
+func Unit2ValueUnit(unit *core.Unit) *object.ValueUnit {
+	decl := SplitDeclaration(unit)
+	var declarator *core.Unit
+	if len(decl.Declarators) != 0 {
+		declarator = decl.Declarators[0]
+	}
+	ret := &object.ValueUnit{
+		Type: TypeOfDeclarator(decl.TypePrefix(), declarator),
+	}
+	if name := FindDeclaratorName(declarator); name != nil {
+		ret.Name = name.Content
+	}
+	return ret
+}
+
+// ExtractParameters from a function declarator
+func ExtractParameters(funcDeclarator *core.Unit) []*object.ValueUnit {
+	var ret []*object.ValueUnit
+	paramList := core.FindFirstByKindInSubsWithBfs(funcDeclarator, KindCParameterList)
+	if paramList == nil {
+		return ret
+	}
+	for _, each := range paramList.SubUnits {
+		switch each.Kind {
+		case KindCParameterDecl, KindCVariadicParameterDecl:
+			ret = append(ret, Unit2ValueUnit(each))
+		case KindCOptionalParameterDecl:
+			// `int b = 0` in cpp, value comes last
+			valueUnit := Unit2ValueUnit(each)
+			if len(each.SubUnits) != 0 {
+				valueUnit.Default = each.SubUnits[len(each.SubUnits)-1].Content
+			}
+			ret = append(ret, valueUnit)
+		case KindCVariadicParameter:
+			ret = append(ret, &object.ValueUnit{Type: each.Content})
+		}
+	}
+	// `f(void)` means no params in c
+	if len(ret) == 1 && ret[0].Type == "void" && ret[0].Name == "" {
+		return nil
+	}
+	return ret
+}
+
+// ExtractReturnType of a function definition or declaration, nil for constructors
+func ExtractReturnType(unit *core.Unit) *object.ValueUnit {
+	decl := SplitDeclaration(unit)
+	if decl.Type == nil || len(decl.Declarators) == 0 {
+		return nil
+	}
+	declarator := decl.Declarators[0]
+	funcDeclarator := FindFuncDeclarator(declarator)
+	if funcDeclarator == nil {
+		return nil
+	}
+	prefix := decl.TypePrefix()
+	suffix := strings.Replace(declarator.Content, funcDeclarator.Content, "", 1)
+	suffix = strings.Join(strings.Fields(suffix), "")
+	if suffix != "" {
+		prefix = prefix + " " + suffix
+	}
+	return &object.ValueUnit{
+		Type: prefix,
+		Name: "",
+	}
+}
@@ -0,0 +1,78 @@
+package c
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsCall(unit *core.Unit) bool {
+	if unit.Kind == KindCCallExpression {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractCalls(units []*core.Unit) ([]*object.Call, error) {
+	var ret []*object.Call
+	for _, eachUnit := range units {
+		if !extractor.IsCall(eachUnit) {
+			continue
+		}
+
+		eachCall, err := extractor.unit2Call(eachUnit)
+		if err != nil {
+			core.Log.Warnf("err: %v", err)
+			continue
+		}
+		ret = append(ret, eachCall)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
+	funcUnit := core.FindFirstByKindInParent(unit, KindCFunctionDefinition)
+	var srcFunc *object.Function
+	var err error
+	if funcUnit != nil && extractor.IsFunction(funcUnit) {
+		srcFunc, err = extractor.ExtractFunction(funcUnit)
+		if err != nil {
+			return nil, errors.New("convert func failed: " + funcUnit.Content)
+		}
+	}
+
+	// headless, give up (temp
+	if srcFunc == nil {
+		return nil, errors.New("headless call")
+	}
+
+	caller, arguments := SplitCall(unit)
+	ret := &object.Call{
+		Src:       srcFunc.GetSignature(),
+		Caller:    caller,
+		Arguments: arguments,
+		Span:      unit.Span,
+	}
+	return ret, nil
+}
+
+// SplitCall returns the callee and identifier arguments of a call expression
+func SplitCall(unit *core.Unit) (string, []string) {
+	var caller string
+	if len(unit.SubUnits) != 0 {
+		caller = unit.SubUnits[0].Content
+	}
+
+	// one for each argument, overloads in cpp can be told by counts
+	var arguments []string
+	argumentPart := core.FindFirstByKindInSubs(unit, KindCArgumentList)
+	if argumentPart != nil {
+		for _, each := range argumentPart.SubUnits {
+			if each.Kind != KindCComment {
+				arguments = append(arguments, each.Content)
+			}
+		}
+	}
+	return caller, arguments
+}
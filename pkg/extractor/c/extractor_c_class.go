@@ -0,0 +1,131 @@
+package c
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type Field struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type ClassExtras struct {
+	// struct, union or enum
+	Kind   string   `json:"kind"`
+	Fields []*Field `json:"fields"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
+var classKinds = map[core.KindRepr]string{
+	KindCStructSpecifier: "struct",
+	KindCUnionSpecifier:  "union",
+	KindCEnumSpecifier:   "enum",
+}
+
+func (extractor *Extractor) IsClass(unit *core.Unit) bool {
+	// c has no class. We use struct, union and enum here.
+	if _, ok := classKinds[unit.Kind]; !ok {
+		return false
+	}
+	// `struct a *b` is only a reference
+	if FindClassBody(unit) == nil {
+		return false
+	}
+	return FindClassName(unit) != nil
+}
+
+func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
+	var ret []*object.Clazz
+	for _, eachUnit := range units {
+		if !extractor.IsClass(eachUnit) {
+			continue
+		}
+		eachClazz, err := extractor.ExtractClass(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachClazz)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error) {
+	clazz := object.NewClazz()
+	clazz.Span = unit.Span
+	clazz.Lang = extractor.GetLang()
+	clazz.Unit = unit
+
+	if name := FindClassName(unit); name != nil {
+		clazz.Name = name.Content
+	}
+
+	extras := &ClassExtras{
+		Kind: classKinds[unit.Kind],
+	}
+	body := FindClassBody(unit)
+	switch body.Kind {
+	case KindCEnumeratorList:
+		for _, each := range core.FindAllByKindInSubs(body, KindCEnumerator) {
+			nameUnit := core.FindFirstByKindInSubs(each, KindCIdentifier)
+			if nameUnit == nil {
+				continue
+			}
+			extras.Fields = append(extras.Fields, &Field{
+				Type: clazz.Name,
+				Name: nameUnit.Content,
+			})
+		}
+	default:
+		for _, each := range core.FindAllByKindInSubs(body, KindCFieldDecl) {
+			extras.Fields = append(extras.Fields, Unit2Fields(each)...)
+		}
+	}
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	clazz.Extras = extras
+	return clazz, nil
+}
+
+// FindClassBody field list of struct/union, or enumerator list of enum
+func FindClassBody(unit *core.Unit) *core.Unit {
+	for _, each := range unit.SubUnits {
+		if each.Kind == KindCFieldDeclList || each.Kind == KindCEnumeratorList {
+			return each
+		}
+	}
+	return nil
+}
+
+// FindClassName anonymous struct can be named by typedef
+func FindClassName(unit *core.Unit) *core.Unit {
+	name := core.FindFirstByKindInSubs(unit, KindCTypeIdentifier)
+	if name != nil {
+		return name
+	}
+	if unit.ParentUnit != nil && unit.ParentUnit.Kind == KindCTypeDefinition {
+		decl := SplitDeclaration(unit.ParentUnit)
+		if len(decl.Declarators) != 0 {
+			return FindDeclaratorName(decl.Declarators[0])
+		}
+	}
+	return nil
+}
+
+// Unit2Fields one field declaration can contain more than one field, eg: `int a, *b;`
+func Unit2Fields(unit *core.Unit) []*Field {
+	var ret []*Field
+	decl := SplitDeclaration(unit)
+	prefix := decl.TypePrefix()
+	for _, each := range decl.Declarators {
+		nameUnit := FindDeclaratorName(each)
+		if nameUnit == nil {
+			continue
+		}
+		ret = append(ret, &Field{
+			Type: TypeOfDeclarator(prefix, each),
+			Name: nameUnit.Content,
+		})
+	}
+	return ret
+}
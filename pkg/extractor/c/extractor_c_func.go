@@ -0,0 +1,76 @@
+package c
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type FunctionExtras struct {
+	// static, inline, extern ...
+	Qualifiers []string `json:"qualifiers"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
+	if unit.Kind != KindCFunctionDefinition {
+		return false
+	}
+	// macros can easily confuse the parser
+	decl := SplitDeclaration(unit)
+	return len(decl.Declarators) != 0 && FindFuncDeclarator(decl.Declarators[0]) != nil
+}
+
+func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Function, error) {
+	var ret []*object.Function
+	for _, eachUnit := range units {
+		if !extractor.IsFunction(eachUnit) {
+			continue
+		}
+		eachFunc, err := extractor.ExtractFunction(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachFunc)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
+	funcUnit.Span = unit.Span
+	funcUnit.Unit = unit
+	funcUnit.Lang = extractor.GetLang()
+
+	// body scope
+	funcBody := core.FindFirstByKindInSubs(unit, KindCCompoundStatement)
+	if funcBody != nil {
+		funcUnit.BodySpan = funcBody.Span
+	}
+
+	decl := SplitDeclaration(unit)
+	if len(decl.Declarators) == 0 {
+		return nil, errors.New("no declarator found in " + unit.Content)
+	}
+	funcDeclarator := FindFuncDeclarator(decl.Declarators[0])
+	funcName := FindDeclaratorName(funcDeclarator)
+	if funcName == nil {
+		return nil, errors.New("no func name found in " + unit.Content)
+	}
+	funcUnit.Name = funcName.Content
+	funcUnit.DefLine = int(funcName.Span.Start.Row + 1)
+
+	funcUnit.Parameters = ExtractParameters(funcDeclarator)
+	if ret := ExtractReturnType(unit); ret != nil && ret.Type != "void" {
+		funcUnit.Returns = append(funcUnit.Returns, ret)
+	}
+
+	funcUnit.Extras = &FunctionExtras{
+		Qualifiers: decl.SpecifierContents(),
+		Doc:        object.NewCommentRule(extractor).FindDoc(unit),
+	}
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
+	return funcUnit, nil
+}
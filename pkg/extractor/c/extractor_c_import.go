@@ -0,0 +1,41 @@
+package c
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindCPreprocInclude
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) {
+			continue
+		}
+		imp := Include2Import(eachUnit)
+		if imp == nil {
+			continue
+		}
+		imp.Lang = extractor.GetLang()
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
+
+// Include2Import `#include <stdio.h>` or `#include "a.h"`, shared with c++
+func Include2Import(unit *core.Unit) *object.Import {
+	if len(unit.SubUnits) == 0 {
+		return nil
+	}
+	imp := object.NewImport()
+	imp.Span = unit.Span
+	imp.Source = strings.Trim(unit.SubUnits[0].Content, "<>\"")
+	// everything in header is visible
+	imp.Wildcard = true
+	return imp
+}
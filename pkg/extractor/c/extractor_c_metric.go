@@ -0,0 +1,36 @@
+package c
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindCIfStatement,
+			KindCForStatement,
+			KindCWhileStatement,
+			KindCDoStatement,
+			KindCCaseStatement,
+			KindCConditionalExpression,
+		},
+		FlowKinds: []core.KindRepr{
+			KindCIfStatement,
+			KindCForStatement,
+			KindCWhileStatement,
+			KindCDoStatement,
+			KindCSwitchStatement,
+			KindCConditionalExpression,
+		},
+		BinaryKinds: []core.KindRepr{
+			KindCBinaryExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindCReturnStatement,
+		},
+		CommentKinds: []core.KindRepr{
+			KindCComment,
+		},
+	}
+}
@@ -0,0 +1,239 @@
+package c
+
+import (
+	"testing"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
+)
+
+var cCode = `
+#include <stdio.h>
+
+struct point {
+    int x, *y;
+};
+
+typedef struct { char *name; } named_t;
+
+enum color { RED, GREEN = 2 };
+
+struct point *ref;
+
+static const char *get_name(const named_t *n, int idx) {
+    printf("%s", n->name);
+    return helper(n, idx);
+}
+
+int *make(void);
+
+int sum(int n, ...) {
+    return 0;
+}
+
+void (*signal(int sig, void (*func)(int)))(int) {
+    return func;
+}
+
+void run(void) {
+}
+`
+
+func TestExtractor_ExtractFunctions(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangC)
+	units, err := parser.Parse([]byte(cCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 4)
+
+	getName := funcs[0]
+	assert.Equal(t, "get_name", getName.Name)
+	assert.Equal(t, core.LangC, getName.Lang)
+	assert.Equal(t, 14, getName.DefLine)
+	assert.Equal(t, "const char *", getName.Returns[0].Type)
+	assert.Equal(t, "const named_t *", getName.Parameters[0].Type)
+	assert.Equal(t, "n", getName.Parameters[0].Name)
+	assert.Equal(t, "int", getName.Parameters[1].Type)
+	assert.Equal(t, []string{"static", "const"}, getName.Extras.(*FunctionExtras).Qualifiers)
+	assert.Equal(t, "||get_name|const named_t *,int|const char *", getName.GetSignature())
+
+	sum := funcs[1]
+	assert.Equal(t, "...", sum.Parameters[1].Type)
+
+	signal := funcs[2]
+	assert.Equal(t, "signal", signal.Name)
+	assert.Equal(t, "void (*)(int)", signal.Parameters[1].Type)
+	assert.Equal(t, "func", signal.Parameters[1].Name)
+
+	run := funcs[3]
+	assert.Empty(t, run.Parameters)
+	assert.Empty(t, run.Returns)
+}
+
+func TestExtractor_ExtractClasses(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangC)
+	units, err := parser.Parse([]byte(cCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 3)
+
+	point := classes[0]
+	assert.Equal(t, "point", point.Name)
+	fields := point.Extras.(*ClassExtras).Fields
+	assert.Len(t, fields, 2)
+	assert.Equal(t, "int *", fields[1].Type)
+	assert.Equal(t, "y", fields[1].Name)
+
+	assert.Equal(t, "named_t", classes[1].Name)
+	assert.Equal(t, "enum", classes[2].Extras.(*ClassExtras).Kind)
+	assert.Len(t, classes[2].Extras.(*ClassExtras).Fields, 2)
+}
+
+func TestExtractor_ExtractCalls(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangC)
+	units, err := parser.Parse([]byte(cCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	assert.Len(t, calls, 2)
+	assert.Equal(t, "helper", calls[1].Caller)
+	assert.Equal(t, []string{"n", "idx"}, calls[1].Arguments)
+}
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangC)
+	units, err := parser.Parse([]byte(cCode + "#include \"local/a.h\"\n"))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 2)
+	assert.Equal(t, "stdio.h", imports[0].Source)
+	assert.Equal(t, "local/a.h", imports[1].Source)
+	assert.True(t, imports[1].Wildcard)
+}
+
+var cCommentCode = `
+/* the point */
+struct point {
+    int x; // x axis
+};
+
+// add two numbers
+static int add(int a, int b) {
+    return a + b;
+}
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangC)
+	units, err := parser.Parse([]byte(cCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "the point", comments[0].Text)
+	assert.Equal(t, ".point", comments[0].Owner)
+	assert.Empty(t, comments[1].Owner)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "add two numbers", functions[0].Extras.(*FunctionExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "the point", classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var cVariableCode = `
+static const int MAX = 10;
+int count = 0, *p;
+int f(int a);
+
+struct point {
+    int x;
+};
+
+void g() {
+    int y = 1;
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangC)
+	units, err := parser.Parse([]byte(cVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 5)
+
+	assert.Equal(t, "MAX", variables[0].Name)
+	assert.Equal(t, "const int", variables[0].Type)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeModule, variables[0].Scope)
+
+	assert.Equal(t, "p", variables[2].Name)
+	assert.Equal(t, "int *", variables[2].Type)
+	assert.Equal(t, object.MutabilityVar, variables[2].Mutability)
+
+	assert.Equal(t, "x", variables[3].Name)
+	assert.Equal(t, object.VariableScopeClass, variables[3].Scope)
+	assert.Equal(t, "point", variables[3].Receiver)
+
+	assert.Equal(t, "y", variables[4].Name)
+	assert.Equal(t, object.VariableScopeLocal, variables[4].Scope)
+}
+
+var cMetricCode = `
+int f(int a) {
+  /* c */
+  if (a > 0 && a < 3) { return 1; } else if (a < 0) { return 2; } else {}
+  for (int i = 0; i < 3; i++) {}
+  while (a > 0) {}
+  do {} while (a > 0);
+  switch (a) { case 1: break; default: break; }
+  int b = a > 0 ? 1 : 2;
+  goto end;
+  return 0;
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangC)
+	units, err := parser.Parse([]byte(cMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 9, metrics.Cyclomatic)
+	assert.Equal(t, 8, metrics.Cognitive)
+	assert.Equal(t, 10, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
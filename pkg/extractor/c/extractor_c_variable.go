@@ -0,0 +1,91 @@
+package c
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	return IsVariableDeclaration(unit)
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		var receiver string
+		scope := object.VariableScopeModule
+		owner := core.FindFirstByOneOfKindInParent(eachUnit, KindCCompoundStatement, KindCFieldDeclList)
+		switch {
+		case owner == nil:
+		case owner.Kind == KindCFieldDeclList:
+			scope = object.VariableScopeClass
+			if name := FindClassName(owner.ParentUnit); name != nil {
+				receiver = name.Content
+			}
+		default:
+			scope = object.VariableScopeLocal
+		}
+		for _, each := range Unit2Variables(eachUnit) {
+			each.Scope = scope
+			each.Receiver = receiver
+			each.Lang = extractor.GetLang()
+			ret = append(ret, each)
+		}
+	}
+	return ret, nil
+}
+
+// IsVariableDeclaration declarations and fields, except functions, eg: `int f(int a);`
+func IsVariableDeclaration(unit *core.Unit) bool {
+	if unit.Kind != KindCDeclaration && unit.Kind != KindCFieldDecl {
+		return false
+	}
+	decl := SplitDeclaration(unit)
+	if len(decl.Declarators) == 0 {
+		return false
+	}
+	for _, each := range decl.Declarators {
+		if FindFuncDeclarator(each) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Unit2Variables one declaration can contain more than one variable, eg: `int a = 1, *b;`
+func Unit2Variables(unit *core.Unit) []*object.Variable {
+	var ret []*object.Variable
+	decl := SplitDeclaration(unit)
+	prefix := decl.TypePrefix()
+	mutability := object.MutabilityVar
+	for _, each := range decl.Specifiers {
+		if each.Kind == KindCTypeQualifier && slices.Contains([]string{"const", "constexpr"}, each.Content) {
+			mutability = object.MutabilityConst
+		}
+	}
+	for _, each := range decl.Declarators {
+		nameUnit := FindDeclaratorName(each)
+		if nameUnit == nil {
+			continue
+		}
+		v := object.NewVariable()
+		v.Name = nameUnit.Content
+		v.Type = TypeOfDeclarator(prefix, each)
+		v.Mutability = mutability
+		v.Span = unit.Span
+		// init_declarator: declarator, value
+		if each.Kind == KindCInitDeclarator && len(each.SubUnits) > 1 {
+			v.Value = each.SubUnits[len(each.SubUnits)-1].Content
+		}
+		ret = append(ret, v)
+	}
+	// default member initializer in c++, eg: `int a = 1;` in classes
+	if len(ret) == 1 && ret[0].Value == "" && len(decl.Others) != 0 {
+		ret[0].Value = decl.Others[len(decl.Others)-1].Content
+	}
+	return ret
+}
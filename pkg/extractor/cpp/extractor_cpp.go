@@ -0,0 +1,115 @@
+package cpp
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/c"
+)
+
+// https://github.com/tree-sitter/tree-sitter-cpp/blob/master/src/node-types.json
+// c++ grammar extends c grammar, so kinds defined in package c are shared.
+const (
+	KindCppNamespaceDefinition  core.KindRepr = "namespace_definition"
+	KindCppNamespaceIdentifier  core.KindRepr = "namespace_identifier"
+	KindCppClassSpecifier       core.KindRepr = "class_specifier"
+	KindCppBaseClassClause      core.KindRepr = "base_class_clause"
+	KindCppAccessSpecifier      core.KindRepr = "access_specifier"
+	KindCppTemplateDeclaration  core.KindRepr = "template_declaration"
+	KindCppTemplateType         core.KindRepr = "template_type"
+	KindCppDeclaration          core.KindRepr = "declaration"
+	KindCppRefQualifier         core.KindRepr = "ref_qualifier"
+	KindCppDefaultMethodClause  core.KindRepr = "default_method_clause"
+	KindCppDeleteMethodClause   core.KindRepr = "delete_method_clause"
+	KindCppPureVirtualClause    core.KindRepr = "pure_virtual_clause"
+	KindCppFieldInitializerList core.KindRepr = "field_initializer_list"
+	KindCppNewExpression        core.KindRepr = "new_expression"
+	KindCppNestedNamespaceSpec  core.KindRepr = "nested_namespace_specifier"
+	KindCppUsingDeclaration     core.KindRepr = "using_declaration"
+	KindCppForRangeLoop         core.KindRepr = "for_range_loop"
+	KindCppCatchClause          core.KindRepr = "catch_clause"
+	KindCppLambdaExpression     core.KindRepr = "lambda_expression"
+	ScopeSplit                                = "::"
+)
+
+var classKinds = map[core.KindRepr]string{
+	KindCppClassSpecifier:  "class",
+	c.KindCStructSpecifier: "struct",
+	c.KindCUnionSpecifier:  "union",
+	c.KindCEnumSpecifier:   "enum",
+}
+
+type Extractor struct {
+}
+
+func (extractor *Extractor) GetLang() core.LangType {
+	return core.LangCpp
+}
+
+// SplitScope split `a::b<c::d>::e` to [a, b<c::d>, e]
+func SplitScope(name string) []string {
+	var ret []string
+	depth := 0
+	last := 0
+	for i := 0; i < len(name); i++ {
+		switch name[i] {
+		case '<', '(':
+			depth++
+		case '>', ')':
+			depth--
+		case ':':
+			if depth == 0 && i+1 < len(name) && name[i+1] == ':' {
+				ret = append(ret, name[last:i])
+				last = i + 2
+				i++
+			}
+		}
+	}
+	return append(ret, name[last:])
+}
+
+// findNamespace joined names of all the namespaces around this unit
+func findNamespace(unit *core.Unit) string {
+	var parts []string
+	for cur := unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		if cur.Kind != KindCppNamespaceDefinition {
+			continue
+		}
+		nameUnit := core.FindFirstByKindInSubs(cur, KindCppNamespaceIdentifier)
+		if nameUnit == nil {
+			nameUnit = core.FindFirstByKindInSubs(cur, KindCppNestedNamespaceSpec)
+		}
+		// anonymous namespace
+		if nameUnit == nil {
+			continue
+		}
+		parts = append([]string{nameUnit.Content}, parts...)
+	}
+	return strings.Join(parts, ScopeSplit)
+}
+
+// findClassPath joined names of all the classes around this unit, eg: Outer::Inner
+func findClassPath(unit *core.Unit) string {
+	var parts []string
+	for cur := unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		if _, ok := classKinds[cur.Kind]; !ok {
+			continue
+		}
+		nameUnit := findClassName(cur)
+		if nameUnit == nil {
+			continue
+		}
+		parts = append([]string{nameUnit.Content}, parts...)
+	}
+	return strings.Join(parts, ScopeSplit)
+}
+
+func findClassName(unit *core.Unit) *core.Unit {
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case c.KindCTypeIdentifier, c.KindCQualifiedIdentifier, KindCppTemplateType:
+			return each
+		}
+	}
+	return c.FindClassName(unit)
+}
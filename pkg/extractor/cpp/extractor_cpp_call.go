@@ -0,0 +1,59 @@
+package cpp
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/c"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsCall(unit *core.Unit) bool {
+	if unit.Kind == c.KindCCallExpression {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractCalls(units []*core.Unit) ([]*object.Call, error) {
+	var ret []*object.Call
+	for _, eachUnit := range units {
+		if !extractor.IsCall(eachUnit) {
+			continue
+		}
+
+		eachCall, err := extractor.unit2Call(eachUnit)
+		if err != nil {
+			core.Log.Warnf("err: %v", err)
+			continue
+		}
+		ret = append(ret, eachCall)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
+	funcUnit := core.FindFirstByKindInParent(unit, c.KindCFunctionDefinition)
+	var srcFunc *object.Function
+	var err error
+	if funcUnit != nil && extractor.IsFunction(funcUnit) {
+		srcFunc, err = extractor.ExtractFunction(funcUnit)
+		if err != nil {
+			return nil, errors.New("convert func failed: " + funcUnit.Content)
+		}
+	}
+
+	// headless, give up (temp
+	if srcFunc == nil {
+		return nil, errors.New("headless call")
+	}
+
+	caller, arguments := c.SplitCall(unit)
+	ret := &object.Call{
+		Src:       srcFunc.GetSignature(),
+		Caller:    caller,
+		Arguments: arguments,
+		Span:      unit.Span,
+	}
+	return ret, nil
+}
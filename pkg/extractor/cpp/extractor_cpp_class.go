@@ -0,0 +1,156 @@
+package cpp
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/c"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type ClassField struct {
+	Type       string   `json:"type"`
+	Name       string   `json:"name"`
+	Access     string   `json:"access"`
+	Qualifiers []string `json:"qualifiers"`
+}
+
+type ClassMethod struct {
+	Name       string              `json:"name"`
+	Access     string              `json:"access"`
+	Qualifiers []string            `json:"qualifiers"`
+	Parameters []*object.ValueUnit `json:"parameters"`
+	Returns    []*object.ValueUnit `json:"returns"`
+}
+
+type ClassExtras struct {
+	// class, struct, union or enum
+	Kind    string         `json:"kind"`
+	Bases   []string       `json:"bases"`
+	Fields  []*ClassField  `json:"fields"`
+	Methods []*ClassMethod `json:"methods"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsClass(unit *core.Unit) bool {
+	if _, ok := classKinds[unit.Kind]; !ok {
+		return false
+	}
+	// `class A;` is only a declaration
+	if c.FindClassBody(unit) == nil {
+		return false
+	}
+	return findClassName(unit) != nil
+}
+
+func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
+	var ret []*object.Clazz
+	for _, eachUnit := range units {
+		if !extractor.IsClass(eachUnit) {
+			continue
+		}
+		eachClazz, err := extractor.ExtractClass(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachClazz)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error) {
+	clazz := object.NewClazz()
+	clazz.Span = unit.Span
+	clazz.Lang = extractor.GetLang()
+	clazz.Unit = unit
+	clazz.Module = findNamespace(unit)
+
+	name := findClassName(unit).Content
+	if outer := findClassPath(unit); outer != "" {
+		name = outer + ScopeSplit + name
+	}
+	clazz.Name = name
+
+	extras := &ClassExtras{
+		Kind: classKinds[unit.Kind],
+	}
+	bases := core.FindFirstByKindInSubs(unit, KindCppBaseClassClause)
+	if bases != nil {
+		for _, each := range bases.SubUnits {
+			if each.Kind == KindCppAccessSpecifier {
+				continue
+			}
+			extras.Bases = append(extras.Bases, each.Content)
+		}
+	}
+
+	body := c.FindClassBody(unit)
+	if body.Kind == c.KindCEnumeratorList {
+		for _, each := range core.FindAllByKindInSubs(body, c.KindCEnumerator) {
+			nameUnit := core.FindFirstByKindInSubs(each, c.KindCIdentifier)
+			if nameUnit == nil {
+				continue
+			}
+			extras.Fields = append(extras.Fields, &ClassField{
+				Type:   clazz.Name,
+				Name:   nameUnit.Content,
+				Access: "public",
+			})
+		}
+		extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+		clazz.Extras = extras
+		return clazz, nil
+	}
+
+	access := "public"
+	if unit.Kind == KindCppClassSpecifier {
+		access = "private"
+	}
+	for _, each := range body.SubUnits {
+		member := each
+		if member.Kind == KindCppTemplateDeclaration {
+			member = member.SubUnits[len(member.SubUnits)-1]
+		}
+
+		switch member.Kind {
+		case KindCppAccessSpecifier:
+			access = strings.TrimSpace(strings.TrimSuffix(member.Content, ":"))
+		case c.KindCFieldDecl, KindCppDeclaration, c.KindCFunctionDefinition:
+			decl := c.SplitDeclaration(member)
+			if len(decl.Declarators) == 0 {
+				// nested class decl, extract it independently
+				continue
+			}
+			funcDeclarator := c.FindFuncDeclarator(decl.Declarators[0])
+			if funcDeclarator == nil {
+				for _, eachField := range c.Unit2Fields(member) {
+					extras.Fields = append(extras.Fields, &ClassField{
+						Type:       eachField.Type,
+						Name:       eachField.Name,
+						Access:     access,
+						Qualifiers: decl.SpecifierContents(),
+					})
+				}
+				continue
+			}
+			nameUnit := c.FindDeclaratorName(funcDeclarator)
+			if nameUnit == nil {
+				continue
+			}
+			method := &ClassMethod{
+				Name:       nameUnit.Content,
+				Access:     access,
+				Qualifiers: extractQualifiers(member, decl, funcDeclarator),
+				Parameters: c.ExtractParameters(funcDeclarator),
+			}
+			if ret := c.ExtractReturnType(member); ret != nil && ret.Type != "void" {
+				method.Returns = append(method.Returns, ret)
+			}
+			extras.Methods = append(extras.Methods, method)
+		}
+	}
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	clazz.Extras = extras
+	return clazz, nil
+}
@@ -0,0 +1,118 @@
+package cpp
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/c"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type FunctionExtras struct {
+	// static, virtual, const, override, noexcept, default ...
+	Qualifiers []string `json:"qualifiers"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
+	if unit.Kind != c.KindCFunctionDefinition {
+		return false
+	}
+	// `virtual void a() = 0;` declares a method only
+	if core.FindFirstByKindInSubs(unit, KindCppPureVirtualClause) != nil {
+		return false
+	}
+	decl := c.SplitDeclaration(unit)
+	return len(decl.Declarators) != 0 && c.FindFuncDeclarator(decl.Declarators[0]) != nil
+}
+
+func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Function, error) {
+	var ret []*object.Function
+	for _, eachUnit := range units {
+		if !extractor.IsFunction(eachUnit) {
+			continue
+		}
+		eachFunc, err := extractor.ExtractFunction(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachFunc)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
+	funcUnit.Span = unit.Span
+	funcUnit.Unit = unit
+	funcUnit.Lang = extractor.GetLang()
+
+	// body scope
+	funcBody := core.FindFirstByKindInSubs(unit, c.KindCCompoundStatement)
+	if funcBody != nil {
+		funcUnit.BodySpan = funcBody.Span
+	}
+
+	decl := c.SplitDeclaration(unit)
+	if len(decl.Declarators) == 0 {
+		return nil, errors.New("no declarator found in " + unit.Content)
+	}
+	funcDeclarator := c.FindFuncDeclarator(decl.Declarators[0])
+	funcName := c.FindDeclaratorName(funcDeclarator)
+	if funcName == nil {
+		return nil, errors.New("no func name found in " + unit.Content)
+	}
+	funcUnit.DefLine = int(funcName.Span.Start.Row + 1)
+	funcUnit.Namespace = findNamespace(unit)
+	funcUnit.Receiver = findClassPath(unit)
+
+	// out-of-class definition: `Foo::bar`, map it back to its class
+	scopes := SplitScope(funcName.Content)
+	funcUnit.Name = scopes[len(scopes)-1]
+	if len(scopes) > 1 {
+		outer := strings.Join(scopes[:len(scopes)-1], ScopeSplit)
+		if funcUnit.Receiver == "" {
+			funcUnit.Receiver = outer
+		} else {
+			funcUnit.Receiver = funcUnit.Receiver + ScopeSplit + outer
+		}
+	}
+
+	funcUnit.Parameters = c.ExtractParameters(funcDeclarator)
+	if ret := c.ExtractReturnType(unit); ret != nil && ret.Type != "void" {
+		funcUnit.Returns = append(funcUnit.Returns, ret)
+	}
+
+	funcUnit.Extras = &FunctionExtras{
+		Qualifiers: extractQualifiers(unit, decl, funcDeclarator),
+		Doc:        object.NewCommentRule(extractor).FindDoc(unit),
+	}
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
+	return funcUnit, nil
+}
+
+// extractQualifiers collect qualifiers from both the declaration and declarator
+// eg: `virtual int a() const override = 0;` -> [virtual, const, override, pure]
+func extractQualifiers(unit *core.Unit, decl *c.Declaration, funcDeclarator *core.Unit) []string {
+	ret := decl.SpecifierContents()
+	for _, each := range funcDeclarator.SubUnits {
+		switch each.Kind {
+		case c.KindCTypeQualifier, c.KindCVirtualSpecifier, c.KindCNoexcept, KindCppRefQualifier:
+			ret = append(ret, each.Content)
+		}
+	}
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindCppDefaultMethodClause:
+			ret = append(ret, "default")
+		case KindCppDeleteMethodClause:
+			ret = append(ret, "delete")
+		case KindCppPureVirtualClause:
+			// `= 0`
+			ret = append(ret, "pure")
+		}
+	}
+	return ret
+}
@@ -0,0 +1,46 @@
+package cpp
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/c"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == c.KindCPreprocInclude || unit.Kind == KindCppUsingDeclaration
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) || len(eachUnit.SubUnits) == 0 {
+			continue
+		}
+		var imp *object.Import
+		if eachUnit.Kind == c.KindCPreprocInclude {
+			imp = c.Include2Import(eachUnit)
+		} else {
+			imp = object.NewImport()
+			imp.Span = eachUnit.Span
+			name := eachUnit.SubUnits[0].Content
+			if strings.HasPrefix(eachUnit.Content, "using namespace") {
+				// using namespace std;
+				imp.Source = name
+				imp.Wildcard = true
+			} else {
+				// using std::string;
+				scopes := SplitScope(name)
+				imp.Source = strings.Join(scopes[:len(scopes)-1], ScopeSplit)
+				imp.AddName(scopes[len(scopes)-1], "")
+			}
+		}
+		if imp == nil {
+			continue
+		}
+		imp.Lang = extractor.GetLang()
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
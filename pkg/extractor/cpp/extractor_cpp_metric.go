@@ -0,0 +1,44 @@
+package cpp
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/c"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			c.KindCIfStatement,
+			c.KindCForStatement,
+			KindCppForRangeLoop,
+			c.KindCWhileStatement,
+			c.KindCDoStatement,
+			c.KindCCaseStatement,
+			KindCppCatchClause,
+			c.KindCConditionalExpression,
+		},
+		FlowKinds: []core.KindRepr{
+			c.KindCIfStatement,
+			c.KindCForStatement,
+			KindCppForRangeLoop,
+			c.KindCWhileStatement,
+			c.KindCDoStatement,
+			c.KindCSwitchStatement,
+			KindCppCatchClause,
+			c.KindCConditionalExpression,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindCppLambdaExpression,
+		},
+		BinaryKinds: []core.KindRepr{
+			c.KindCBinaryExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			c.KindCReturnStatement,
+		},
+		CommentKinds: []core.KindRepr{
+			c.KindCComment,
+		},
+	}
+}
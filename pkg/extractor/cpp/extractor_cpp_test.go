@@ -0,0 +1,261 @@
+package cpp
+
+import (
+	"testing"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
+)
+
+var cppCode = `
+#include <vector>
+namespace outer {
+namespace inner {
+
+template <typename T>
+class Foo : public Base, private Other<T> {
+public:
+    Foo(int a);
+    virtual ~Foo();
+    int bar(const std::string &s, int n = 3) const override;
+    static Foo *create();
+    virtual void pure() = 0;
+    int inl() const noexcept { return value_; }
+private:
+    int value_;
+    std::vector<int> items;
+    struct Nested { int z; void nm() {} };
+};
+
+int Foo::bar(const std::string &s, int n) const {
+    auto x = helper(s);
+    this->inl();
+    obj.method(1, 2);
+    ns::free_fn(n);
+    return n;
+}
+
+Foo::Foo(int a) : value_(a) {}
+Foo::~Foo() {}
+void Foo::Nested::nm2() {}
+std::vector<int> &get(std::vector<int> &&v) { return v; }
+bool operator==(const Foo &a, const Foo &b) { return true; }
+}
+}
+`
+
+func TestExtractor_ExtractFunctions(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCpp)
+	units, err := parser.Parse([]byte(cppCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 8)
+
+	// in-class definition
+	inl := funcs[0]
+	assert.Equal(t, "inl", inl.Name)
+	assert.Equal(t, "Foo", inl.Receiver)
+	assert.Equal(t, "outer::inner", inl.Namespace)
+	assert.Equal(t, []string{"const", "noexcept"}, inl.Extras.(*FunctionExtras).Qualifiers)
+
+	assert.Equal(t, "Foo::Nested", funcs[1].Receiver)
+
+	// out-of-class definition
+	bar := funcs[2]
+	assert.Equal(t, "bar", bar.Name)
+	assert.Equal(t, "Foo", bar.Receiver)
+	assert.Equal(t, 21, bar.DefLine)
+	assert.Equal(t, "outer::inner|Foo|bar|const std::string &,int|int", bar.GetSignature())
+
+	ctor := funcs[3]
+	assert.Equal(t, "Foo", ctor.Name)
+	assert.Empty(t, ctor.Returns)
+	assert.Equal(t, "~Foo", funcs[4].Name)
+
+	nm2 := funcs[5]
+	assert.Equal(t, "nm2", nm2.Name)
+	assert.Equal(t, "Foo::Nested", nm2.Receiver)
+
+	get := funcs[6]
+	assert.Equal(t, "", get.Receiver)
+	assert.Equal(t, "std::vector<int> &", get.Returns[0].Type)
+	assert.Equal(t, "std::vector<int> &&", get.Parameters[0].Type)
+
+	assert.Equal(t, "operator==", funcs[7].Name)
+}
+
+func TestExtractor_ExtractClasses(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCpp)
+	units, err := parser.Parse([]byte(cppCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 2)
+
+	foo := classes[0]
+	assert.Equal(t, "Foo", foo.Name)
+	assert.Equal(t, "outer::inner", foo.Module)
+	extras := foo.Extras.(*ClassExtras)
+	assert.Equal(t, "class", extras.Kind)
+	assert.Equal(t, []string{"Base", "Other<T>"}, extras.Bases)
+	assert.Len(t, extras.Fields, 2)
+	assert.Equal(t, "private", extras.Fields[0].Access)
+	assert.Len(t, extras.Methods, 6)
+	assert.Equal(t, "public", extras.Methods[0].Access)
+	assert.Equal(t, []string{"const", "override"}, extras.Methods[2].Qualifiers)
+	assert.Equal(t, []string{"virtual", "pure"}, extras.Methods[4].Qualifiers)
+
+	nested := classes[1]
+	assert.Equal(t, "Foo::Nested", nested.Name)
+	assert.Equal(t, "struct", nested.Extras.(*ClassExtras).Kind)
+}
+
+func TestExtractor_ExtractCalls(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCpp)
+	units, err := parser.Parse([]byte(cppCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	assert.Len(t, calls, 4)
+	assert.Equal(t, "outer::inner|Foo|bar|const std::string &,int|int", calls[0].Src)
+	assert.Equal(t, "obj.method", calls[2].Caller)
+	assert.Equal(t, "ns::free_fn", calls[3].Caller)
+}
+
+var cppImportCode = `
+#include <vector>
+using namespace std;
+using std::string;
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCpp)
+	units, err := parser.Parse([]byte(cppImportCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 3)
+	assert.Equal(t, "vector", imports[0].Source)
+	assert.Equal(t, "std", imports[1].Source)
+	assert.True(t, imports[1].Wildcard)
+	assert.Equal(t, "std", imports[2].Source)
+	assert.Equal(t, []string{"string"}, imports[2].Names)
+	assert.Equal(t, core.LangCpp, imports[2].Lang)
+}
+
+var cppCommentCode = `
+/// max of two values
+template <typename T>
+T max(T a, T b) { return a > b ? a : b; }
+
+// a shape
+class Shape {};
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCpp)
+	units, err := parser.Parse([]byte(cppCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "max of two values", comments[0].Text)
+	assert.Equal(t, "||max|T,T|T", comments[0].Owner)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "max of two values", functions[0].Extras.(*FunctionExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "a shape", classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var cppVariableCode = `
+constexpr int MAX = 10;
+
+namespace n {
+std::string name = "a";
+
+class A {
+    static const int x = 1;
+    int y;
+    void f() { auto z = 1; }
+};
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCpp)
+	units, err := parser.Parse([]byte(cppVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 5)
+
+	assert.Equal(t, "MAX", variables[0].Name)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeModule, variables[0].Scope)
+
+	assert.Equal(t, "name", variables[1].Name)
+	assert.Equal(t, "std::string", variables[1].Type)
+	assert.Equal(t, "n", variables[1].Namespace)
+
+	// default member initializer
+	assert.Equal(t, "x", variables[2].Name)
+	assert.Equal(t, "1", variables[2].Value)
+	assert.Equal(t, object.VariableScopeClass, variables[2].Scope)
+	assert.Equal(t, "A", variables[2].Receiver)
+
+	assert.Equal(t, object.MutabilityVar, variables[3].Mutability)
+	assert.Equal(t, object.VariableScopeLocal, variables[4].Scope)
+}
+
+var cppMetricCode = `
+int f(int a) {
+  if (a > 0 and a < 3) { return 1; } else if (a < 0) { return 2; }
+  for (auto x : xs) {}
+  try {} catch (std::exception& e) {}
+  auto l = [](int x) { return x; };
+  return 0;
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCpp)
+	units, err := parser.Parse([]byte(cppMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 6, metrics.Cyclomatic)
+	assert.Equal(t, 5, metrics.Cognitive)
+	assert.Equal(t, 7, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
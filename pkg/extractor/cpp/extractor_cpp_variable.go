@@ -0,0 +1,39 @@
+package cpp
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/c"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	return c.IsVariableDeclaration(unit)
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		scope := object.VariableScopeModule
+		owner := core.FindFirstByOneOfKindInParent(eachUnit, c.KindCCompoundStatement, c.KindCFieldDeclList)
+		switch {
+		case owner == nil:
+		case owner.Kind == c.KindCFieldDeclList:
+			scope = object.VariableScopeClass
+		default:
+			scope = object.VariableScopeLocal
+		}
+		for _, each := range c.Unit2Variables(eachUnit) {
+			each.Scope = scope
+			each.Namespace = findNamespace(eachUnit)
+			if scope == object.VariableScopeClass {
+				each.Receiver = findClassPath(eachUnit)
+			}
+			each.Lang = extractor.GetLang()
+			ret = append(ret, each)
+		}
+	}
+	return ret, nil
+}
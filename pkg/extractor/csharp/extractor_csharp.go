@@ -0,0 +1,139 @@
+package csharp
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+// https://github.com/tree-sitter/tree-sitter-c-sharp/blob/master/src/node-types.json
+const (
+	KindCSharpCompilationUnit          core.KindRepr = "compilation_unit"
+	KindCSharpNamespaceDeclaration     core.KindRepr = "namespace_declaration"
+	KindCSharpFileScopedNamespaceDecl  core.KindRepr = "file_scoped_namespace_declaration"
+	KindCSharpClassDeclaration         core.KindRepr = "class_declaration"
+	KindCSharpInterfaceDeclaration     core.KindRepr = "interface_declaration"
+	KindCSharpRecordDeclaration        core.KindRepr = "record_declaration"
+	KindCSharpRecordStructDeclaration  core.KindRepr = "record_struct_declaration"
+	KindCSharpStructDeclaration        core.KindRepr = "struct_declaration"
+	KindCSharpEnumDeclaration          core.KindRepr = "enum_declaration"
+	KindCSharpMethodDeclaration        core.KindRepr = "method_declaration"
+	KindCSharpConstructorDeclaration   core.KindRepr = "constructor_declaration"
+	KindCSharpPropertyDeclaration      core.KindRepr = "property_declaration"
+	KindCSharpFieldDeclaration         core.KindRepr = "field_declaration"
+	KindCSharpVariableDeclaration      core.KindRepr = "variable_declaration"
+	KindCSharpVariableDeclarator       core.KindRepr = "variable_declarator"
+	KindCSharpEnumMemberDeclList       core.KindRepr = "enum_member_declaration_list"
+	KindCSharpEnumMemberDeclaration    core.KindRepr = "enum_member_declaration"
+	KindCSharpDeclarationList          core.KindRepr = "declaration_list"
+	KindCSharpAttributeList            core.KindRepr = "attribute_list"
+	KindCSharpAttribute                core.KindRepr = "attribute"
+	KindCSharpModifier                 core.KindRepr = "modifier"
+	KindCSharpBaseList                 core.KindRepr = "base_list"
+	KindCSharpIdentifier               core.KindRepr = "identifier"
+	KindCSharpTypeParameterList        core.KindRepr = "type_parameter_list"
+	KindCSharpTypeParameterConstraints core.KindRepr = "type_parameter_constraints_clause"
+	KindCSharpParameterList            core.KindRepr = "parameter_list"
+	KindCSharpParameter                core.KindRepr = "parameter"
+	KindCSharpExplicitInterfaceSpec    core.KindRepr = "explicit_interface_specifier"
+	KindCSharpBlock                    core.KindRepr = "block"
+	KindCSharpArrowExpressionClause    core.KindRepr = "arrow_expression_clause"
+	KindCSharpAccessorList             core.KindRepr = "accessor_list"
+	KindCSharpAccessorDeclaration      core.KindRepr = "accessor_declaration"
+	KindCSharpInvocationExpression     core.KindRepr = "invocation_expression"
+	KindCSharpArgumentList             core.KindRepr = "argument_list"
+	KindCSharpArgument                 core.KindRepr = "argument"
+	KindCSharpUsingDirective           core.KindRepr = "using_directive"
+	KindCSharpComment                  core.KindRepr = "comment"
+	KindCSharpLocalDeclaration         core.KindRepr = "local_declaration_statement"
+	KindCSharpImplicitType             core.KindRepr = "implicit_type"
+	KindCSharpIfStatement              core.KindRepr = "if_statement"
+	KindCSharpForStatement             core.KindRepr = "for_statement"
+	KindCSharpForEachStatement         core.KindRepr = "foreach_statement"
+	KindCSharpWhileStatement           core.KindRepr = "while_statement"
+	KindCSharpDoStatement              core.KindRepr = "do_statement"
+	KindCSharpSwitchSection            core.KindRepr = "switch_section"
+	KindCSharpCatchClause              core.KindRepr = "catch_clause"
+	KindCSharpConditionalExpression    core.KindRepr = "conditional_expression"
+	KindCSharpSwitchStatement          core.KindRepr = "switch_statement"
+	KindCSharpLambdaExpression         core.KindRepr = "lambda_expression"
+	KindCSharpLocalFunctionStatement   core.KindRepr = "local_function_statement"
+	KindCSharpBinaryExpression         core.KindRepr = "binary_expression"
+	KindCSharpReturnStatement          core.KindRepr = "return_statement"
+	FieldCSharpName                    core.KindRepr = "name"
+	FieldCSharpType                    core.KindRepr = "type"
+	NamespaceSplit                                   = "."
+)
+
+var classKinds = map[core.KindRepr]string{
+	KindCSharpClassDeclaration:        "class",
+	KindCSharpInterfaceDeclaration:    "interface",
+	KindCSharpRecordDeclaration:       "record",
+	KindCSharpRecordStructDeclaration: "record struct",
+	KindCSharpStructDeclaration:       "struct",
+	KindCSharpEnumDeclaration:         "enum",
+}
+
+type Extractor struct {
+}
+
+func (extractor *Extractor) GetLang() core.LangType {
+	return core.LangCSharp
+}
+
+func findNamespace(unit *core.Unit) string {
+	var parts []string
+	var root *core.Unit
+	for cur := unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		root = cur
+		if cur.Kind != KindCSharpNamespaceDeclaration && cur.Kind != KindCSharpFileScopedNamespaceDecl {
+			continue
+		}
+		if len(cur.SubUnits) == 0 {
+			continue
+		}
+		parts = append([]string{cur.SubUnits[0].Content}, parts...)
+	}
+	if len(parts) == 0 && root != nil {
+		// file scoped namespace can also be a sibling of types
+		fileScoped := core.FindFirstByKindInSubs(root, KindCSharpFileScopedNamespaceDecl)
+		if fileScoped != nil && len(fileScoped.SubUnits) != 0 {
+			parts = append(parts, fileScoped.SubUnits[0].Content)
+		}
+	}
+	return strings.Join(parts, NamespaceSplit)
+}
+
+// findTypePath joined names of all the types around this unit, eg: Outer.Inner
+func findTypePath(unit *core.Unit) string {
+	var parts []string
+	for cur := unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		if _, ok := classKinds[cur.Kind]; !ok {
+			continue
+		}
+		name := core.FindFirstByKindInSubs(cur, KindCSharpIdentifier)
+		if name == nil {
+			continue
+		}
+		parts = append([]string{name.Content}, parts...)
+	}
+	return strings.Join(parts, NamespaceSplit)
+}
+
+func findAttributes(unit *core.Unit) []string {
+	var ret []string
+	for _, eachList := range core.FindAllByKindInSubs(unit, KindCSharpAttributeList) {
+		for _, each := range core.FindAllByKindInSubs(eachList, KindCSharpAttribute) {
+			ret = append(ret, each.Content)
+		}
+	}
+	return ret
+}
+
+func findModifiers(unit *core.Unit) []string {
+	var ret []string
+	for _, each := range core.FindAllByKindInSubs(unit, KindCSharpModifier) {
+		ret = append(ret, each.Content)
+	}
+	return ret
+}
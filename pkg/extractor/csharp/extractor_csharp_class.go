@@ -0,0 +1,145 @@
+package csharp
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type ClassField struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Attributes []string `json:"attributes"`
+	Modifiers  []string `json:"modifiers"`
+}
+
+type ClassExtras struct {
+	// class, interface, record, struct or enum
+	Kind           string        `json:"kind"`
+	Attributes     []string      `json:"attributes"`
+	Modifiers      []string      `json:"modifiers"`
+	BaseTypes      []string      `json:"baseTypes"`
+	TypeParameters string        `json:"typeParameters"`
+	Fields         []*ClassField `json:"fields"`
+	Properties     []*ClassField `json:"properties"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsClass(unit *core.Unit) bool {
+	_, ok := classKinds[unit.Kind]
+	return ok
+}
+
+func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
+	var ret []*object.Clazz
+	for _, eachUnit := range units {
+		if !extractor.IsClass(eachUnit) {
+			continue
+		}
+		eachClazz, err := extractor.ExtractClass(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachClazz)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error) {
+	clazz := object.NewClazz()
+	clazz.Span = unit.Span
+	clazz.Lang = extractor.GetLang()
+	clazz.Unit = unit
+	clazz.Module = findNamespace(unit)
+
+	name := core.FindFirstByKindInSubs(unit, KindCSharpIdentifier)
+	if name == nil {
+		return nil, errors.New("no class name found in " + unit.Content)
+	}
+	clazz.Name = name.Content
+	if outer := findTypePath(unit); outer != "" {
+		clazz.Name = outer + NamespaceSplit + clazz.Name
+	}
+
+	extras := &ClassExtras{
+		Kind:       classKinds[unit.Kind],
+		Attributes: findAttributes(unit),
+		Modifiers:  findModifiers(unit),
+	}
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindCSharpBaseList:
+			for _, eachBase := range each.SubUnits {
+				extras.BaseTypes = append(extras.BaseTypes, eachBase.Content)
+			}
+		case KindCSharpTypeParameterList:
+			extras.TypeParameters = each.Content
+		case KindCSharpParameterList:
+			// record primary constructor
+			for _, eachParam := range extractParameters(each) {
+				extras.Properties = append(extras.Properties, &ClassField{
+					Name: eachParam.Name,
+					Type: eachParam.Type,
+				})
+			}
+		case KindCSharpEnumMemberDeclList:
+			for _, eachMember := range core.FindAllByKindInSubs(each, KindCSharpEnumMemberDeclaration) {
+				memberName := core.FindFirstByKindInSubs(eachMember, KindCSharpIdentifier)
+				if memberName == nil {
+					continue
+				}
+				extras.Fields = append(extras.Fields, &ClassField{
+					Name: memberName.Content,
+					Type: clazz.Name,
+				})
+			}
+		case KindCSharpDeclarationList:
+			extras.Fields = append(extras.Fields, extractFields(each)...)
+			for _, eachProp := range core.FindAllByKindInSubs(each, KindCSharpPropertyDeclaration) {
+				prop, err := extractor.ExtractFunction(eachProp)
+				if err != nil {
+					return nil, err
+				}
+				field := &ClassField{
+					Name:       prop.Name,
+					Attributes: findAttributes(eachProp),
+					Modifiers:  findModifiers(eachProp),
+				}
+				if len(prop.Returns) != 0 {
+					field.Type = prop.Returns[0].Type
+				}
+				extras.Properties = append(extras.Properties, field)
+			}
+		}
+	}
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	clazz.Extras = extras
+	return clazz, nil
+}
+
+func extractFields(unit *core.Unit) []*ClassField {
+	var ret []*ClassField
+	for _, each := range core.FindAllByKindInSubs(unit, KindCSharpFieldDeclaration) {
+		variableDecl := core.FindFirstByKindInSubs(each, KindCSharpVariableDeclaration)
+		if variableDecl == nil || len(variableDecl.SubUnits) == 0 {
+			continue
+		}
+		// int a, b = 1;
+		typeName := variableDecl.SubUnits[0].Content
+		for _, eachDeclarator := range core.FindAllByKindInSubs(variableDecl, KindCSharpVariableDeclarator) {
+			name := core.FindFirstByKindInSubs(eachDeclarator, KindCSharpIdentifier)
+			if name == nil {
+				continue
+			}
+			ret = append(ret, &ClassField{
+				Name:       name.Content,
+				Type:       typeName,
+				Attributes: findAttributes(each),
+				Modifiers:  findModifiers(each),
+			})
+		}
+	}
+	return ret
+}
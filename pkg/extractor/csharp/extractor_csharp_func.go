@@ -0,0 +1,165 @@
+package csharp
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type FunctionExtras struct {
+	// method, constructor or property
+	Kind           string   `json:"kind"`
+	Attributes     []string `json:"attributes"`
+	Modifiers      []string `json:"modifiers"`
+	TypeParameters string   `json:"typeParameters"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+}
+
+var funcKinds = map[core.KindRepr]string{
+	KindCSharpMethodDeclaration:      "method",
+	KindCSharpConstructorDeclaration: "constructor",
+	KindCSharpPropertyDeclaration:    "property",
+}
+
+func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
+	_, ok := funcKinds[unit.Kind]
+	return ok
+}
+
+func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Function, error) {
+	var ret []*object.Function
+	for _, eachUnit := range units {
+		if !extractor.IsFunction(eachUnit) {
+			continue
+		}
+		eachFunc, err := extractor.ExtractFunction(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachFunc)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
+	funcUnit.Span = unit.Span
+	funcUnit.Unit = unit
+	funcUnit.Lang = extractor.GetLang()
+
+	funcUnit.Namespace = findNamespace(unit)
+	funcUnit.Receiver = findTypePath(unit)
+	if funcUnit.Namespace != "" && funcUnit.Receiver != "" {
+		funcUnit.Receiver = funcUnit.Namespace + NamespaceSplit + funcUnit.Receiver
+	}
+
+	extras := &FunctionExtras{
+		Kind:       funcKinds[unit.Kind],
+		Attributes: findAttributes(unit),
+		Modifiers:  findModifiers(unit),
+	}
+
+	// [attributes] [modifiers] [type] name [type params] [params] [constraints] body
+	nameIndex := -1
+	nameFound := false
+	for i, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindCSharpParameterList:
+			funcUnit.Parameters = extractParameters(each)
+			nameFound = true
+		case KindCSharpTypeParameterList:
+			extras.TypeParameters = each.Content
+			nameFound = true
+		case KindCSharpBlock, KindCSharpArrowExpressionClause, KindCSharpAccessorList:
+			funcUnit.BodySpan = each.Span
+			nameFound = true
+		case KindCSharpIdentifier:
+			// the last identifier before params and body
+			if !nameFound {
+				nameIndex = i
+			}
+		}
+	}
+	if nameIndex == -1 {
+		return nil, errors.New("no func name found in " + unit.Content)
+	}
+	funcName := unit.SubUnits[nameIndex]
+	funcUnit.Name = funcName.Content
+	funcUnit.DefLine = int(funcName.Span.Start.Row + 1)
+
+	// returns, ctor has no return type
+	if unit.Kind != KindCSharpConstructorDeclaration && nameIndex > 0 {
+		retUnit := unit.SubUnits[nameIndex-1]
+		if retUnit.Kind == KindCSharpExplicitInterfaceSpec && nameIndex > 1 {
+			retUnit = unit.SubUnits[nameIndex-2]
+		}
+		if retUnit.Kind != KindCSharpModifier && retUnit.Kind != KindCSharpAttributeList && retUnit.Content != "void" {
+			funcUnit.Returns = append(funcUnit.Returns, &object.ValueUnit{
+				Type: retUnit.Content,
+				// c# has no named return value
+				Name: "",
+			})
+		}
+	}
+
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	funcUnit.Extras = extras
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
+	return funcUnit, nil
+}
+
+func extractParameters(unit *core.Unit) []*object.ValueUnit {
+	var ret []*object.ValueUnit
+	var pendingType *core.Unit
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindCSharpParameter:
+			if param := parameter2ValueUnit(each); param != nil {
+				ret = append(ret, param)
+			}
+		case KindCSharpIdentifier:
+			// `params string[] args` can be flattened into the parameter list
+			if pendingType != nil {
+				ret = append(ret, &object.ValueUnit{
+					Type: pendingType.Content,
+					Name: each.Content,
+				})
+				pendingType = nil
+				continue
+			}
+			pendingType = each
+		case KindCSharpAttributeList:
+			continue
+		default:
+			pendingType = each
+		}
+	}
+	return ret
+}
+
+func parameter2ValueUnit(unit *core.Unit) *object.ValueUnit {
+	// [attributes] [modifiers] type name [= default]
+	nameIndex := -1
+	for i, each := range unit.SubUnits {
+		if each.FieldName == FieldCSharpName {
+			nameIndex = i
+			break
+		}
+	}
+	if nameIndex == -1 {
+		return nil
+	}
+	ret := &object.ValueUnit{
+		Name: unit.SubUnits[nameIndex].Content,
+	}
+	if typeUnit := core.FindFirstByFieldInSubs(unit, FieldCSharpType); typeUnit != nil {
+		ret.Type = typeUnit.Content
+	}
+	// the default value has no field name, it is the only node after the name
+	if nameIndex+1 < len(unit.SubUnits) {
+		ret.Default = unit.SubUnits[nameIndex+1].Content
+	}
+	return ret
+}
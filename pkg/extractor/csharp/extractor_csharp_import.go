@@ -0,0 +1,39 @@
+package csharp
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindCSharpUsingDirective
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) || len(eachUnit.SubUnits) == 0 {
+			continue
+		}
+		imp := object.NewImport()
+		imp.Span = eachUnit.Span
+		imp.Lang = extractor.GetLang()
+
+		// using Json = Newtonsoft.Json;
+		if alias := core.FindFirstByFieldInSubs(eachUnit, FieldCSharpName); alias != nil {
+			imp.Alias = alias.Content
+			imp.Source = eachUnit.SubUnits[len(eachUnit.SubUnits)-1].Content
+			ret = append(ret, imp)
+			continue
+		}
+		// using System.Text;
+		// using static System.Math;
+		imp.Source = eachUnit.SubUnits[len(eachUnit.SubUnits)-1].Content
+		imp.Static = strings.Contains(eachUnit.Content, "using static ")
+		imp.Wildcard = true
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
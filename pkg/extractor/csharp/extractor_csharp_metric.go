@@ -0,0 +1,44 @@
+package csharp
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindCSharpIfStatement,
+			KindCSharpForStatement,
+			KindCSharpForEachStatement,
+			KindCSharpWhileStatement,
+			KindCSharpDoStatement,
+			KindCSharpSwitchSection,
+			KindCSharpCatchClause,
+			KindCSharpConditionalExpression,
+		},
+		FlowKinds: []core.KindRepr{
+			KindCSharpIfStatement,
+			KindCSharpForStatement,
+			KindCSharpForEachStatement,
+			KindCSharpWhileStatement,
+			KindCSharpDoStatement,
+			KindCSharpSwitchStatement,
+			KindCSharpCatchClause,
+			KindCSharpConditionalExpression,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindCSharpLambdaExpression,
+			KindCSharpLocalFunctionStatement,
+		},
+		BinaryKinds: []core.KindRepr{
+			KindCSharpBinaryExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindCSharpReturnStatement,
+		},
+		CommentKinds: []core.KindRepr{
+			KindCSharpComment,
+		},
+	}
+}
@@ -0,0 +1,312 @@
+package csharp
+
+import (
+	"testing"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
+)
+
+var csharpCode = `
+using System;
+using System.Collections.Generic;
+
+namespace Acme.Web
+{
+    [Serializable]
+    [Obsolete("x")]
+    public class OrderService : BaseService, IOrderService<Order>
+    {
+        private readonly int _count = 0;
+        public string Name { get; set; }
+
+        public OrderService(ILogger logger, int count = 3, string sep = "a=b") : base(logger)
+        {
+            _count = count;
+        }
+
+        [HttpGet("orders/{id}")]
+        public async Task<Order> GetAsync(int id, params string[] tags)
+        {
+            var x = repo.Find(id);
+            Console.WriteLine(x);
+            Helper<int>(id);
+            return await Task.FromResult(x);
+        }
+
+        static void Main(string[] args) { }
+
+        public class Inner { void Run() {} }
+    }
+
+    public interface IOrderService<T> : IDisposable where T : class
+    {
+        T Get(int id);
+    }
+
+    public record Person(string FirstName, int Age);
+    public struct Point { public int X; public int Y; }
+    public enum Color { Red, Green = 2 }
+}
+`
+
+var csharpFileScopedCode = `
+namespace Acme.FileScoped;
+
+public class A { void B() { C(); } }
+`
+
+func TestExtractor_ExtractFunctions(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCSharp)
+	units, err := parser.Parse([]byte(csharpCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 6)
+
+	prop := funcs[0]
+	assert.Equal(t, "Name", prop.Name)
+	assert.Equal(t, "property", prop.Extras.(*FunctionExtras).Kind)
+	assert.Equal(t, "string", prop.Returns[0].Type)
+
+	ctor := funcs[1]
+	assert.Equal(t, "OrderService", ctor.Name)
+	assert.Equal(t, "Acme.Web.OrderService", ctor.Receiver)
+	assert.Empty(t, ctor.Returns)
+	assert.Len(t, ctor.Parameters, 3)
+	assert.Equal(t, "ILogger", ctor.Parameters[0].Type)
+	assert.Empty(t, ctor.Parameters[0].Default)
+	assert.Equal(t, "count", ctor.Parameters[1].Name)
+	assert.Equal(t, "3", ctor.Parameters[1].Default)
+	assert.Equal(t, "string", ctor.Parameters[2].Type)
+	assert.Equal(t, "sep", ctor.Parameters[2].Name)
+	assert.Equal(t, `"a=b"`, ctor.Parameters[2].Default)
+
+	getAsync := funcs[2]
+	assert.Equal(t, "GetAsync", getAsync.Name)
+	assert.Equal(t, 20, getAsync.DefLine)
+	assert.Equal(t, []string{"HttpGet(\"orders/{id}\")"}, getAsync.Extras.(*FunctionExtras).Attributes)
+	assert.Equal(t, []string{"public", "async"}, getAsync.Extras.(*FunctionExtras).Modifiers)
+	assert.Equal(t, "Acme.Web|Acme.Web.OrderService|GetAsync|int,string[]|Task<Order>", getAsync.GetSignature())
+
+	main := funcs[3]
+	assert.Empty(t, main.Returns)
+	assert.Equal(t, "string[]", main.Parameters[0].Type)
+
+	assert.Equal(t, "Acme.Web.OrderService.Inner", funcs[4].Receiver)
+
+	get := funcs[5]
+	assert.Equal(t, "Get", get.Name)
+	assert.Equal(t, "T", get.Returns[0].Type)
+}
+
+func TestExtractor_ExtractFunctionsFileScoped(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCSharp)
+	units, err := parser.Parse([]byte(csharpFileScopedCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 1)
+	assert.Equal(t, "Acme.FileScoped|Acme.FileScoped.A|B||", funcs[0].GetSignature())
+}
+
+func TestExtractor_ExtractClasses(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCSharp)
+	units, err := parser.Parse([]byte(csharpCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 6)
+
+	service := classes[0]
+	assert.Equal(t, "OrderService", service.Name)
+	assert.Equal(t, "Acme.Web", service.Module)
+	extras := service.Extras.(*ClassExtras)
+	assert.Equal(t, []string{"Serializable", "Obsolete(\"x\")"}, extras.Attributes)
+	assert.Equal(t, []string{"BaseService", "IOrderService<Order>"}, extras.BaseTypes)
+	assert.Len(t, extras.Fields, 1)
+	assert.Equal(t, []string{"private", "readonly"}, extras.Fields[0].Modifiers)
+	assert.Len(t, extras.Properties, 1)
+	assert.Equal(t, "string", extras.Properties[0].Type)
+
+	assert.Equal(t, "OrderService.Inner", classes[1].Name)
+
+	iface := classes[2].Extras.(*ClassExtras)
+	assert.Equal(t, "interface", iface.Kind)
+	assert.Equal(t, []string{"IDisposable"}, iface.BaseTypes)
+	assert.Equal(t, "<T>", iface.TypeParameters)
+
+	record := classes[3].Extras.(*ClassExtras)
+	assert.Equal(t, "record", record.Kind)
+	assert.Len(t, record.Properties, 2)
+
+	assert.Len(t, classes[4].Extras.(*ClassExtras).Fields, 2)
+	assert.Equal(t, "enum", classes[5].Extras.(*ClassExtras).Kind)
+	assert.Len(t, classes[5].Extras.(*ClassExtras).Fields, 2)
+}
+
+func TestExtractor_ExtractCalls(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCSharp)
+	units, err := parser.Parse([]byte(csharpCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	assert.Len(t, calls, 4)
+	assert.Equal(t, "repo.Find", calls[0].Caller)
+	assert.Equal(t, []string{"id"}, calls[0].Arguments)
+	assert.Equal(t, "Helper<int>", calls[2].Caller)
+	assert.Equal(t, "Acme.Web|Acme.Web.OrderService|GetAsync|int,string[]|Task<Order>", calls[3].Src)
+}
+
+var csharpImportCode = `
+using System.Text;
+using static System.Math;
+using Json = Newtonsoft.Json;
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCSharp)
+	units, err := parser.Parse([]byte(csharpImportCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 3)
+	assert.Equal(t, "System.Text", imports[0].Source)
+	assert.True(t, imports[0].Wildcard)
+	assert.False(t, imports[0].Static)
+	assert.Empty(t, imports[0].Alias)
+	assert.True(t, imports[1].Static)
+	assert.Empty(t, imports[1].Alias)
+	assert.Equal(t, "Newtonsoft.Json", imports[2].Source)
+	assert.Equal(t, "Json", imports[2].Alias)
+}
+
+var csharpCommentCode = `
+namespace A {
+    /// <summary>A user.</summary>
+    [Serializable]
+    public class User {
+        // full name
+        public string Name() { return ""; }
+    }
+}
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCSharp)
+	units, err := parser.Parse([]byte(csharpCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "<summary>A user.</summary>", comments[0].Text)
+	assert.Equal(t, "A.User", comments[0].Owner)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "full name", functions[0].Extras.(*FunctionExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "<summary>A user.</summary>", classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var csharpVariableCode = `
+namespace App.Models
+{
+    class User
+    {
+        public const int Max = 10;
+        private static readonly string name = "a", alias;
+
+        void F()
+        {
+            var y = 1;
+        }
+    }
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCSharp)
+	units, err := parser.Parse([]byte(csharpVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 4)
+
+	assert.Equal(t, "Max", variables[0].Name)
+	assert.Equal(t, "int", variables[0].Type)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeClass, variables[0].Scope)
+	assert.Equal(t, "App.Models", variables[0].Namespace)
+	assert.Equal(t, "App.Models.User", variables[0].Receiver)
+
+	assert.Equal(t, "alias", variables[2].Name)
+	assert.Equal(t, "string", variables[2].Type)
+	assert.Equal(t, object.MutabilityFinal, variables[2].Mutability)
+
+	assert.Equal(t, "y", variables[3].Name)
+	assert.Empty(t, variables[3].Type)
+	assert.Equal(t, object.VariableScopeLocal, variables[3].Scope)
+}
+
+var csharpMetricCode = `
+class A {
+  int F(int a) {
+    if (a > 0 && a < 3) { return 1; } else if (a < 0) { return 2; } else {}
+    for (int i = 0; i < 3; i++) {}
+    foreach (var x in xs) {}
+    while (a > 0) {}
+    do {} while (a > 0);
+    switch (a) { case 1: break; default: break; }
+    try {} catch (Exception e) {} finally {}
+    var b = a > 0 ? 1 : 2;
+    var c = a ?? 1;
+    Func<int,int> l = x => x;
+    return 0;
+  }
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangCSharp)
+	units, err := parser.Parse([]byte(csharpMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 11, metrics.Cyclomatic)
+	assert.Equal(t, 10, metrics.Cognitive)
+	assert.Equal(t, 13, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
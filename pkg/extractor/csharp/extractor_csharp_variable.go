@@ -0,0 +1,78 @@
+package csharp
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	if unit.Kind != KindCSharpFieldDeclaration && unit.Kind != KindCSharpLocalDeclaration {
+		return false
+	}
+	return core.FindFirstByKindInSubs(unit, KindCSharpVariableDeclaration) != nil
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		ret = append(ret, extractor.extractVariable(eachUnit)...)
+	}
+	return ret, nil
+}
+
+// extractVariable [modifiers], variable_declaration(type, declarators...)
+func (extractor *Extractor) extractVariable(unit *core.Unit) []*object.Variable {
+	mutability := object.MutabilityVar
+	for _, each := range core.FindAllByKindInSubs(unit, KindCSharpModifier) {
+		switch each.Content {
+		case "const":
+			mutability = object.MutabilityConst
+		case "readonly":
+			mutability = object.MutabilityFinal
+		}
+	}
+
+	namespace := findNamespace(unit)
+	scope := object.VariableScopeClass
+	var receiver string
+	if unit.Kind == KindCSharpLocalDeclaration {
+		scope = object.VariableScopeLocal
+	} else {
+		receiver = findTypePath(unit)
+		if namespace != "" && receiver != "" {
+			receiver = namespace + NamespaceSplit + receiver
+		}
+	}
+
+	decl := core.FindFirstByKindInSubs(unit, KindCSharpVariableDeclaration)
+	var typeName string
+	if len(decl.SubUnits) != 0 && decl.SubUnits[0].Kind != KindCSharpVariableDeclarator &&
+		decl.SubUnits[0].Kind != KindCSharpImplicitType {
+		typeName = decl.SubUnits[0].Content
+	}
+	var ret []*object.Variable
+	for _, eachDeclarator := range core.FindAllByKindInSubs(decl, KindCSharpVariableDeclarator) {
+		name := core.FindFirstByKindInSubs(eachDeclarator, KindCSharpIdentifier)
+		if name == nil {
+			continue
+		}
+		v := object.NewVariable()
+		v.Name = name.Content
+		v.Type = typeName
+		// `name = value`
+		if len(eachDeclarator.SubUnits) > 1 && eachDeclarator.SubUnits[0] == name {
+			v.Value = eachDeclarator.SubUnits[len(eachDeclarator.SubUnits)-1].Content
+		}
+		v.Mutability = mutability
+		v.Scope = scope
+		v.Namespace = namespace
+		v.Receiver = receiver
+		v.Span = unit.Span
+		v.Lang = extractor.GetLang()
+		ret = append(ret, v)
+	}
+	return ret
+}
@@ -4,12 +4,20 @@ import (
 	"sync"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/c"
+	"github.com/opensibyl/sibyl2/pkg/extractor/cpp"
+	"github.com/opensibyl/sibyl2/pkg/extractor/csharp"
 	"github.com/opensibyl/sibyl2/pkg/extractor/golang"
 	"github.com/opensibyl/sibyl2/pkg/extractor/java"
 	"github.com/opensibyl/sibyl2/pkg/extractor/javascript"
 	"github.com/opensibyl/sibyl2/pkg/extractor/kotlin"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/opensibyl/sibyl2/pkg/extractor/php"
 	"github.com/opensibyl/sibyl2/pkg/extractor/python"
+	"github.com/opensibyl/sibyl2/pkg/extractor/ruby"
+	"github.com/opensibyl/sibyl2/pkg/extractor/rust"
+	"github.com/opensibyl/sibyl2/pkg/extractor/scala"
+	"github.com/opensibyl/sibyl2/pkg/extractor/swift"
 )
 
 /*
@@ -26,6 +34,9 @@ type Extractor interface {
 	FunctionSupport
 	CallSupport
 	ClassSupport
+	ImportSupport
+	CommentSupport
+	VariableSupport
 }
 
 type ExtractType = string
@@ -41,6 +52,9 @@ const (
 	TypeExtractSymbol   ExtractType = "symbol"
 	TypeExtractCall     ExtractType = "call"
 	TypeExtractClazz    ExtractType = "class"
+	TypeExtractImport   ExtractType = "import"
+	TypeExtractComment  ExtractType = "comment"
+	TypeExtractVariable ExtractType = "variable"
 )
 
 type SymbolSupport interface {
@@ -65,6 +79,22 @@ type CallSupport interface {
 	ExtractCalls([]*core.Unit) ([]*Call, error)
 }
 
+type ImportSupport interface {
+	IsImport(*core.Unit) bool
+	ExtractImports([]*core.Unit) ([]*Import, error)
+}
+
+type CommentSupport interface {
+	IsComment(*core.Unit) bool
+	ExtractComments([]*core.Unit) ([]*Comment, error)
+}
+
+type VariableSupport interface {
+	IsVariable(*core.Unit) bool
+	// ExtractVariables variables in all the scopes, including local variables
+	ExtractVariables([]*core.Unit) ([]*Variable, error)
+}
+
 func GetExtractor(lang core.LangType) Extractor {
 	switch lang {
 	case core.LangJava:
@@ -77,6 +107,22 @@ func GetExtractor(lang core.LangType) Extractor {
 		return &kotlin.Extractor{}
 	case core.LangJavaScript:
 		return &javascript.Extractor{}
+	case core.LangC:
+		return &c.Extractor{}
+	case core.LangCpp:
+		return &cpp.Extractor{}
+	case core.LangRust:
+		return &rust.Extractor{}
+	case core.LangCSharp:
+		return &csharp.Extractor{}
+	case core.LangPhp:
+		return &php.Extractor{}
+	case core.LangRuby:
+		return &ruby.Extractor{}
+	case core.LangScala:
+		return &scala.Extractor{}
+	case core.LangSwift:
+		return &swift.Extractor{}
 	}
 	if e, ok := additionalExtractors[lang]; ok {
 		return e
@@ -100,3 +146,6 @@ type Function = object.Function
 type Symbol = object.Symbol
 type Call = object.Call
 type Clazz = object.Clazz
+type Import = object.Import
+type Comment = object.Comment
+type Variable = object.Variable
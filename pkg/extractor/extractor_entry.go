@@ -0,0 +1,90 @@
+package extractor
+
+import (
+	"sync"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type EntryPoint = object.EntryPoint
+
+/*
+EntryPointDetector find the registrations of entry points in a file
+
+Built-in extractors detect the common frameworks.
+Others can be plugged in with RegisterEntryPointDetector.
+*/
+type EntryPointDetector interface {
+	DetectEntryPoints([]*core.Unit) ([]*EntryPoint, error)
+}
+
+var (
+	entryPointDetectorMu sync.RWMutex
+	entryPointDetectors  = make(map[core.LangType][]EntryPointDetector)
+)
+
+func RegisterEntryPointDetector(langType core.LangType, detector EntryPointDetector) {
+	entryPointDetectorMu.Lock()
+	defer entryPointDetectorMu.Unlock()
+	if detector == nil {
+		panic("entry point detector is nil")
+	}
+	entryPointDetectors[langType] = append(entryPointDetectors[langType], detector)
+}
+
+// GetEntryPointDetectors the built-in one, and the registered ones
+func GetEntryPointDetectors(lang core.LangType) []EntryPointDetector {
+	var ret []EntryPointDetector
+	if builtin, ok := GetExtractor(lang).(EntryPointDetector); ok {
+		ret = append(ret, builtin)
+	}
+	entryPointDetectorMu.RLock()
+	defer entryPointDetectorMu.RUnlock()
+	return append(ret, entryPointDetectors[lang]...)
+}
+
+func DetectEntryPoints(lang core.LangType, units []*core.Unit) ([]*EntryPoint, error) {
+	var ret []*EntryPoint
+	for _, each := range GetEntryPointDetectors(lang) {
+		entries, err := each.DetectEntryPoints(units)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, entries...)
+	}
+	return ret, nil
+}
+
+/*
+BindEntryPoints attach entry points to their handler functions, and return the unbound ones
+
+Handlers found in place (annotated methods, closures) are bound by units.
+Others are bound by names, only if the name is unique in these functions.
+*/
+func BindEntryPoints(entries []*EntryPoint, functions []*Function) []*EntryPoint {
+	byUnit := make(map[*core.Unit]*Function, len(functions))
+	byName := make(map[string][]*Function, len(functions))
+	for _, each := range functions {
+		if each.Unit != nil {
+			byUnit[each.Unit] = each
+		}
+		byName[each.Name] = append(byName[each.Name], each)
+	}
+
+	var unbound []*EntryPoint
+	for _, each := range entries {
+		var handler *Function
+		if each.Unit != nil {
+			handler = byUnit[each.Unit]
+		} else if candidates := byName[each.HandlerName()]; len(candidates) == 1 {
+			handler = candidates[0]
+		}
+		if handler == nil {
+			unbound = append(unbound, each)
+			continue
+		}
+		handler.EntryPoints = append(handler.EntryPoints, each)
+	}
+	return unbound
+}
@@ -0,0 +1,50 @@
+package extractor
+
+import (
+	"sync"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+/*
+FunctionFileHook adjust the functions extracted from a file, by the things only known from its path
+
+Built-in extractors handle their own conventions, eg: go tests only run in `_test.go`.
+Others can be plugged in with RegisterFunctionFileHook.
+*/
+type FunctionFileHook interface {
+	HookFileFunctions(path string, functions []*Function) []*Function
+}
+
+var (
+	functionFileHookMu sync.RWMutex
+	functionFileHooks  = make(map[core.LangType][]FunctionFileHook)
+)
+
+func RegisterFunctionFileHook(langType core.LangType, hook FunctionFileHook) {
+	functionFileHookMu.Lock()
+	defer functionFileHookMu.Unlock()
+	if hook == nil {
+		panic("function file hook is nil")
+	}
+	functionFileHooks[langType] = append(functionFileHooks[langType], hook)
+}
+
+// GetFunctionFileHooks the built-in one, and the registered ones
+func GetFunctionFileHooks(lang core.LangType) []FunctionFileHook {
+	var ret []FunctionFileHook
+	if builtin, ok := GetExtractor(lang).(FunctionFileHook); ok {
+		ret = append(ret, builtin)
+	}
+	functionFileHookMu.RLock()
+	defer functionFileHookMu.RUnlock()
+	return append(ret, functionFileHooks[lang]...)
+}
+
+// HookFileFunctions run all the hooks of this language in order
+func HookFileFunctions(lang core.LangType, path string, functions []*Function) []*Function {
+	for _, each := range GetFunctionFileHooks(lang) {
+		functions = each.HookFileFunctions(path, functions)
+	}
+	return functions
+}
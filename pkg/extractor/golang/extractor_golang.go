@@ -6,26 +6,65 @@ import (
 
 // https://github.com/tree-sitter/tree-sitter-go/blob/master/src/node-types.json
 const (
-	KindGolangMethodDecl        core.KindRepr = "method_declaration"
-	KindGolangFuncDecl          core.KindRepr = "function_declaration"
-	KindGolangIdentifier        core.KindRepr = "identifier"
-	KindGolangFieldIdentifier   core.KindRepr = "field_identifier"
-	KindGolangTypeIdentifier    core.KindRepr = "type_identifier"
-	KindGolangParameterList     core.KindRepr = "parameter_list"
-	KindGolangParameterDecl     core.KindRepr = "parameter_declaration"
-	KindGolangCallExpression    core.KindRepr = "call_expression"
-	KindGolangTypeSpec          core.KindRepr = "type_spec"
-	KindGolangStructType        core.KindRepr = "struct_type"
-	KindGolangFieldDeclList     core.KindRepr = "field_declaration_list"
-	KindGolangFieldDecl         core.KindRepr = "field_declaration"
-	KindGolangPackageIdentifier core.KindRepr = "package_identifier"
-	KindGolangSourceFile        core.KindRepr = "source_file"
-	KindGolangBlock             core.KindRepr = "block"
-	FieldGolangType             core.KindRepr = "type"
-	FieldGolangName             core.KindRepr = "name"
-	FieldGolangParameters       core.KindRepr = "parameters"
-	FieldGolangFunction         core.KindRepr = "function"
-	FieldGolangArguments        core.KindRepr = "arguments"
+	KindGolangMethodDecl                core.KindRepr = "method_declaration"
+	KindGolangFuncDecl                  core.KindRepr = "function_declaration"
+	KindGolangFuncLiteral               core.KindRepr = "func_literal"
+	KindGolangIdentifier                core.KindRepr = "identifier"
+	KindGolangFieldIdentifier           core.KindRepr = "field_identifier"
+	KindGolangTypeIdentifier            core.KindRepr = "type_identifier"
+	KindGolangParameterList             core.KindRepr = "parameter_list"
+	KindGolangParameterDecl             core.KindRepr = "parameter_declaration"
+	KindGolangCallExpression            core.KindRepr = "call_expression"
+	KindGolangImportSpec                core.KindRepr = "import_spec"
+	KindGolangTypeSpec                  core.KindRepr = "type_spec"
+	KindGolangTypeAlias                 core.KindRepr = "type_alias"
+	KindGolangTypeParameterList         core.KindRepr = "type_parameter_list"
+	KindGolangTypeParameterDecl         core.KindRepr = "type_parameter_declaration"
+	KindGolangStructType                core.KindRepr = "struct_type"
+	KindGolangInterfaceType             core.KindRepr = "interface_type"
+	KindGolangMethodElem                core.KindRepr = "method_elem"
+	KindGolangTypeElem                  core.KindRepr = "type_elem"
+	KindGolangQualifiedType             core.KindRepr = "qualified_type"
+	KindGolangGenericType               core.KindRepr = "generic_type"
+	KindGolangFunctionType              core.KindRepr = "function_type"
+	KindGolangSliceType                 core.KindRepr = "slice_type"
+	KindGolangArrayType                 core.KindRepr = "array_type"
+	KindGolangMapType                   core.KindRepr = "map_type"
+	KindGolangChannelType               core.KindRepr = "channel_type"
+	KindGolangPointerType               core.KindRepr = "pointer_type"
+	KindGolangVariadicParamDecl         core.KindRepr = "variadic_parameter_declaration"
+	KindGolangFieldDeclList             core.KindRepr = "field_declaration_list"
+	KindGolangFieldDecl                 core.KindRepr = "field_declaration"
+	KindGolangPackageIdentifier         core.KindRepr = "package_identifier"
+	KindGolangSourceFile                core.KindRepr = "source_file"
+	KindGolangBlock                     core.KindRepr = "block"
+	KindGolangComment                   core.KindRepr = "comment"
+	KindGolangConstSpec                 core.KindRepr = "const_spec"
+	KindGolangVarSpec                   core.KindRepr = "var_spec"
+	KindGolangShortVarDecl              core.KindRepr = "short_var_declaration"
+	KindGolangExpressionList            core.KindRepr = "expression_list"
+	KindGolangRawStringLiteral          core.KindRepr = "raw_string_literal"
+	KindGolangStringLiteral             core.KindRepr = "interpreted_string_literal"
+	KindGolangIfStatement               core.KindRepr = "if_statement"
+	KindGolangForStatement              core.KindRepr = "for_statement"
+	KindGolangExpressionCase            core.KindRepr = "expression_case"
+	KindGolangTypeCase                  core.KindRepr = "type_case"
+	KindGolangCommunicationCase         core.KindRepr = "communication_case"
+	KindGolangExpressionSwitchStatement core.KindRepr = "expression_switch_statement"
+	KindGolangTypeSwitchStatement       core.KindRepr = "type_switch_statement"
+	KindGolangSelectStatement           core.KindRepr = "select_statement"
+	KindGolangBinaryExpression          core.KindRepr = "binary_expression"
+	KindGolangReturnStatement           core.KindRepr = "return_statement"
+	KindGolangPackageClause             core.KindRepr = "package_clause"
+	KindGolangRangeClause               core.KindRepr = "range_clause"
+	KindGolangSelectorExpression        core.KindRepr = "selector_expression"
+	KindGolangArgumentList              core.KindRepr = "argument_list"
+	KindGolangAssignmentStatement       core.KindRepr = "assignment_statement"
+	FieldGolangType                     core.KindRepr = "type"
+	FieldGolangName                     core.KindRepr = "name"
+	FieldGolangParameters               core.KindRepr = "parameters"
+	FieldGolangFunction                 core.KindRepr = "function"
+	FieldGolangArguments                core.KindRepr = "arguments"
 )
 
 type Extractor struct {
@@ -32,8 +32,8 @@ func (extractor *Extractor) ExtractCalls(units []*core.Unit) ([]*object.Call, er
 }
 
 func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
-	// todo: what about nested call
-	funcUnit := core.FindFirstByOneOfKindInParent(unit, KindGolangFuncDecl, KindGolangMethodDecl)
+	// the nearest one, calls in func literals belong to the literals
+	funcUnit := core.FindFirstByOneOfKindInParent(unit, KindGolangFuncDecl, KindGolangMethodDecl, KindGolangFuncLiteral)
 	var srcFunc *object.Function
 	var err error
 	if funcUnit != nil {
@@ -60,10 +60,33 @@ func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
 	}
 
 	ret := &object.Call{
-		Src:       srcFunc.GetSignature(),
-		Caller:    funcPart.Content,
-		Arguments: arguments,
-		Span:      unit.Span,
+		Src:          srcFunc.GetSignature(),
+		Caller:       funcPart.Content,
+		Arguments:    arguments,
+		Span:         unit.Span,
+		ReceiverType: receiverType(funcPart),
 	}
 	return ret, nil
 }
+
+// receiverType type of `s` in `s.Foo()`, when `s` is a receiver or parameter of the enclosing functions
+func receiverType(funcPart *core.Unit) string {
+	if funcPart.Kind != KindGolangSelectorExpression || len(funcPart.SubUnits) != 2 || funcPart.SubUnits[0].Kind != KindGolangIdentifier {
+		return ""
+	}
+	name := funcPart.SubUnits[0].Content
+	for cur := funcPart.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		switch cur.Kind {
+		case KindGolangFuncDecl, KindGolangMethodDecl, KindGolangFuncLiteral:
+			// receiver, parameters and named results
+			for _, eachList := range core.FindAllByKindInSubs(cur, KindGolangParameterList) {
+				for _, each := range extractParameterList(eachList) {
+					if each.Name == name {
+						return NormalizeTypeName(each.Type)
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -1,30 +1,63 @@
 package golang
 
 import (
+	"errors"
+
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 )
 
+const (
+	ClassKindStruct    = "struct"
+	ClassKindInterface = "interface"
+	ClassKindFunc      = "func"
+	ClassKindSlice     = "slice"
+	ClassKindArray     = "array"
+	ClassKindMap       = "map"
+	ClassKindChan      = "chan"
+	ClassKindPointer   = "pointer"
+	ClassKindAlias     = "alias"
+	// ClassKindNamed defined from another named type, eg: `type Duration int64`
+	ClassKindNamed = "named"
+)
+
 type Field struct {
 	Type string `json:"type"`
 	Name string `json:"name"`
 }
 
+// Method an element of the method set
+type Method struct {
+	Name       string              `json:"name"`
+	Parameters []*object.ValueUnit `json:"parameters"`
+	Returns    []*object.ValueUnit `json:"returns"`
+}
+
 type ClassExtras struct {
-	Fields []*Field `json:"fields"`
+	Kind           string              `json:"kind"`
+	TypeParameters []*object.ValueUnit `json:"typeParameters"`
+	// Underlying the type definition of non-struct and non-interface types
+	Underlying string   `json:"underlying"`
+	Fields     []*Field `json:"fields"`
+	// Methods declared in interface body.
+	// For other types, they come from the functions with this receiver,
+	// which can only be linked with the whole package (see sibyl2.AnalyzeGoMethodSets).
+	Methods []*Method `json:"methods"`
+	// Promoted methods from embedded fields, linked like Methods
+	Promoted []*Method `json:"promoted"`
+	// Implements qualified names of the interfaces satisfied, eg: `github.com/x/repo/pkg/io.Reader`, linked like Methods
+	Implements []string `json:"implements"`
+	// Embedded normalized names of embedded struct fields or embedded interfaces
+	Embedded []string `json:"embedded"`
+	// interface only
+	Constraints []string `json:"constraints"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
 }
 
 func (extractor *Extractor) IsClass(unit *core.Unit) bool {
-	// golang has no class. We use struct here.
-	if unit.Kind == KindGolangTypeSpec {
-		for _, eachSub := range unit.SubUnits {
-			if eachSub.Kind == KindGolangStructType {
-				return true
-			}
-		}
-		return false
-	}
-	return false
+	// golang has no class. We use all the named types here.
+	return unit.Kind == KindGolangTypeSpec || unit.Kind == KindGolangTypeAlias
 }
 
 func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
@@ -48,11 +81,11 @@ func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error)
 	clazz.Lang = extractor.GetLang()
 	clazz.Unit = unit
 
-	// struct name
-	structName := core.FindFirstByFieldInSubsWithBfs(
-		core.FindFirstByKindInSubsWithBfs(unit, KindGolangTypeSpec),
-		FieldGolangName)
-	clazz.Name = structName.Content
+	// type name, always the first one
+	if len(unit.SubUnits) == 0 || unit.SubUnits[0].Kind != KindGolangTypeIdentifier {
+		return nil, errors.New("no type name found in: " + unit.Content)
+	}
+	clazz.Name = unit.SubUnits[0].Content
 
 	// package name
 	root := core.FindFirstByKindInParent(unit, KindGolangSourceFile)
@@ -62,24 +95,147 @@ func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error)
 	}
 
 	extras := &ClassExtras{}
-	fieldList := core.FindFirstByKindInSubsWithBfs(unit, KindGolangFieldDeclList)
-
-	if fieldList != nil {
-		fields := core.FindAllByKindInSubs(fieldList, KindGolangFieldDecl)
-		for _, eachField := range fields {
-			typeDef := core.FindFirstByFieldInSubs(eachField, FieldGolangType)
-			nameDef := core.FindFirstByFieldInSubs(eachField, FieldGolangName)
-			f := &Field{}
-			if typeDef != nil {
-				f.Type = typeDef.Content
-			}
-			if nameDef != nil {
-				f.Name = nameDef.Content
+	var typeDef *core.Unit
+	for _, each := range unit.SubUnits[1:] {
+		if each.Kind == KindGolangTypeParameterList {
+			extras.TypeParameters = extractParameterList(each)
+			continue
+		}
+		typeDef = each
+	}
+	if typeDef == nil {
+		return nil, errors.New("no type definition found in: " + unit.Content)
+	}
+
+	if unit.Kind == KindGolangTypeAlias {
+		extras.Kind = ClassKindAlias
+	} else {
+		extras.Kind = typeKind(typeDef)
+	}
+	switch extras.Kind {
+	case ClassKindStruct:
+		extras.Fields = extractFields(typeDef)
+		for _, each := range extras.Fields {
+			// embedded field has no name
+			if each.Name == "" {
+				extras.Embedded = append(extras.Embedded, NormalizeTypeName(each.Type))
 			}
-			extras.Fields = append(extras.Fields, f)
 		}
+	case ClassKindInterface:
+		extractInterface(typeDef, extras)
+	default:
+		extras.Underlying = typeDef.Content
 	}
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
 	clazz.Extras = extras
 
 	return clazz, nil
 }
+
+func typeKind(typeDef *core.Unit) string {
+	switch typeDef.Kind {
+	case KindGolangStructType:
+		return ClassKindStruct
+	case KindGolangInterfaceType:
+		return ClassKindInterface
+	case KindGolangFunctionType:
+		return ClassKindFunc
+	case KindGolangSliceType:
+		return ClassKindSlice
+	case KindGolangArrayType:
+		return ClassKindArray
+	case KindGolangMapType:
+		return ClassKindMap
+	case KindGolangChannelType:
+		return ClassKindChan
+	case KindGolangPointerType:
+		return ClassKindPointer
+	default:
+		return ClassKindNamed
+	}
+}
+
+func extractFields(structType *core.Unit) []*Field {
+	var ret []*Field
+	fieldList := core.FindFirstByKindInSubsWithBfs(structType, KindGolangFieldDeclList)
+	if fieldList == nil {
+		return ret
+	}
+	for _, eachField := range core.FindAllByKindInSubs(fieldList, KindGolangFieldDecl) {
+		// field_declaration: names..., type, [tag]
+		var names []string
+		var typeDef *core.Unit
+		for _, each := range eachField.SubUnits {
+			switch each.Kind {
+			case KindGolangFieldIdentifier:
+				names = append(names, each.Content)
+			case KindGolangRawStringLiteral, KindGolangStringLiteral:
+				// tag
+			default:
+				typeDef = each
+			}
+		}
+		if len(names) == 0 {
+			// embedded, the whole decl without tag is the type, eg: `*Headless`
+			typeName := eachField.Content
+			if typeDef != nil {
+				typeName = eachField.Content[:typeDef.Span.End.Column-eachField.Span.Start.Column]
+			}
+			ret = append(ret, &Field{Type: typeName})
+			continue
+		}
+		f := &Field{}
+		if typeDef != nil {
+			f.Type = typeDef.Content
+		}
+		for _, eachName := range names {
+			ret = append(ret, &Field{Type: f.Type, Name: eachName})
+		}
+	}
+	return ret
+}
+
+func extractInterface(interfaceType *core.Unit, extras *ClassExtras) {
+	for _, each := range interfaceType.SubUnits {
+		switch each.Kind {
+		case KindGolangMethodElem:
+			extras.Methods = append(extras.Methods, extractMethod(each))
+		case KindGolangTypeElem:
+			// a single type is embedded, `~int | string` is a constraint
+			if len(each.SubUnits) == 1 && isTypeName(each.SubUnits[0]) {
+				extras.Embedded = append(extras.Embedded, NormalizeTypeName(each.Content))
+			} else {
+				extras.Constraints = append(extras.Constraints, each.Content)
+			}
+		}
+	}
+}
+
+// isTypeName `Named`, `io.Closer` or `List[T]`
+func isTypeName(unit *core.Unit) bool {
+	switch unit.Kind {
+	case KindGolangTypeIdentifier, KindGolangQualifiedType, KindGolangGenericType:
+		return true
+	}
+	return false
+}
+
+// extractMethod
+// method_elem: name, parameter_list, [parameter_list | type]
+func extractMethod(unit *core.Unit) *Method {
+	ret := &Method{}
+	for i, each := range unit.SubUnits {
+		switch {
+		case i == 0:
+			ret.Name = each.Content
+		case i == 1:
+			ret.Parameters = extractParameterList(each)
+		case each.Kind == KindGolangParameterList:
+			ret.Returns = extractParameterList(each)
+		default:
+			// only one anonymous return value
+			ret.Returns = append(ret.Returns, &object.ValueUnit{Type: each.Content})
+		}
+	}
+	return ret
+}
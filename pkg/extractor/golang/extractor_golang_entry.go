@@ -0,0 +1,193 @@
+package golang
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+// routerMethods methods of gin and echo routers, `Any` means all the http methods
+var routerMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "Any"}
+
+/*
+DetectEntryPoints route registrations of gin, echo and net/http, and cron jobs of robfig/cron
+
+	api := r.Group("/api")
+	api.GET("/users", auth, listUsers)     -> GET /api/users, listUsers
+	http.HandleFunc("POST /ping", ping)    -> POST /ping, ping
+	c.AddFunc("0 * * * *", clean)          -> 0 * * * *, clean
+
+Frameworks are told by imports of this file.
+*/
+func (extractor *Extractor) DetectEntryPoints(units []*core.Unit) ([]*object.EntryPoint, error) {
+	imports, err := extractor.ExtractImports(units)
+	if err != nil {
+		return nil, err
+	}
+	detector := &entryPointDetector{
+		prefixes: make(map[string]string),
+	}
+	for _, each := range imports {
+		switch {
+		case strings.HasPrefix(each.Source, "github.com/gin-gonic/gin"):
+			detector.router = object.EntryFrameworkGin
+		case strings.HasPrefix(each.Source, "github.com/labstack/echo") && detector.router == "":
+			detector.router = object.EntryFrameworkEcho
+		case each.Source == "net/http":
+			detector.netHttp = true
+		case strings.HasPrefix(each.Source, "github.com/robfig/cron"):
+			detector.cron = true
+		}
+	}
+
+	var ret []*object.EntryPoint
+	for _, eachUnit := range units {
+		switch eachUnit.Kind {
+		case KindGolangShortVarDecl, KindGolangAssignmentStatement:
+			detector.trackGroups(eachUnit)
+		case KindGolangCallExpression:
+			if entry := detector.detect(eachUnit); entry != nil {
+				ret = append(ret, entry)
+			}
+		}
+	}
+	return ret, nil
+}
+
+type entryPointDetector struct {
+	// gin or echo
+	router  object.EntryFramework
+	netHttp bool
+	cron    bool
+	// router groups, variable -> prefix
+	prefixes map[string]string
+}
+
+// trackGroups `api := r.Group("/api")`
+func (d *entryPointDetector) trackGroups(unit *core.Unit) {
+	if d.router == "" || len(unit.SubUnits) != 2 {
+		return
+	}
+	left, right := unit.SubUnits[0].SubUnits, unit.SubUnits[1].SubUnits
+	for i, each := range right {
+		if i >= len(left) || left[i].Kind != KindGolangIdentifier {
+			break
+		}
+		receiver, method, args := splitMethodCall(each)
+		if method != "Group" || len(args) == 0 {
+			continue
+		}
+		if prefix, ok := stringArg(args[0]); ok {
+			d.prefixes[left[i].Content] = object.JoinRoute(d.prefixes[receiver], prefix)
+		}
+	}
+}
+
+func (d *entryPointDetector) detect(call *core.Unit) *object.EntryPoint {
+	receiver, method, args := splitMethodCall(call)
+	if method == "" || len(args) < 2 {
+		return nil
+	}
+	entry := &object.EntryPoint{
+		Kind: object.EntryKindHttp,
+		Span: call.Span,
+	}
+	var handler *core.Unit
+
+	switch {
+	case d.router != "" && slices.Contains(routerMethods, method):
+		// r.GET("/users", handlers...)
+		route, ok := stringArg(args[0])
+		if !ok || !strings.HasPrefix(route, "/") {
+			return nil
+		}
+		entry.Framework = d.router
+		entry.Route = object.JoinRoute(d.prefixes[receiver], route)
+		if method != "Any" {
+			entry.Method = method
+		}
+		handler = d.routerHandler(args[1:])
+	case d.router != "" && (method == "Handle" || method == "Add") && len(args) >= 3:
+		// r.Handle("GET", "/users", handlers...)
+		httpMethod, ok := stringArg(args[0])
+		route, ok2 := stringArg(args[1])
+		if !ok || !ok2 || !strings.HasPrefix(route, "/") {
+			return nil
+		}
+		entry.Framework = d.router
+		entry.Method = strings.ToUpper(httpMethod)
+		entry.Route = object.JoinRoute(d.prefixes[receiver], route)
+		handler = d.routerHandler(args[2:])
+	case d.netHttp && (method == "HandleFunc" || method == "Handle") && len(args) == 2:
+		// http.HandleFunc("GET /users", handler), method is optional since go 1.22
+		pattern, ok := stringArg(args[0])
+		if !ok {
+			return nil
+		}
+		entry.Framework = object.EntryFrameworkNetHttp
+		entry.Route = pattern
+		if index := strings.Index(pattern, " "); index != -1 {
+			entry.Method = pattern[:index]
+			entry.Route = strings.TrimSpace(pattern[index+1:])
+		}
+		handler = args[1]
+	case d.cron && method == "AddFunc" && len(args) == 2:
+		// c.AddFunc("0 * * * *", job)
+		spec, ok := stringArg(args[0])
+		if !ok {
+			return nil
+		}
+		entry.Kind = object.EntryKindSchedule
+		entry.Framework = object.EntryFrameworkCron
+		entry.Route = spec
+		handler = args[1]
+	default:
+		return nil
+	}
+
+	if handler.Kind == KindGolangFuncLiteral {
+		entry.Unit = handler
+	} else {
+		entry.Handler = handler.Content
+	}
+	return entry
+}
+
+// routerHandler the last one in gin, middlewares come after handler in echo
+func (d *entryPointDetector) routerHandler(handlers []*core.Unit) *core.Unit {
+	if d.router == object.EntryFrameworkEcho {
+		return handlers[0]
+	}
+	return handlers[len(handlers)-1]
+}
+
+// splitMethodCall `r.GET("/", h)` -> r, GET, ["/", h]
+func splitMethodCall(unit *core.Unit) (string, string, []*core.Unit) {
+	if unit.Kind != KindGolangCallExpression || len(unit.SubUnits) < 2 {
+		return "", "", nil
+	}
+	selector := unit.SubUnits[0]
+	if selector.Kind != KindGolangSelectorExpression || len(selector.SubUnits) != 2 {
+		return "", "", nil
+	}
+	args := core.FindFirstByKindInSubs(unit, KindGolangArgumentList)
+	if args == nil {
+		return "", "", nil
+	}
+	var argUnits []*core.Unit
+	for _, each := range args.SubUnits {
+		if each.Kind != KindGolangComment {
+			argUnits = append(argUnits, each)
+		}
+	}
+	return selector.SubUnits[0].Content, selector.SubUnits[1].Content, argUnits
+}
+
+func stringArg(unit *core.Unit) (string, bool) {
+	if unit.Kind != KindGolangStringLiteral && unit.Kind != KindGolangRawStringLiteral {
+		return "", false
+	}
+	return unquote(unit.Content), true
+}
@@ -2,6 +2,8 @@ package golang
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
@@ -9,12 +11,26 @@ import (
 )
 
 type FuncExtras struct {
+	// RawReceiver receiver type in source, eg: `*List[T]`
+	RawReceiver     string `json:"rawReceiver"`
+	PointerReceiver bool   `json:"pointerReceiver"`
+	// TypeParameters eg: `[K comparable, V any]`
+	TypeParameters []*object.ValueUnit `json:"typeParameters"`
+	// Literal anonymous function, named like `main.func1` as go compiler does
+	Literal bool `json:"literal"`
+	// Parent signature of the enclosing function, empty for top level
+	Parent string `json:"parent"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+	// TestFramework empty if this function is not a test
+	TestFramework object.TestFramework `json:"testFramework"`
 }
 
 func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
 	allowed := []core.KindRepr{
 		KindGolangMethodDecl,
 		KindGolangFuncDecl,
+		KindGolangFuncLiteral,
 	}
 	return slices.Contains(allowed, unit.Kind)
 }
@@ -36,192 +52,229 @@ func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Func
 }
 
 func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function, error) {
+	var funcUnit *object.Function
+	var err error
 	switch unit.Kind {
 	case KindGolangFuncDecl:
-		return extractor.funcUnit2Function(unit)
+		funcUnit, err = extractor.funcUnit2Function(unit)
 	case KindGolangMethodDecl:
-		return extractor.methodUnit2Function(unit)
+		funcUnit, err = extractor.methodUnit2Function(unit)
+	case KindGolangFuncLiteral:
+		funcUnit, err = extractor.literalUnit2Function(unit)
 	default:
 		// should not reach here
 		return nil, errors.New("IMPOSSIBLE")
 	}
+	if err != nil {
+		return nil, err
+	}
+	if unit.Kind == KindGolangFuncDecl {
+		funcUnit.Extras.(*FuncExtras).TestFramework = testFramework(funcUnit)
+	}
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
+	return funcUnit, nil
 }
 
 func (extractor *Extractor) methodUnit2Function(unit *core.Unit) (*object.Function, error) {
-	funcUnit := &object.Function{}
-	funcUnit.Span = unit.Span
-	funcUnit.Unit = unit
-
-	// body scope
-	funcBody := core.FindFirstByKindInSubsWithBfs(unit, KindGolangBlock)
-	if funcBody != nil {
-		funcUnit.BodySpan = funcBody.Span
+	// receiver, always the first one
+	if len(unit.SubUnits) == 0 || unit.SubUnits[0].Kind != KindGolangParameterList {
+		return nil, errors.New("no receiver found in: " + unit.Content)
 	}
-
-	// name
-	funcIdentifier := core.FindFirstByKindInSubsWithDfs(unit, KindGolangFieldIdentifier)
-	if funcIdentifier == nil {
-		return nil, errors.New("no func name found in " + unit.Content)
+	receivers := extractParameterList(unit.SubUnits[0])
+	if len(receivers) == 0 {
+		return nil, errors.New("no receiver found in: " + unit.Content)
 	}
-	funcUnit.Name = funcIdentifier.Content
-	funcUnit.DefLine = int(funcIdentifier.Span.Start.Row + 1)
 
-	// receiver
-	parameterList := core.FindFirstByKindInSubsWithDfs(unit, KindGolangParameterList)
-	parameterList = core.FindFirstByKindInSubsWithDfs(parameterList, KindGolangParameterList)
-	receiverDecl := core.FindFirstByKindInSubsWithDfs(parameterList, KindGolangParameterDecl)
-	typeDecl := core.FindFirstByFieldInSubsWithDfs(receiverDecl, FieldGolangType)
-	if typeDecl == nil {
-		return nil, errors.New("no receiver found in: " + typeDecl.Content)
+	funcUnit, err := extractor.signature2Function(unit, unit.SubUnits[1:])
+	if err != nil {
+		return nil, err
 	}
-	funcUnit.Receiver = typeDecl.Content
+	rawReceiver := receivers[0].Type
+	funcUnit.Receiver = NormalizeTypeName(rawReceiver)
+	extras := funcUnit.Extras.(*FuncExtras)
+	extras.RawReceiver = rawReceiver
+	extras.PointerReceiver = strings.HasPrefix(rawReceiver, "*")
+	return funcUnit, nil
+}
 
-	// namespace: package
-	root := core.FindFirstByKindInParent(unit, KindGolangSourceFile)
-	pkgName := core.FindFirstByKindInSubsWithDfs(root, KindGolangPackageIdentifier)
-	if pkgName != nil {
-		funcUnit.Namespace = pkgName.Content
-	}
+func (extractor *Extractor) funcUnit2Function(unit *core.Unit) (*object.Function, error) {
+	return extractor.signature2Function(unit, unit.SubUnits)
+}
 
-	// params
-	paramListList := core.FindAllByKindInSubsWithDfs(unit, KindGolangParameterList)
-	// no param == empty slice, never nil
-	paramList := paramListList[1]
-	for _, each := range core.FindAllByKindInSubsWithDfs(paramList, KindGolangParameterDecl) {
-		typeName := core.FindFirstByFieldInSubsWithDfs(each, FieldGolangType)
-		paramName := core.FindFirstByFieldInSubsWithDfs(each, FieldGolangName)
-		var paramNameContent string
-		if paramName == nil {
-			paramNameContent = ""
-		} else {
-			paramNameContent = paramName.Content
-		}
+// literalUnit2Function
+// func literal has no name, we name it with its enclosing function and position, as go compiler does:
+//
+//	func main() {
+//		go func() {     // main.func1
+//			go func() { // main.func1.1
+//			}()
+//		}()
+//	}
+//
+// and the literals at top level: `glob..func1`
+func (extractor *Extractor) literalUnit2Function(unit *core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
+	funcUnit.Span = unit.Span
+	funcUnit.Lang = extractor.GetLang()
+	funcUnit.Unit = unit
+	funcUnit.DefLine = int(unit.Span.Start.Row + 1)
+	extras := extractor.fillSignature(funcUnit, unit.SubUnits)
+	extras.Literal = true
+	funcUnit.Extras = extras
 
-		valueUnit := &object.ValueUnit{
-			Type: typeName.Content,
-			Name: paramNameContent,
+	parentUnit := core.FindFirstByOneOfKindInParent(unit.ParentUnit, KindGolangFuncDecl, KindGolangMethodDecl, KindGolangFuncLiteral)
+	if parentUnit == nil {
+		root := core.FindFirstByKindInParent(unit, KindGolangSourceFile)
+		if root == nil {
+			return nil, errors.New("no source file found for: " + unit.Content)
 		}
-		funcUnit.Parameters = append(funcUnit.Parameters, valueUnit)
-	}
-
-	// returns
-	// never nil
-	retParams := core.FindFirstByFieldInSubsWithDfs(unit, FieldGolangParameters)
-	switch retParams.Kind {
-	case KindGolangParameterList:
-		// multi params
-		for _, each := range core.FindAllByKindInSubsWithDfs(retParams, KindGolangParameterDecl) {
-			typeName := core.FindFirstByFieldInSubsWithDfs(each, FieldGolangType)
-			paramName := core.FindFirstByFieldInSubsWithDfs(each, FieldGolangName)
-			var paramNameContent string
-			if paramName == nil {
-				paramNameContent = ""
-			} else {
-				paramNameContent = paramName.Content
+		funcUnit.Name = fmt.Sprintf("glob..func%d", literalIndex(root, unit))
+		return funcUnit, nil
+	}
+
+	parent, err := extractor.ExtractFunction(parentUnit)
+	if err != nil {
+		return nil, err
+	}
+	if parentUnit.Kind == KindGolangFuncLiteral {
+		funcUnit.Name = fmt.Sprintf("%s.%d", parent.Name, literalIndex(parentUnit, unit))
+	} else {
+		funcUnit.Name = fmt.Sprintf("%s.func%d", parent.Name, literalIndex(parentUnit, unit))
+	}
+	funcUnit.Receiver = parent.Receiver
+	extras.Parent = parent.GetSignature()
+	return funcUnit, nil
+}
+
+// literalIndex position (starts from 1) of a literal in the direct scope
+func literalIndex(scope *core.Unit, literal *core.Unit) int {
+	index := 0
+	var walk func(*core.Unit) bool
+	walk = func(cur *core.Unit) bool {
+		for _, each := range cur.SubUnits {
+			switch each.Kind {
+			case KindGolangFuncLiteral:
+				index++
+				if each == literal {
+					return true
+				}
+				continue
+			case KindGolangFuncDecl, KindGolangMethodDecl:
+				continue
 			}
-			valueUnit := &object.ValueUnit{
-				Type: typeName.Content,
-				Name: paramNameContent,
+			if walk(each) {
+				return true
 			}
-			funcUnit.Returns = append(funcUnit.Returns, valueUnit)
 		}
-	case KindGolangTypeIdentifier:
-		// only one param, and anonymous
-		valueUnit := &object.ValueUnit{
-			Type: retParams.Content,
-			Name: "",
-		}
-		funcUnit.Returns = append(funcUnit.Returns, valueUnit)
-	default:
-		// no returns
+		return false
 	}
-	// extras
-	funcUnit.Extras = &FuncExtras{}
-
-	return funcUnit, nil
+	walk(scope)
+	return index
 }
 
-func (extractor *Extractor) funcUnit2Function(unit *core.Unit) (*object.Function, error) {
-	funcUnit := &object.Function{}
+// signature2Function
+// signature: name, [type_parameter_list], parameter_list, [parameter_list | type], [block]
+func (extractor *Extractor) signature2Function(unit *core.Unit, signature []*core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
 	funcUnit.Span = unit.Span
 	funcUnit.Lang = extractor.GetLang()
 	funcUnit.Unit = unit
 
-	// body scope
-	funcBody := core.FindFirstByKindInSubsWithBfs(unit, KindGolangBlock)
-	if funcBody != nil {
-		funcUnit.BodySpan = funcBody.Span
-	}
-
 	// name
-	funcIdentifier := core.FindFirstByKindInSubsWithDfs(unit, KindGolangIdentifier)
-	if funcIdentifier == nil {
+	if len(signature) == 0 || (signature[0].Kind != KindGolangIdentifier && signature[0].Kind != KindGolangFieldIdentifier) {
 		return nil, errors.New("no func name found in " + unit.Content)
 	}
+	funcIdentifier := signature[0]
 	funcUnit.Name = funcIdentifier.Content
 	funcUnit.DefLine = int(funcIdentifier.Span.Start.Row + 1)
 
+	funcUnit.Extras = extractor.fillSignature(funcUnit, signature[1:])
+	return funcUnit, nil
+}
+
+// fillSignature
+// signature (without name): [type_parameter_list], parameter_list, [parameter_list | type], [block]
+func (extractor *Extractor) fillSignature(funcUnit *object.Function, signature []*core.Unit) *FuncExtras {
 	// namespace: package
-	root := core.FindFirstByKindInParent(unit, KindGolangSourceFile)
+	root := core.FindFirstByKindInParent(funcUnit.Unit, KindGolangSourceFile)
 	pkgName := core.FindFirstByKindInSubsWithDfs(root, KindGolangPackageIdentifier)
 	if pkgName != nil {
 		funcUnit.Namespace = pkgName.Content
 	}
 
-	// params
-	// no param == empty slice, never nil
-	paramList := core.FindFirstByKindInSubsWithDfs(unit, KindGolangParameterList)
-	for _, each := range core.FindAllByKindInSubsWithDfs(paramList, KindGolangParameterDecl) {
-		typeName := core.FindFirstByFieldInSubsWithDfs(each, FieldGolangType)
-		paramName := core.FindFirstByFieldInSubsWithDfs(each, FieldGolangName)
-		var paramNameContent string
-		if paramName == nil {
-			paramNameContent = ""
-		} else {
-			paramNameContent = paramName.Content
+	extras := &FuncExtras{
+		Doc: object.NewCommentRule(extractor).FindDoc(funcUnit.Unit),
+	}
+	paramsFound := false
+	for _, each := range signature {
+		switch {
+		case each.Kind == KindGolangTypeParameterList:
+			extras.TypeParameters = extractParameterList(each)
+		case each.Kind == KindGolangBlock:
+			funcUnit.BodySpan = each.Span
+		case each.Kind == KindGolangComment:
+			// ignore
+		case !paramsFound && each.Kind == KindGolangParameterList:
+			funcUnit.Parameters = extractParameterList(each)
+			paramsFound = true
+		case paramsFound && each.Kind == KindGolangParameterList:
+			// multi returns, or named
+			funcUnit.Returns = extractParameterList(each)
+		case paramsFound:
+			// only one return value, and anonymous
+			funcUnit.Returns = append(funcUnit.Returns, &object.ValueUnit{
+				Type: each.Content,
+				Name: "",
+			})
 		}
-		valueUnit := &object.ValueUnit{
-			Type: typeName.Content,
-			Name: paramNameContent,
+	}
+	return extras
+}
+
+// extractParameterList flatten a parameter list, such as `(a, b int, opts ...string)`.
+// grouped names will be split into separated value units which share the same type.
+func extractParameterList(paramList *core.Unit) []*object.ValueUnit {
+	var ret []*object.ValueUnit
+	if paramList == nil {
+		return ret
+	}
+	for _, each := range paramList.SubUnits {
+		if each.Kind != KindGolangParameterDecl && each.Kind != KindGolangVariadicParamDecl && each.Kind != KindGolangTypeParameterDecl {
+			continue
 		}
-		funcUnit.Parameters = append(funcUnit.Parameters, valueUnit)
-	}
-
-	// returns
-	retParams := core.FindFirstByFieldInSubsWithDfs(unit, FieldGolangParameters)
-	if retParams != nil {
-		switch retParams.Kind {
-		case KindGolangParameterList:
-			// multi params
-			for _, each := range core.FindAllByKindInSubsWithDfs(retParams, KindGolangParameterDecl) {
-				typeName := core.FindFirstByFieldInSubsWithDfs(each, FieldGolangType)
-				paramName := core.FindFirstByFieldInSubsWithDfs(each, FieldGolangName)
-				var paramNameContent string
-				if paramName == nil {
-					paramNameContent = ""
-				} else {
-					paramNameContent = paramName.Content
-				}
-				valueUnit := &object.ValueUnit{
-					Type: typeName.Content,
-					Name: paramNameContent,
-				}
-				funcUnit.Returns = append(funcUnit.Returns, valueUnit)
-			}
-		case KindGolangTypeIdentifier:
-			// only one param, and anonymous
-			valueUnit := &object.ValueUnit{
-				Type: retParams.Content,
-				Name: "",
+		var names []string
+		var typeName string
+		for _, eachSub := range each.SubUnits {
+			if eachSub.Kind == KindGolangIdentifier {
+				names = append(names, eachSub.Content)
+				continue
 			}
-			funcUnit.Returns = append(funcUnit.Returns, valueUnit)
-		default:
-			// no returns
+			typeName = eachSub.Content
+		}
+		if each.Kind == KindGolangVariadicParamDecl {
+			typeName = "..." + typeName
+		}
+		if len(names) == 0 {
+			// anonymous
+			names = append(names, "")
+		}
+		for _, eachName := range names {
+			ret = append(ret, &object.ValueUnit{
+				Type: typeName,
+				Name: eachName,
+			})
 		}
 	}
+	return ret
+}
 
-	// extras
-	funcUnit.Extras = &FuncExtras{}
-
-	return funcUnit, nil
+// NormalizeTypeName strip pointers and type arguments from a type reference,
+// eg: `*List[T]` -> `List`, `*pkg.Parser` -> `pkg.Parser`
+func NormalizeTypeName(typeName string) string {
+	typeName = strings.TrimSpace(typeName)
+	typeName = strings.TrimLeft(typeName, "*")
+	if i := strings.Index(typeName, "["); i != -1 {
+		typeName = typeName[:i]
+	}
+	return strings.TrimSpace(typeName)
 }
@@ -0,0 +1,46 @@
+package golang
+
+import (
+	"strconv"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindGolangImportSpec
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) || len(eachUnit.SubUnits) == 0 {
+			continue
+		}
+		imp := object.NewImport()
+		imp.Span = eachUnit.Span
+		imp.Lang = extractor.GetLang()
+
+		// f "fmt"
+		path := eachUnit.SubUnits[len(eachUnit.SubUnits)-1]
+		imp.Source = unquote(path.Content)
+		if len(eachUnit.SubUnits) > 1 {
+			name := eachUnit.SubUnits[0].Content
+			if name == "." {
+				// dot import
+				imp.Wildcard = true
+			} else {
+				imp.Alias = name
+			}
+		}
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
+
+func unquote(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package golang
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindGolangIfStatement,
+			KindGolangForStatement,
+			KindGolangExpressionCase,
+			KindGolangTypeCase,
+			KindGolangCommunicationCase,
+		},
+		FlowKinds: []core.KindRepr{
+			KindGolangIfStatement,
+			KindGolangForStatement,
+			KindGolangExpressionSwitchStatement,
+			KindGolangTypeSwitchStatement,
+			KindGolangSelectStatement,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindGolangFuncLiteral,
+		},
+		BinaryKinds: []core.KindRepr{
+			KindGolangBinaryExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindGolangReturnStatement,
+		},
+		CommentKinds: []core.KindRepr{
+			KindGolangComment,
+		},
+	}
+}
@@ -1,34 +1,81 @@
 package golang
 
 import (
-	"strings"
-
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 )
 
 func (extractor *Extractor) IsSymbol(unit *core.Unit) bool {
-	// todo: use grammar.js instead
-	if strings.HasSuffix(unit.Kind, "identifier") {
+	// blank identifiers `_` are not symbols
+	switch unit.Kind {
+	case KindGolangIdentifier, KindGolangFieldIdentifier, KindGolangPackageIdentifier, KindGolangTypeIdentifier:
 		return true
 	}
 	return false
 }
 
-func (extractor *Extractor) ExtractSymbols(unit []*core.Unit) ([]*object.Symbol, error) {
-	ret := make([]*object.Symbol, 0)
-	for _, eachUnit := range unit {
-		if !extractor.IsSymbol(eachUnit) {
-			continue
+func (extractor *Extractor) ExtractSymbols(units []*core.Unit) ([]*object.Symbol, error) {
+	return object.ExtractSymbols(extractor, units, classifySymbol, extractor.scopeResolver())
+}
+
+// classifySymbol by its parent
+func classifySymbol(unit *core.Unit) (object.NodeType, object.SyntaxType) {
+	parent := unit.ParentUnit
+	if parent == nil {
+		return object.NodeTypeReference, object.SyntaxTypeVariable
+	}
+	first := parent.SubUnits[0] == unit
+
+	switch {
+	case parent.Kind == KindGolangFuncDecl && unit.Kind == KindGolangIdentifier:
+		return object.NodeTypeDefinition, object.SyntaxTypeFunction
+	case (parent.Kind == KindGolangMethodDecl || parent.Kind == KindGolangMethodElem) && unit.Kind == KindGolangFieldIdentifier:
+		return object.NodeTypeDefinition, object.SyntaxTypeMethod
+	case (parent.Kind == KindGolangTypeSpec || parent.Kind == KindGolangTypeAlias) && first:
+		return object.NodeTypeDefinition, object.SyntaxTypeType
+	case unit.Kind == KindGolangPackageIdentifier:
+		// `package a` and `import f "fmt"`
+		if parent.Kind == KindGolangPackageClause || parent.Kind == KindGolangImportSpec {
+			return object.NodeTypeDefinition, object.SyntaxTypeModule
+		}
+		return object.NodeTypeReference, object.SyntaxTypeModule
+	case unit.Kind == KindGolangTypeIdentifier:
+		return object.NodeTypeReference, object.SyntaxTypeType
+	}
+
+	switch parent.Kind {
+	case KindGolangParameterDecl, KindGolangTypeParameterDecl, KindGolangVariadicParamDecl, KindGolangVarSpec, KindGolangConstSpec, KindGolangFieldDecl:
+		return object.NodeTypeDefinition, object.SyntaxTypeVariable
+	case KindGolangExpressionList:
+		// `a, b := ...` and `for k, v := range ...`
+		grand := parent.ParentUnit
+		if grand != nil && grand.SubUnits[0] == parent &&
+			(grand.Kind == KindGolangShortVarDecl || grand.Kind == KindGolangRangeClause) {
+			return object.NodeTypeDefinition, object.SyntaxTypeVariable
+		}
+	case KindGolangCallExpression:
+		// `a()`
+		if first {
+			return object.NodeTypeReference, object.SyntaxTypeCall
 		}
-		symbol := &object.Symbol{
-			Symbol:    eachUnit.Content,
-			Kind:      eachUnit.Kind,
-			Span:      eachUnit.Span,
-			FieldName: eachUnit.FieldName,
-			Unit:      eachUnit,
+	case KindGolangSelectorExpression:
+		// `b.a()`
+		grand := parent.ParentUnit
+		if !first && grand != nil && grand.Kind == KindGolangCallExpression && grand.SubUnits[0] == parent {
+			return object.NodeTypeReference, object.SyntaxTypeCall
 		}
-		ret = append(ret, symbol)
 	}
-	return ret, nil
+	return object.NodeTypeReference, object.SyntaxTypeVariable
+}
+
+func (extractor *Extractor) scopeResolver() *object.ScopeResolver {
+	return &object.ScopeResolver{
+		IsScope: func(unit *core.Unit) bool {
+			return extractor.IsFunction(unit) || unit.Kind == KindGolangBlock
+		},
+		IsMember: func(symbol *object.Symbol) bool {
+			// `a.b`, members can not be resolved without types
+			return symbol.Kind == KindGolangFieldIdentifier
+		},
+	}
 }
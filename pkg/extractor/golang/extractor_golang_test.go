@@ -60,11 +60,56 @@ func TestGolangExtractor_ExtractFunctions(t *testing.T) {
 	}
 	assert.Equal(t, target.Lang, core.LangGo)
 
+	method := funcs[1]
+	assert.Equal(t, "Parser", method.Receiver)
+	assert.Equal(t, core.LangGo, method.Lang)
+	assert.Equal(t, "*Parser", method.Extras.(*FuncExtras).RawReceiver)
+	assert.True(t, method.Extras.(*FuncExtras).PointerReceiver)
+
 	privateMethod := funcs[len(funcs)-1]
 	assert.NotEqual(t, privateMethod.BodySpan.Start.Row, 0)
 	assert.Equal(t, privateMethod.Namespace, "abc")
 }
 
+var goCodeWithResults = `
+package abc
+
+func multi(a *core.Unit, opts map[string]int) (ret *core.Unit, err error) {
+	return nil, nil
+}
+
+func (p *Parser) single() bool {
+	return true
+}
+`
+
+func TestGolangExtractor_ExtractParametersAndResults(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goCodeWithResults))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 2)
+
+	multi := funcs[0]
+	assert.Equal(t, []*object.ValueUnit{
+		{Name: "a", Type: "*core.Unit"},
+		{Name: "opts", Type: "map[string]int"},
+	}, multi.Parameters)
+	assert.Equal(t, []*object.ValueUnit{
+		{Name: "ret", Type: "*core.Unit"},
+		{Name: "err", Type: "error"},
+	}, multi.Returns)
+
+	single := funcs[1]
+	assert.Equal(t, "Parser", single.Receiver)
+	assert.Empty(t, single.Parameters)
+	assert.Equal(t, []*object.ValueUnit{{Type: "bool"}}, single.Returns)
+}
+
 func TestGolangExtractor_Serialize(t *testing.T) {
 	t.Parallel()
 	parser := core.NewParser(core.LangGo)
@@ -110,6 +155,8 @@ func TestExtractor_ExtractClasses(t *testing.T) {
 	extractor := &Extractor{}
 	data, err := extractor.ExtractClasses(units)
 	assert.Nil(t, err)
+	assert.Equal(t, []string{"Headless"}, data[0].Extras.(*ClassExtras).Embedded)
+	assert.Equal(t, "*Headless", data[0].Extras.(*ClassExtras).Fields[0].Type)
 	for _, eachType := range data {
 		core.Log.Infof("clazz: %v", eachType.GetSignature())
 
@@ -117,3 +164,594 @@ func TestExtractor_ExtractClasses(t *testing.T) {
 		core.Log.Infof("fields: %v, %v", fields[0].Type, fields[0].Name)
 	}
 }
+
+var goTypesCode = `
+package abc
+
+type Reader interface {
+	io.Closer
+	Read(p []byte) (n int, err error)
+	Write(a, b int, opts ...string) error
+}
+
+type Number interface {
+	~int | ~float64
+}
+
+type Handler func(ctx *Context) error
+
+type IDs []string
+
+type Duration int64
+
+type List[K comparable, V any] struct {
+	items map[K]V
+}
+
+type (
+	Alias = map[string]int
+)
+`
+
+func TestExtractor_ExtractNamedTypes(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goTypesCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	data, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, data, 7)
+
+	kinds := make(map[string]*ClassExtras)
+	for _, each := range data {
+		assert.Equal(t, "abc", each.Module)
+		kinds[each.Name] = each.Extras.(*ClassExtras)
+	}
+
+	reader := kinds["Reader"]
+	assert.Equal(t, ClassKindInterface, reader.Kind)
+	assert.Equal(t, []string{"io.Closer"}, reader.Embedded)
+	assert.Len(t, reader.Methods, 2)
+	assert.Equal(t, "Read", reader.Methods[0].Name)
+	assert.Len(t, reader.Methods[0].Returns, 2)
+	assert.Equal(t, "err", reader.Methods[0].Returns[1].Name)
+	write := reader.Methods[1]
+	assert.Len(t, write.Parameters, 3)
+	assert.Equal(t, "b", write.Parameters[1].Name)
+	assert.Equal(t, "int", write.Parameters[1].Type)
+	assert.Equal(t, "...string", write.Parameters[2].Type)
+	assert.Equal(t, "error", write.Returns[0].Type)
+
+	assert.Equal(t, ClassKindInterface, kinds["Number"].Kind)
+	assert.Equal(t, []string{"~int | ~float64"}, kinds["Number"].Constraints)
+
+	assert.Equal(t, ClassKindFunc, kinds["Handler"].Kind)
+	assert.Equal(t, "func(ctx *Context) error", kinds["Handler"].Underlying)
+	assert.Equal(t, ClassKindSlice, kinds["IDs"].Kind)
+	assert.Equal(t, ClassKindNamed, kinds["Duration"].Kind)
+	assert.Equal(t, "int64", kinds["Duration"].Underlying)
+	assert.Equal(t, ClassKindAlias, kinds["Alias"].Kind)
+	assert.Equal(t, "map[string]int", kinds["Alias"].Underlying)
+
+	list := kinds["List"]
+	assert.Equal(t, ClassKindStruct, list.Kind)
+	assert.Len(t, list.TypeParameters, 2)
+	assert.Equal(t, "K", list.TypeParameters[0].Name)
+	assert.Equal(t, "any", list.TypeParameters[1].Type)
+	assert.Len(t, list.Fields, 1)
+	assert.Equal(t, "items", list.Fields[0].Name)
+}
+
+func TestNormalizeTypeName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "Parser", NormalizeTypeName("*Parser"))
+	assert.Equal(t, "List", NormalizeTypeName("*List[K, V]"))
+	assert.Equal(t, "pkg.Parser", NormalizeTypeName("pkg.Parser"))
+}
+
+var goTrickyFuncCode = `
+package abc
+
+func Map[T, U any, K comparable](src []T, fn func(T) U) (res []U) {
+	return nil
+}
+
+func (l *List[T]) Push(a, b T, rest ...T) *List[T] {
+	return l
+}
+
+func (List[T]) Len() int
+
+func noName(int, string) (int, error) {
+	return 0, nil
+}
+
+func ptr() *sitter.Node { return nil }
+
+func (p Parser) grouped(x, y, z float64, opts ...func(*Parser)) (a, b int, err error) {
+	return
+}
+`
+
+func TestExtractor_ExtractTrickyFunctions(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goTrickyFuncCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	cases := []struct {
+		signature      string
+		receiver       string
+		typeParameters int
+		hasBody        bool
+	}{
+		{"abc||Map|[]T,func(T) U|[]U", "", 3, true},
+		{"abc|List|Push|T,T,...T|*List[T]", "*List[T]", 0, true},
+		{"abc|List|Len||int", "List[T]", 0, false},
+		{"abc||noName|int,string|int,error", "", 0, true},
+		{"abc||ptr||*sitter.Node", "", 0, true},
+		{"abc|Parser|grouped|float64,float64,float64,...func(*Parser)|int,int,error", "Parser", 0, true},
+	}
+	assert.Len(t, funcs, len(cases))
+	for i, each := range cases {
+		f := funcs[i]
+		extras := f.Extras.(*FuncExtras)
+		assert.Equal(t, each.signature, f.GetSignature())
+		assert.Equal(t, each.receiver, extras.RawReceiver)
+		assert.Len(t, extras.TypeParameters, each.typeParameters)
+		assert.Equal(t, each.hasBody, f.BodySpan.Start.Row != 0, f.Name)
+	}
+
+	// grouped names
+	mapFunc := funcs[0]
+	assert.Equal(t, "U", mapFunc.Extras.(*FuncExtras).TypeParameters[1].Name)
+	assert.Equal(t, "any", mapFunc.Extras.(*FuncExtras).TypeParameters[1].Type)
+	assert.Equal(t, "res", mapFunc.Returns[0].Name)
+	push := funcs[1]
+	assert.Equal(t, "b", push.Parameters[1].Name)
+	assert.Equal(t, "rest", push.Parameters[2].Name)
+	assert.False(t, funcs[2].Extras.(*FuncExtras).PointerReceiver)
+	grouped := funcs[5]
+	assert.Equal(t, "z", grouped.Parameters[2].Name)
+	assert.Equal(t, "b", grouped.Returns[1].Name)
+}
+
+var goLiteralCode = `
+package abc
+
+var global = func() int { return 1 }
+
+func (s *Server) Start() {
+	go func(a int) {
+		run(a)
+	}(1)
+	s.r.Handle("/", func(c *gin.Context) error {
+		inner := func() { deep() }
+		inner()
+		return nil
+	})
+}
+`
+
+func TestExtractor_ExtractFuncLiterals(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goLiteralCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	names := make(map[string]*object.Function)
+	for _, each := range funcs {
+		names[each.Name] = each
+	}
+	assert.Len(t, names, 5)
+	assert.Contains(t, names, "glob..func1")
+	assert.Contains(t, names, "Start.func1")
+	assert.Contains(t, names, "Start.func2.1")
+
+	start := names["Start"]
+	handler := names["Start.func2"]
+	handlerExtras := handler.Extras.(*FuncExtras)
+	assert.True(t, handlerExtras.Literal)
+	assert.Equal(t, start.GetSignature(), handlerExtras.Parent)
+	assert.Equal(t, "Server", handler.Receiver)
+	assert.Equal(t, "*gin.Context", handler.Parameters[0].Type)
+	assert.Equal(t, "error", handler.Returns[0].Type)
+	assert.Equal(t, 10, handler.DefLine)
+	assert.Equal(t, handler.GetSignature(), names["Start.func2.1"].Extras.(*FuncExtras).Parent)
+	assert.Empty(t, names["glob..func1"].Extras.(*FuncExtras).Parent)
+
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	callers := make(map[string]string)
+	for _, each := range calls {
+		callers[each.Caller] = each.Src
+	}
+	assert.Equal(t, names["Start.func1"].GetSignature(), callers["run"])
+	assert.Equal(t, names["Start.func2.1"].GetSignature(), callers["deep"])
+	assert.Equal(t, handler.GetSignature(), callers["inner"])
+	assert.Equal(t, start.GetSignature(), callers["s.r.Handle"])
+}
+
+var goImportCode = `
+package main
+
+import "fmt"
+import (
+	f "os"
+	. "strings"
+	_ "embed"
+)
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goImportCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 4)
+
+	assert.Equal(t, "fmt", imports[0].Source)
+	assert.Empty(t, imports[0].Alias)
+	assert.Equal(t, uint32(3), imports[0].Span.Start.Row)
+	assert.Equal(t, "os", imports[1].Source)
+	assert.Equal(t, "f", imports[1].Alias)
+	assert.Equal(t, "strings", imports[2].Source)
+	assert.True(t, imports[2].Wildcard)
+	assert.Equal(t, "_", imports[3].Alias)
+}
+
+var goCommentCode = `
+package a
+
+// Foo does foo.
+// Deprecated: use Bar instead.
+func Foo() {
+	a := 1 // trailing
+}
+
+/* Bar does bar. */
+
+func Bar() {}
+
+// A is a struct
+type A struct{}
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 5)
+	assert.Equal(t, "Foo does foo.", comments[0].Text)
+	assert.Equal(t, object.CommentLine, comments[0].Kind)
+	assert.Equal(t, uint32(3), comments[0].Span.Start.Row)
+	assert.Equal(t, "a||Foo||", comments[0].Owner)
+	assert.Equal(t, "a||Foo||", comments[1].Owner)
+	// trailing comment
+	assert.Empty(t, comments[2].Owner)
+	// blank line between
+	assert.Equal(t, object.CommentBlock, comments[3].Kind)
+	assert.Empty(t, comments[3].Owner)
+	assert.Equal(t, "a.A", comments[4].Owner)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "Foo does foo.\nDeprecated: use Bar instead.", functions[0].Extras.(*FuncExtras).Doc)
+	assert.Empty(t, functions[1].Extras.(*FuncExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "A is a struct", classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var goVariableCode = `
+package a
+
+const Max = 10
+
+const (
+	A, B int = 1, 2
+)
+
+var debug = false
+
+type S struct {
+	Name string
+}
+
+func f() {
+	x, err := g()
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 7)
+
+	assert.Equal(t, "Max", variables[0].Name)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeModule, variables[0].Scope)
+	assert.Equal(t, "a", variables[0].Namespace)
+
+	// const block
+	assert.Equal(t, "B", variables[2].Name)
+	assert.Equal(t, "int", variables[2].Type)
+	assert.Equal(t, "2", variables[2].Value)
+
+	assert.Equal(t, "debug", variables[3].Name)
+	assert.Equal(t, object.MutabilityVar, variables[3].Mutability)
+
+	// field
+	assert.Equal(t, "Name", variables[4].Name)
+	assert.Equal(t, object.VariableScopeClass, variables[4].Scope)
+	assert.Equal(t, "S", variables[4].Receiver)
+
+	// multi returns
+	assert.Equal(t, "err", variables[6].Name)
+	assert.Equal(t, "g()", variables[6].Value)
+	assert.Equal(t, object.VariableScopeLocal, variables[6].Scope)
+}
+
+var goMetricCode = `
+package a
+
+func f(a, b int) int {
+	// c
+	if a > 0 && b > 0 || a < 0 {
+		return 1
+	} else if b > 0 {
+		return 2
+	} else {
+	}
+	for i := 0; i < 3; i++ {
+		switch i {
+		case 1:
+		default:
+		}
+	}
+	select {
+	case <-ch:
+	}
+	g := func() {}
+	return 0
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 8, metrics.Cyclomatic)
+	assert.Equal(t, 8, metrics.Cognitive)
+	assert.Equal(t, 19, metrics.Loc)
+	assert.Equal(t, 2, metrics.MaxNesting)
+	assert.Equal(t, 2, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
+
+var goSymbolCode = `
+package a
+
+type S struct {
+	Name string
+}
+
+func (s *S) Run(n int) {
+	x := n
+	s.Name = fmt.Sprint(x)
+	helper(x)
+}
+
+func helper(a int) {}
+`
+
+func TestExtractor_ExtractSymbolKinds(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goSymbolCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	symbols, err := extractor.ExtractSymbols(units)
+	assert.Nil(t, err)
+	find := func(name string, nodeType object.NodeType) *object.Symbol {
+		for _, each := range symbols {
+			if each.Symbol == name && each.NodeType == nodeType {
+				return each
+			}
+		}
+		return nil
+	}
+
+	s := find("S", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeType, s.SyntaxType)
+	assert.Empty(t, s.Scope)
+	assert.Equal(t, object.SyntaxTypeType, find("S", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, "a.S", find("Name", object.NodeTypeDefinition).Scope)
+
+	run := find("Run", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeMethod, run.SyntaxType)
+	assert.Empty(t, run.Scope)
+	assert.Equal(t, object.SyntaxTypeFunction, find("helper", object.NodeTypeDefinition).SyntaxType)
+
+	x := find("x", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeVariable, x.SyntaxType)
+	assert.Equal(t, "a|S|Run|int|", x.Scope)
+	assert.Equal(t, object.SyntaxTypeVariable, find("n", object.NodeTypeDefinition).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeVariable, find("x", object.NodeTypeReference).SyntaxType)
+
+	assert.Equal(t, object.SyntaxTypeCall, find("helper", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeCall, find("Sprint", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeVariable, find("Name", object.NodeTypeReference).SyntaxType)
+}
+
+var goTestCode = `
+package a
+
+import "testing"
+
+func TestA(t *testing.T) {}
+
+func Testing(t *testing.T) {}
+
+func Test_b(t *testing.T) {}
+
+func BenchmarkA(b *testing.B) {}
+
+func FuzzA(f *testing.F) {}
+
+func TestMain(m *testing.M) {}
+`
+
+func TestExtractor_ExtractTests(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goTestCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	var tests []string
+	for _, each := range functions {
+		if each.IsTest() {
+			assert.Equal(t, object.TestFrameworkGo, each.GetTestFramework())
+			tests = append(tests, each.Name)
+		}
+	}
+	assert.Equal(t, []string{"TestA", "Test_b", "BenchmarkA", "FuzzA"}, tests)
+	assert.True(t, IsTestFile("a/b_test.go"))
+	assert.False(t, IsTestFile("a/b.go"))
+
+	functions = extractor.HookFileFunctions("a/b_test.go", functions)
+	assert.True(t, functions[0].IsTest())
+	// never run outside `_test.go`
+	for _, each := range extractor.HookFileFunctions("a/b.go", functions) {
+		assert.False(t, each.IsTest())
+	}
+}
+
+var goEntryCode = `
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/robfig/cron/v3"
+)
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api")
+	api.GET("/users", auth, listUsers)
+	r.Handle("POST", "/users", func(c *gin.Context) {})
+	http.HandleFunc("GET /ping", ping)
+
+	c := cron.New()
+	c.AddFunc("0 * * * *", clean)
+	r.Run()
+}
+`
+
+func TestExtractor_DetectEntryPoints(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goEntryCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	entries, err := extractor.DetectEntryPoints(units)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 4)
+
+	assert.Equal(t, object.EntryFrameworkGin, entries[0].Framework)
+	assert.Equal(t, "GET", entries[0].Method)
+	assert.Equal(t, "/api/users", entries[0].Route)
+	assert.Equal(t, "listUsers", entries[0].Handler)
+
+	// inline handler
+	assert.Equal(t, "POST", entries[1].Method)
+	assert.Equal(t, KindGolangFuncLiteral, entries[1].Unit.Kind)
+
+	assert.Equal(t, object.EntryFrameworkNetHttp, entries[2].Framework)
+	assert.Equal(t, "GET", entries[2].Method)
+	assert.Equal(t, "/ping", entries[2].Route)
+
+	assert.Equal(t, object.EntryKindSchedule, entries[3].Kind)
+	assert.Equal(t, "0 * * * *", entries[3].Route)
+	assert.Equal(t, "clean", entries[3].Handler)
+}
+
+var goCallCode = `
+package svc
+
+func (s *Server) Handle(req *Request, items []*Item) (err error) {
+	s.helper()
+	req.Validate()
+	util.Clean()
+	x := build()
+	x.Run()
+	go func(r *util.Reader) {
+		r.Read()
+		req.Done()
+	}(nil)
+}
+`
+
+func TestExtractor_ExtractCallReceiverType(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangGo)
+	units, err := parser.Parse([]byte(goCallCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	receiverTypes := make(map[string]string)
+	for _, each := range calls {
+		receiverTypes[each.Caller] = each.ReceiverType
+	}
+	assert.Equal(t, "Server", receiverTypes["s.helper"])
+	assert.Equal(t, "Request", receiverTypes["req.Validate"])
+	assert.Equal(t, "util.Reader", receiverTypes["r.Read"])
+	// from the enclosing function
+	assert.Equal(t, "Request", receiverTypes["req.Done"])
+	assert.Empty(t, receiverTypes["util.Clean"])
+	assert.Empty(t, receiverTypes["x.Run"])
+}
@@ -0,0 +1,64 @@
+package golang
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extras *FuncExtras) GetTestFramework() object.TestFramework {
+	return extras.TestFramework
+}
+
+// prefix -> the only parameter
+var testPrefixes = map[string]string{
+	"Test":      "*testing.T",
+	"Benchmark": "*testing.B",
+	"Fuzz":      "*testing.F",
+}
+
+// testFramework `func TestXxx(t *testing.T)`, `func BenchmarkXxx(b *testing.B)` and `func FuzzXxx(f *testing.F)`.
+// Files are unknown here, see IsTestFile.
+func testFramework(f *object.Function) object.TestFramework {
+	if f.Receiver != "" || len(f.Parameters) != 1 || len(f.Returns) != 0 {
+		return ""
+	}
+	for prefix, paramType := range testPrefixes {
+		if isTestName(f.Name, prefix) && f.Parameters[0].Type == paramType {
+			return object.TestFrameworkGo
+		}
+	}
+	return ""
+}
+
+// isTestName as go test does, `Testing` is not a test but `Test_a` is
+func isTestName(name string, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return !unicode.IsLower(r)
+}
+
+// IsTestFile go tests only live in `_test.go`
+func IsTestFile(path string) bool {
+	return strings.HasSuffix(path, "_test.go")
+}
+
+// HookFileFunctions go test functions outside `_test.go` will never run
+func (extractor *Extractor) HookFileFunctions(path string, functions []*object.Function) []*object.Function {
+	if IsTestFile(path) {
+		return functions
+	}
+	for _, each := range functions {
+		if extras, ok := each.Extras.(*FuncExtras); ok {
+			extras.TestFramework = ""
+		}
+	}
+	return functions
+}
@@ -0,0 +1,106 @@
+package golang
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	switch unit.Kind {
+	case KindGolangConstSpec, KindGolangVarSpec, KindGolangShortVarDecl:
+		return true
+	case KindGolangFieldDecl:
+		// struct fields, embedded fields are ignored
+		return core.FindFirstByKindInSubs(unit, KindGolangFieldIdentifier) != nil &&
+			core.FindFirstByKindInParent(unit, KindGolangStructType) != nil
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		ret = append(ret, extractor.extractVariable(eachUnit)...)
+	}
+	return ret, nil
+}
+
+// extractVariable one spec can declare multi variables, eg: `const A, B int = 1, 2`
+func (extractor *Extractor) extractVariable(unit *core.Unit) []*object.Variable {
+	var names []*core.Unit
+	var typeDef *core.Unit
+	var values []*core.Unit
+	var valueList *core.Unit
+	if unit.Kind == KindGolangShortVarDecl {
+		// expression_list := expression_list
+		if len(unit.SubUnits) != 2 {
+			return nil
+		}
+		names = unit.SubUnits[0].SubUnits
+		valueList = unit.SubUnits[1]
+	} else {
+		for _, each := range unit.SubUnits {
+			switch each.Kind {
+			case KindGolangIdentifier, KindGolangFieldIdentifier:
+				names = append(names, each)
+			case KindGolangExpressionList:
+				valueList = each
+			case KindGolangRawStringLiteral, KindGolangStringLiteral:
+				// struct tag
+			default:
+				typeDef = each
+			}
+		}
+	}
+	if valueList != nil {
+		values = valueList.SubUnits
+	}
+
+	mutability := object.MutabilityVar
+	if unit.Kind == KindGolangConstSpec {
+		mutability = object.MutabilityConst
+	}
+	scope := object.VariableScopeModule
+	var receiver string
+	if unit.Kind == KindGolangFieldDecl {
+		scope = object.VariableScopeClass
+		if typeSpec := core.FindFirstByKindInParent(unit, KindGolangTypeSpec); typeSpec != nil && len(typeSpec.SubUnits) != 0 {
+			receiver = typeSpec.SubUnits[0].Content
+		}
+	} else if core.FindFirstByKindInParent(unit, KindGolangBlock) != nil {
+		scope = object.VariableScopeLocal
+	}
+
+	var namespace string
+	root := core.FindFirstByKindInParent(unit, KindGolangSourceFile)
+	if pkgName := core.FindFirstByKindInSubsWithDfs(root, KindGolangPackageIdentifier); pkgName != nil {
+		namespace = pkgName.Content
+	}
+
+	ret := make([]*object.Variable, 0, len(names))
+	for i, each := range names {
+		v := object.NewVariable()
+		v.Name = each.Content
+		if typeDef != nil {
+			v.Type = typeDef.Content
+		}
+		switch {
+		case len(values) == len(names):
+			v.Value = values[i].Content
+		case valueList != nil:
+			// multi returns, eg: `a, b := f()`
+			v.Value = valueList.Content
+		}
+		v.Mutability = mutability
+		v.Scope = scope
+		v.Namespace = namespace
+		v.Receiver = receiver
+		v.Span = unit.Span
+		v.Lang = extractor.GetLang()
+		ret = append(ret, v)
+	}
+	return ret
+}
@@ -1,7 +1,10 @@
 package java
 
 import (
+	"strconv"
+
 	"github.com/opensibyl/sibyl2/pkg/core"
+	"golang.org/x/exp/slices"
 )
 
 // https://github.com/tree-sitter/tree-sitter-java/tree/master/src/node-types.json
@@ -14,8 +17,27 @@ const (
 	KindJavaClassBody            core.KindRepr = "class_body"
 	KindJavaFieldDeclaration     core.KindRepr = "field_declaration"
 	KindJavaEnumDeclaration      core.KindRepr = "enum_declaration"
+	KindJavaEnumBody             core.KindRepr = "enum_body"
+	KindJavaEnumBodyDeclarations core.KindRepr = "enum_body_declarations"
 	KindJavaInterfaceDeclaration core.KindRepr = "interface_declaration"
 	KindJavaMethodDeclaration    core.KindRepr = "method_declaration"
+	KindJavaConstructorDecl      core.KindRepr = "constructor_declaration"
+	KindJavaCompactConstructor   core.KindRepr = "compact_constructor_declaration"
+	KindJavaConstructorBody      core.KindRepr = "constructor_body"
+	KindJavaRecordDeclaration    core.KindRepr = "record_declaration"
+	KindJavaAnnotationTypeDecl   core.KindRepr = "annotation_type_declaration"
+	KindJavaAnnotationElement    core.KindRepr = "annotation_type_element_declaration"
+	KindJavaConstantDeclaration  core.KindRepr = "constant_declaration"
+	KindJavaObjectCreation       core.KindRepr = "object_creation_expression"
+	KindJavaSpreadParameter      core.KindRepr = "spread_parameter"
+	KindJavaVariableDeclarator   core.KindRepr = "variable_declarator"
+	KindJavaLocalVariableDecl    core.KindRepr = "local_variable_declaration"
+	KindJavaDimensions           core.KindRepr = "dimensions"
+	KindJavaTypeParameters       core.KindRepr = "type_parameters"
+	KindJavaTypeParameter        core.KindRepr = "type_parameter"
+	KindJavaThrows               core.KindRepr = "throws"
+	KindJavaBlockComment         core.KindRepr = "block_comment"
+	KindJavaLineComment          core.KindRepr = "line_comment"
 	KindJavaFormalParameters     core.KindRepr = "formal_parameters"
 	KindJavaFormalParameter      core.KindRepr = "formal_parameter"
 	KindJavaMethodInvocation     core.KindRepr = "method_invocation"
@@ -25,9 +47,30 @@ const (
 	KindJavaBlock                core.KindRepr = "block"
 	KindJavaSuperClass           core.KindRepr = "superclass"
 	KindJavaSuperInterface       core.KindRepr = "super_interfaces"
+	KindJavaExtendsInterfaces    core.KindRepr = "extends_interfaces"
+	KindJavaImportDeclaration    core.KindRepr = "import_declaration"
+	KindJavaAsterisk             core.KindRepr = "asterisk"
 	KindJavaTypeList             core.KindRepr = "type_list"
 	KindJavaTypeIdentifier       core.KindRepr = "type_identifier"
 	KindJavaGenericType          core.KindRepr = "generic_type"
+	KindJavaScopedTypeIdentifier core.KindRepr = "scoped_type_identifier"
+	KindJavaIfStatement          core.KindRepr = "if_statement"
+	KindJavaForStatement         core.KindRepr = "for_statement"
+	KindJavaEnhancedForStatement core.KindRepr = "enhanced_for_statement"
+	KindJavaWhileStatement       core.KindRepr = "while_statement"
+	KindJavaDoStatement          core.KindRepr = "do_statement"
+	KindJavaSwitchLabel          core.KindRepr = "switch_label"
+	KindJavaCatchClause          core.KindRepr = "catch_clause"
+	KindJavaTernaryExpression    core.KindRepr = "ternary_expression"
+	KindJavaSwitchExpression     core.KindRepr = "switch_expression"
+	KindJavaLambdaExpression     core.KindRepr = "lambda_expression"
+	KindJavaBinaryExpression     core.KindRepr = "binary_expression"
+	KindJavaReturnStatement      core.KindRepr = "return_statement"
+	KindJavaInferredParameters   core.KindRepr = "inferred_parameters"
+	KindJavaCatchFormalParameter core.KindRepr = "catch_formal_parameter"
+	KindJavaEnumConstant         core.KindRepr = "enum_constant"
+	KindJavaArgumentList         core.KindRepr = "argument_list"
+	KindJavaFieldAccess          core.KindRepr = "field_access"
 	FieldJavaType                core.KindRepr = "type"
 	FieldJavaDimensions          core.KindRepr = "dimensions"
 	FieldJavaObject              core.KindRepr = "object"
@@ -42,3 +85,132 @@ type Extractor struct {
 func (extractor *Extractor) GetLang() core.LangType {
 	return core.LangJava
 }
+
+var namedClassKinds = []core.KindRepr{
+	KindJavaClassDeclaration,
+	KindJavaEnumDeclaration,
+	KindJavaInterfaceDeclaration,
+	KindJavaRecordDeclaration,
+	KindJavaAnnotationTypeDecl,
+}
+
+func isNamedClass(unit *core.Unit) bool {
+	return slices.Contains(namedClassKinds, unit.Kind)
+}
+
+func findPackage(unit *core.Unit) string {
+	program := core.FindFirstByKindInParent(unit, KindJavaProgram)
+	packageDecl := core.FindFirstByKindInSubsWithDfs(program, KindJavaProgramDeclaration)
+	packageIdentifier := core.FindFirstByKindInSubsWithDfs(packageDecl, KindJavaScopeIdentifier)
+	if packageIdentifier == nil {
+		// package without dot
+		packageIdentifier = core.FindFirstByKindInSubsWithDfs(packageDecl, KindJavaIdentifier)
+	}
+	if packageIdentifier == nil {
+		core.Log.Warnf("no package found in %s", unit.Content)
+		return ""
+	}
+	return packageIdentifier.Content
+}
+
+// findName the direct identifier child
+func findName(unit *core.Unit) *core.Unit {
+	for _, each := range unit.SubUnits {
+		if each.Kind == KindJavaIdentifier {
+			return each
+		}
+	}
+	return nil
+}
+
+// anonymousClassBody returns class body if this unit is an anonymous class, eg: `new Runnable() {...}`
+func anonymousClassBody(unit *core.Unit) *core.Unit {
+	if unit.Kind != KindJavaObjectCreation {
+		return nil
+	}
+	for _, each := range unit.SubUnits {
+		if each.Kind == KindJavaClassBody {
+			return each
+		}
+	}
+	return nil
+}
+
+// findOwnerClass the closest named or anonymous class which contains this unit
+func findOwnerClass(unit *core.Unit) *core.Unit {
+	cur := unit
+	for parent := unit.ParentUnit; parent != nil; cur, parent = parent, parent.ParentUnit {
+		if isNamedClass(parent) {
+			return parent
+		}
+		// arguments of anonymous class creation are not a part of it
+		if body := anonymousClassBody(parent); body != nil && body == cur {
+			return parent
+		}
+	}
+	return nil
+}
+
+// classPath nested path of a class in its package.
+// Anonymous classes are numbered in their owner class, like javac does.
+//
+//	Outer.Inner
+//	Outer.Inner$1
+func classPath(classUnit *core.Unit) string {
+	var segment string
+	owner := findOwnerClass(classUnit)
+	if isNamedClass(classUnit) {
+		if name := findName(classUnit); name != nil {
+			segment = name.Content
+		}
+		if owner == nil {
+			return segment
+		}
+		return classPath(owner) + "." + segment
+	}
+
+	if owner == nil {
+		// should not happen in valid code
+		return "$" + strconv.Itoa(anonymousIndex(classUnit.ParentUnit, classUnit))
+	}
+	return classPath(owner) + "$" + strconv.Itoa(anonymousIndex(owner, classUnit))
+}
+
+// anonymousIndex position (starts from 1) of an anonymous class in its owner
+func anonymousIndex(owner *core.Unit, target *core.Unit) int {
+	index := 0
+	var walk func(*core.Unit) bool
+	walk = func(cur *core.Unit) bool {
+		if isNamedClass(cur) {
+			return false
+		}
+		body := anonymousClassBody(cur)
+		if body != nil {
+			index++
+			if cur == target {
+				return true
+			}
+		}
+		for _, each := range cur.SubUnits {
+			// classes inside it belong to another owner
+			if each == body {
+				continue
+			}
+			if walk(each) {
+				return true
+			}
+		}
+		return false
+	}
+
+	scope := owner
+	if body := anonymousClassBody(owner); body != nil {
+		scope = body
+	}
+	for _, each := range scope.SubUnits {
+		if walk(each) {
+			break
+		}
+	}
+	return index
+}
@@ -2,6 +2,7 @@ package java
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
@@ -32,7 +33,7 @@ func (extractor *Extractor) ExtractCalls(units []*core.Unit) ([]*object.Call, er
 }
 
 func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
-	funcUnit := core.FindFirstByOneOfKindInParent(unit, KindJavaMethodDeclaration)
+	funcUnit := core.FindFirstByOneOfKindInParent(unit, KindJavaMethodDeclaration, KindJavaConstructorDecl, KindJavaCompactConstructor)
 	var srcFunc *object.Function
 	var err error
 	if funcUnit != nil {
@@ -47,32 +48,29 @@ func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
 		return nil, errors.New("headless call")
 	}
 
-	var argumentPart *core.Unit
 	var arguments []string
 	var caller string
 
+	namePart := core.FindFirstByFieldInSubs(unit, FieldJavaName)
+	if namePart == nil {
+		return nil, errors.New("no id: " + unit.Content)
+	}
+	argumentPart := core.FindFirstByFieldInSubs(unit, FieldJavaArguments)
 	callerPart := core.FindFirstByFieldInSubs(unit, FieldJavaObject)
 	if callerPart == nil {
 		// b()
-		callerPart = core.FindFirstByFieldInSubs(unit, FieldJavaName)
-		argumentPart = core.FindFirstByFieldInSubs(unit, FieldJavaArguments)
-		caller = callerPart.Content
+		caller = namePart.Content
 	} else {
 		// a.b()
-		identifiers := core.FindAllByKindInSubs(unit, KindJavaIdentifier)
-		argumentPart = core.FindFirstByFieldInSubs(unit, FieldJavaName)
-
-		if len(identifiers) == 0 {
-			return nil, errors.New("no id: " + unit.Content)
-		}
-
-		caller = callerPart.Content + "." + identifiers[len(identifiers)-1].Content
+		caller = callerPart.Content + "." + namePart.Content
 	}
 
-	// not perfect, eg: anonymous function call?
+	// one for each argument, overloads can be told by counts
 	if argumentPart != nil {
-		for _, each := range core.FindAllByKindInSubs(argumentPart, KindJavaIdentifier) {
-			arguments = append(arguments, each.Content)
+		for _, each := range argumentPart.SubUnits {
+			if each.Kind != KindJavaBlockComment && each.Kind != KindJavaLineComment {
+				arguments = append(arguments, each.Content)
+			}
 		}
 	}
 
@@ -82,5 +80,75 @@ func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
 		Arguments: arguments,
 		Span:      unit.Span,
 	}
+	if callerPart != nil {
+		ret.ReceiverType = receiverType(callerPart)
+	}
 	return ret, nil
 }
+
+var typeKinds = []core.KindRepr{KindJavaTypeIdentifier, KindJavaGenericType, KindJavaScopedTypeIdentifier}
+
+// receiverType type of `a` in `a.b()`, when `a` is a typed variable or a new object
+func receiverType(object *core.Unit) string {
+	switch object.Kind {
+	case KindJavaObjectCreation:
+		return findTypeName(object)
+	case KindJavaIdentifier:
+	default:
+		return ""
+	}
+
+	name := object.Content
+	for cur := object.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		var decls []*core.Unit
+		switch cur.Kind {
+		case KindJavaMethodDeclaration, KindJavaConstructorDecl:
+			// locals declared before, and parameters
+			for _, each := range core.FindAllByKindInSubsWithDfs(cur, KindJavaLocalVariableDecl) {
+				if !each.Span.Start.After(object.Span.Start) {
+					decls = append(decls, each)
+				}
+			}
+			if params := core.FindFirstByKindInSubs(cur, KindJavaFormalParameters); params != nil {
+				decls = append(decls, core.FindAllByKindInSubs(params, KindJavaFormalParameter)...)
+			}
+		case KindJavaClassBody:
+			decls = core.FindAllByKindInSubs(cur, KindJavaFieldDeclaration)
+		default:
+			continue
+		}
+		for _, each := range decls {
+			if declares(each, name) {
+				return findTypeName(each)
+			}
+		}
+	}
+	return ""
+}
+
+// declares `Foo a = ...`, `Foo a, b;` and `Foo a` (parameter)
+func declares(decl *core.Unit, name string) bool {
+	if decl.Kind == KindJavaFormalParameter {
+		id := findName(decl)
+		return id != nil && id.Content == name
+	}
+	for _, each := range core.FindAllByKindInSubs(decl, KindJavaVariableDeclarator) {
+		if id := findName(each); id != nil && id.Content == name {
+			return true
+		}
+	}
+	return false
+}
+
+// findTypeName the direct type without type arguments, empty for `var`
+func findTypeName(unit *core.Unit) string {
+	typeUnits := core.FindAllByKindsInSubs(unit, typeKinds...)
+	if len(typeUnits) == 0 || typeUnits[0].Content == "var" {
+		return ""
+	}
+	typeName := typeUnits[0].Content
+	if index := strings.Index(typeName, "<"); index != -1 {
+		typeName = typeName[:index]
+	}
+	return typeName
+}
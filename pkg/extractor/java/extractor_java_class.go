@@ -2,32 +2,48 @@ package java
 
 import (
 	"errors"
-	"strings"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 )
 
+const (
+	ClassKindClass      = "class"
+	ClassKindEnum       = "enum"
+	ClassKindInterface  = "interface"
+	ClassKindRecord     = "record"
+	ClassKindAnnotation = "annotation"
+)
+
 type ClassField struct {
 	Name        string   `json:"name"`
 	Type        string   `json:"type"`
 	Annotations []string `json:"annotations"`
 	Modifiers   []string `json:"modifiers"`
+	// Default value of annotation elements
+	Default string `json:"default"`
 }
 
 type ClassExtras struct {
+	Kind        string        `json:"kind"`
 	Annotations []string      `json:"annotations"`
 	Fields      []*ClassField `json:"fields"`
 	Modifiers   []string      `json:"modifiers"`
-	Extends     string        `json:"extends"`
-	Implements  []string      `json:"implements"`
+	// TypeParameters eg: `K extends Comparable<K>`
+	TypeParameters []string `json:"typeParameters"`
+	// Extends Implements ExtendedInterfaces, as they are written, eg: `Base<T>`
+	Extends            string   `json:"extends"`
+	Implements         []string `json:"implements"`
+	ExtendedInterfaces []string `json:"extendedInterfaces"`
+	// SuperTypes full names of all the direct super types, without type arguments.
+	// Resolved with imports, eg: `Base<T>` -> `com.z.Base`
+	SuperTypes []string `json:"superTypes"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
 }
 
 func (extractor *Extractor) IsClass(unit *core.Unit) bool {
-	if unit.Kind == KindJavaClassDeclaration || unit.Kind == KindJavaEnumDeclaration || unit.Kind == KindJavaInterfaceDeclaration {
-		return true
-	}
-	return false
+	return isNamedClass(unit)
 }
 
 func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
@@ -50,95 +66,125 @@ func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error)
 	clazz.Span = unit.Span
 	clazz.Lang = extractor.GetLang()
 	clazz.Unit = unit
+	clazz.Module = findPackage(unit)
 
-	program := core.FindFirstByKindInParent(unit, KindJavaProgram)
-	packageDecl := core.FindFirstByKindInSubsWithDfs(program, KindJavaProgramDeclaration)
-	packageIdentifier := core.FindFirstByKindInSubsWithDfs(packageDecl, KindJavaScopeIdentifier)
-	if packageIdentifier == nil {
-		core.Log.Warnf("no package found in %s", unit.Content)
-	} else {
-		clazz.Module = packageIdentifier.Content
-	}
-
-	// trace its class (the closest one
-	clazzDecl := core.FindFirstByOneOfKindInParent(unit, KindJavaClassDeclaration, KindJavaEnumDeclaration, KindJavaInterfaceDeclaration)
-	clazzIdentifier := core.FindFirstByKindInSubsWithBfs(clazzDecl, KindJavaIdentifier)
-	if clazzIdentifier == nil {
+	if findName(unit) == nil {
 		return nil, errors.New("no class found in " + unit.Content)
 	}
-	clazz.Name = clazzIdentifier.Content
+	// nested classes are qualified with their outer classes, eg: Outer.Inner
+	clazz.Name = classPath(unit)
 
 	extras := &ClassExtras{}
+	switch unit.Kind {
+	case KindJavaEnumDeclaration:
+		extras.Kind = ClassKindEnum
+	case KindJavaInterfaceDeclaration:
+		extras.Kind = ClassKindInterface
+	case KindJavaRecordDeclaration:
+		extras.Kind = ClassKindRecord
+	case KindJavaAnnotationTypeDecl:
+		extras.Kind = ClassKindAnnotation
+	default:
+		extras.Kind = ClassKindClass
+	}
+
 	// class annotations
-	classModifiers := core.FindFirstByKindInSubsWithBfs(clazzDecl, KindJavaModifiers)
-	if classModifiers != nil {
-		classAnnotations := core.FindAllByKindsInSubs(classModifiers, KindJavaMarkerAnnotation, KindJavaAnnotation)
-		if len(classAnnotations) != 0 {
-			for _, each := range classAnnotations {
-				extras.Annotations = append(extras.Annotations, each.Content)
+	extras.Annotations = findAnnotations(unit)
+	extras.Modifiers = findModifiers(unit)
+
+	// fields
+	if unit.Kind == KindJavaRecordDeclaration {
+		// record components
+		for _, each := range extractParameters(core.FindFirstByKindInSubs(unit, KindJavaFormalParameters)) {
+			extras.Fields = append(extras.Fields, &ClassField{
+				Name: each.Name,
+				Type: each.Type,
+			})
+		}
+	}
+	for _, eachBody := range unit.SubUnits {
+		members := eachBody.SubUnits
+		if eachBody.Kind == KindJavaEnumBody {
+			// enum constants first, and then the normal class body
+			members = nil
+			for _, each := range core.FindAllByKindInSubs(eachBody, KindJavaEnumBodyDeclarations) {
+				members = append(members, each.SubUnits...)
+			}
+		}
+		for _, eachMember := range members {
+			switch eachMember.Kind {
+			case KindJavaFieldDeclaration, KindJavaConstantDeclaration:
+				fields, err := extractFields(eachMember)
+				if err != nil {
+					return nil, err
+				}
+				extras.Fields = append(extras.Fields, fields...)
+			case KindJavaAnnotationElement:
+				extras.Fields = append(extras.Fields, extractAnnotationElement(eachMember))
 			}
 		}
 	}
-	// fields
-	body := core.FindFirstByKindInSubsWithBfs(clazzDecl, KindJavaClassBody)
-	if body != nil {
-		fields := core.FindAllByKindsInSubs(body, KindJavaFieldDeclaration)
-		for _, eachField := range fields {
-			typeDecl := core.FindFirstByFieldInSubs(eachField, FieldJavaType)
-			variableDecl := core.FindFirstByFieldInSubs(eachField, FieldJavaDeclarator)
-			nameDecl := core.FindFirstByKindInSubsWithBfs(variableDecl, KindJavaIdentifier)
+
+	for _, each := range core.FindAllByKindInSubs(core.FindFirstByKindInSubs(unit, KindJavaTypeParameters), KindJavaTypeParameter) {
+		extras.TypeParameters = append(extras.TypeParameters, each.Content)
+	}
+
+	// extends and implements
+	extras.Extends, extras.Implements, extras.ExtendedInterfaces = superTypes(unit)
+	extras.SuperTypes = resolveSuperTypes(unit)
+
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	clazz.Extras = extras
+
+	return clazz, nil
+}
+
+// extractFields field_declaration or constant_declaration: [modifiers], type, declarators...
+func extractFields(unit *core.Unit) ([]*ClassField, error) {
+	var ret []*ClassField
+	var typeDecl *core.Unit
+	var annotations []string
+	var modifiers []string
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindJavaModifiers:
+			annotations, modifiers = splitModifiers(each)
+		case KindJavaVariableDeclarator:
+			nameDecl := findName(each)
 			if nameDecl == nil || typeDecl == nil {
 				return nil, errors.New("not finished field decl")
 			}
-			field := &ClassField{
+			ret = append(ret, &ClassField{
 				Name:        nameDecl.Content,
 				Type:        typeDecl.Content,
-				Annotations: nil,
-				Modifiers:   nil,
+				Annotations: annotations,
+				Modifiers:   modifiers,
+			})
+		default:
+			if typeDecl == nil {
+				typeDecl = each
 			}
-			extras.Fields = append(extras.Fields, field)
-
-			modifiers := core.FindFirstByKindInSubsWithBfs(eachField, KindJavaModifiers)
-			if modifiers == nil {
-				// no modifiers and annotations
-				continue
-			}
-			modifiersStr := modifiers.Content
-
-			// annotation?
-			annotations := core.FindAllByKindsInSubs(modifiers, KindJavaMarkerAnnotation, KindJavaAnnotation)
-			if len(annotations) != 0 {
-				for _, each := range annotations {
-					field.Annotations = append(extras.Annotations, each.Content)
-					// remove it from modifiers
-					// currently tree-sitter did not split these nodes
-					modifiersStr = strings.Replace(modifiersStr, each.Content, "", 1)
-				}
-			}
-			field.Modifiers = strings.Split(strings.TrimSpace(modifiersStr), " ")
-		}
-	}
-	// extends and implements
-	extends := core.FindFirstByKindInSubsWithBfs(unit, KindJavaSuperClass)
-	if extends != nil {
-		typeIdentifier := core.FindFirstByOneOfKindInParent(unit, KindJavaTypeIdentifier, KindJavaGenericType)
-		if typeIdentifier != nil {
-			extras.Extends = typeIdentifier.Content
 		}
 	}
-	implements := core.FindFirstByKindInSubsWithBfs(unit, KindJavaSuperInterface)
-	if implements != nil {
-		typeList := core.FindFirstByKindInSubsWithBfs(implements, KindJavaTypeList)
-		// should not nil
-		if typeList == nil {
-			return nil, errors.New("implements but not decl")
-		}
-		for _, each := range core.FindAllByKindInSubs(typeList, KindJavaTypeIdentifier) {
-			extras.Implements = append(extras.Implements, each.Content)
+	return ret, nil
+}
+
+// extractAnnotationElement annotation_type_element_declaration: [modifiers], type, name, [default value]
+func extractAnnotationElement(unit *core.Unit) *ClassField {
+	ret := &ClassField{}
+	var name *core.Unit
+	for _, each := range unit.SubUnits {
+		switch {
+		case each.Kind == KindJavaModifiers:
+			ret.Annotations = findAnnotations(unit)
+		case name == nil && each.Kind == KindJavaIdentifier:
+			name = each
+			ret.Name = each.Content
+		case name == nil:
+			ret.Type = each.Content
+		case each.Kind != KindJavaDimensions:
+			ret.Default = each.Content
 		}
 	}
-
-	clazz.Extras = extras
-
-	return clazz, nil
+	return ret
 }
@@ -0,0 +1,14 @@
+package java
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsComment(unit *core.Unit) bool {
+	return unit.Kind == KindJavaLineComment || unit.Kind == KindJavaBlockComment
+}
+
+func (extractor *Extractor) ExtractComments(units []*core.Unit) ([]*object.Comment, error) {
+	return object.ExtractComments(extractor, nil, units)
+}
@@ -0,0 +1,36 @@
+package java
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+// DetectEntryPoints spring and jax-rs handlers, and spring scheduled jobs
+func (extractor *Extractor) DetectEntryPoints(units []*core.Unit) ([]*object.EntryPoint, error) {
+	var ret []*object.EntryPoint
+	for _, eachUnit := range units {
+		if eachUnit.Kind != KindJavaMethodDeclaration {
+			continue
+		}
+		annotations := findAnnotations(eachUnit)
+		if len(annotations) == 0 {
+			continue
+		}
+		var classAnnotations []string
+		if clazzDecl := findOwnerClass(eachUnit); clazzDecl != nil {
+			classAnnotations = findAnnotations(clazzDecl)
+		}
+		var handler string
+		if nameUnit := findName(eachUnit); nameUnit != nil {
+			handler = nameUnit.Content
+		}
+
+		for _, each := range object.NewAnnotationEntryPoints(classAnnotations, annotations) {
+			each.Handler = handler
+			each.Span = eachUnit.Span
+			each.Unit = eachUnit
+			ret = append(ret, each)
+		}
+	}
+	return ret, nil
+}
@@ -2,6 +2,7 @@ package java
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
@@ -9,19 +10,34 @@ import (
 
 // FunctionExtras JavaFunctionExtras
 type FunctionExtras struct {
-	Annotations []string   `json:"annotations"`
-	ClassInfo   *ClassInfo `json:"classInfo"`
+	Annotations []string `json:"annotations"`
+	// Modifiers keywords, eg: public, static, abstract, synchronized, default
+	Modifiers []string `json:"modifiers"`
+	// IsConstructor constructors, including compact constructors of records
+	IsConstructor bool `json:"isConstructor"`
+	// TypeParameters eg: `T extends Number`
+	TypeParameters []string   `json:"typeParameters"`
+	Throws         []string   `json:"throws"`
+	Javadoc        *Javadoc   `json:"javadoc"`
+	ClassInfo      *ClassInfo `json:"classInfo"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+	// TestFramework empty if this function is not a test
+	TestFramework object.TestFramework `json:"testFramework"`
 }
 
 type ClassInfo struct {
 	PackageName string   `json:"packageName"`
 	ClassName   string   `json:"className"`
 	Annotations []string `json:"annotations"`
+	// SuperTypes full names of the direct super types
+	SuperTypes []string `json:"superTypes"`
 }
 
 func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
 	// no function in java
-	if unit.Kind == KindJavaMethodDeclaration {
+	switch unit.Kind {
+	case KindJavaMethodDeclaration, KindJavaConstructorDecl, KindJavaCompactConstructor:
 		return true
 	}
 	return false
@@ -49,63 +65,56 @@ func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function,
 	funcUnit.Lang = extractor.GetLang()
 
 	// body scope
-	funcBody := core.FindFirstByKindInSubsWithBfs(unit, KindJavaBlock)
-	if funcBody != nil {
-		funcUnit.BodySpan = funcBody.Span
+	for _, each := range unit.SubUnits {
+		if each.Kind == KindJavaBlock || each.Kind == KindJavaConstructorBody {
+			funcUnit.BodySpan = each.Span
+		}
 	}
 
-	pkgName := ""
-	clazzName := ""
-
 	// trace its package
-	program := core.FindFirstByKindInParent(unit, KindJavaProgram)
-	packageDecl := core.FindFirstByKindInSubsWithDfs(program, KindJavaProgramDeclaration)
-	packageIdentifier := core.FindFirstByKindInSubsWithDfs(packageDecl, KindJavaScopeIdentifier)
-	if packageIdentifier == nil {
-		core.Log.Warnf("no package found in %s", unit.Content)
-	} else {
-		pkgName = packageIdentifier.Content
-	}
+	pkgName := findPackage(unit)
 
-	// trace its class (the closest one
-	clazzDecl := core.FindFirstByOneOfKindInParent(unit, KindJavaClassDeclaration, KindJavaEnumDeclaration, KindJavaInterfaceDeclaration)
-	clazzIdentifier := core.FindFirstByKindInSubsWithBfs(clazzDecl, KindJavaIdentifier)
-	if clazzIdentifier == nil {
+	// trace its class (the closest one, maybe nested or anonymous
+	clazzDecl := findOwnerClass(unit)
+	if clazzDecl == nil {
 		return nil, errors.New("no class found in " + unit.Content)
 	}
-	clazzName = clazzIdentifier.Content
+	clazzName := classPath(clazzDecl)
 	funcUnit.Receiver = pkgName + "." + clazzName
 	funcUnit.Namespace = pkgName
 
-	funcIdentifier := core.FindFirstByKindInSubsWithBfs(unit, KindJavaIdentifier)
+	funcIdentifier := findName(unit)
 	if funcIdentifier == nil {
 		return nil, errors.New("no func id found in identifier" + unit.Content)
 	}
 	funcUnit.Name = funcIdentifier.Content
 	funcUnit.DefLine = int(funcIdentifier.Span.Start.Row + 1)
 
-	// returns
-	retUnit := core.FindFirstByFieldInSubsWithDfs(unit, FieldJavaDimensions)
-	valueUnit := &object.ValueUnit{
-		Type: retUnit.Content,
-		// java has no named return value
-		Name: "",
+	// returns, constructors have no return value
+	if unit.Kind == KindJavaMethodDeclaration {
+		var retUnit *core.Unit
+		for _, each := range unit.SubUnits {
+			if each == funcIdentifier {
+				break
+			}
+			retUnit = each
+		}
+		if retUnit != nil {
+			funcUnit.Returns = append(funcUnit.Returns, &object.ValueUnit{
+				Type: retUnit.Content,
+				// java has no named return value
+				Name: "",
+			})
+		}
 	}
-	funcUnit.Returns = append(funcUnit.Returns, valueUnit)
 
 	// params
-	parameters := core.FindFirstByKindInSubsWithDfs(unit, KindJavaFormalParameters)
-	if parameters != nil {
-		for _, each := range core.FindAllByKindInSubsWithDfs(parameters, KindJavaFormalParameter) {
-			typeName := core.FindFirstByFieldInSubsWithBfs(each, FieldJavaType)
-			paramName := core.FindFirstByFieldInSubsWithBfs(each, FieldJavaDimensions)
-			valueUnit = &object.ValueUnit{
-				Type: typeName.Content,
-				Name: paramName.Content,
-			}
-			funcUnit.Parameters = append(funcUnit.Parameters, valueUnit)
-		}
+	parameters := core.FindFirstByKindInSubs(unit, KindJavaFormalParameters)
+	if unit.Kind == KindJavaCompactConstructor {
+		// params come from record header
+		parameters = core.FindFirstByKindInSubs(clazzDecl, KindJavaFormalParameters)
 	}
+	funcUnit.Parameters = extractParameters(parameters)
 
 	// extras
 	extras := &FunctionExtras{}
@@ -117,27 +126,101 @@ func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function,
 	extras.ClassInfo = classInfo
 
 	// class annotations
-	classModifiers := core.FindFirstByKindInSubsWithBfs(clazzDecl, KindJavaModifiers)
-	if classModifiers != nil {
-		classAnnotations := core.FindAllByKindsInSubs(classModifiers, KindJavaMarkerAnnotation, KindJavaAnnotation)
-		if len(classAnnotations) != 0 {
-			for _, each := range classAnnotations {
-				classInfo.Annotations = append(classInfo.Annotations, each.Content)
+	classInfo.Annotations = findAnnotations(clazzDecl)
+	classInfo.SuperTypes = resolveSuperTypes(clazzDecl)
+
+	extras.Annotations = findAnnotations(unit)
+	extras.TestFramework = testFramework(extras.Annotations)
+	extras.Modifiers = findModifiers(unit)
+	extras.IsConstructor = unit.Kind == KindJavaConstructorDecl || unit.Kind == KindJavaCompactConstructor
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindJavaTypeParameters:
+			for _, eachParam := range core.FindAllByKindInSubs(each, KindJavaTypeParameter) {
+				extras.TypeParameters = append(extras.TypeParameters, eachParam.Content)
 			}
-		}
-	}
-	// todo: inherit
-
-	modifiers := core.FindFirstByKindInSubsWithBfs(unit, KindJavaModifiers)
-	if modifiers != nil {
-		annotations := core.FindAllByKindsInSubs(modifiers, KindJavaMarkerAnnotation, KindJavaAnnotation)
-		if len(annotations) != 0 {
-			for _, each := range annotations {
-				extras.Annotations = append(extras.Annotations, each.Content)
+		case KindJavaThrows:
+			for _, eachType := range each.SubUnits {
+				extras.Throws = append(extras.Throws, eachType.Content)
 			}
 		}
 	}
+	extras.Javadoc = findJavadoc(unit)
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
 	funcUnit.Extras = extras
 
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
 	return funcUnit, nil
 }
+
+// findAnnotations from the direct modifiers
+func findAnnotations(unit *core.Unit) []string {
+	annotations, _ := splitModifiers(core.FindFirstByKindInSubs(unit, KindJavaModifiers))
+	return annotations
+}
+
+// findModifiers keywords from the direct modifiers, eg: public, static
+func findModifiers(unit *core.Unit) []string {
+	_, keywords := splitModifiers(core.FindFirstByKindInSubs(unit, KindJavaModifiers))
+	return keywords
+}
+
+// splitModifiers split modifiers into annotations and keywords
+func splitModifiers(modifiers *core.Unit) ([]string, []string) {
+	var annotations []string
+	if modifiers == nil {
+		return annotations, nil
+	}
+	modifiersStr := modifiers.Content
+	for _, each := range core.FindAllByKindsInSubs(modifiers, KindJavaMarkerAnnotation, KindJavaAnnotation) {
+		annotations = append(annotations, each.Content)
+		// remove it from modifiers
+		// currently tree-sitter did not split these nodes
+		modifiersStr = strings.Replace(modifiersStr, each.Content, "", 1)
+	}
+	return annotations, strings.Fields(modifiersStr)
+}
+
+// extractParameters from formal_parameters
+// formal_parameter: [modifiers], type, name, [dimensions]
+// spread_parameter: [modifiers], type, variable_declarator
+func extractParameters(parameters *core.Unit) []*object.ValueUnit {
+	var ret []*object.ValueUnit
+	if parameters == nil {
+		return ret
+	}
+	for _, each := range parameters.SubUnits {
+		switch each.Kind {
+		case KindJavaFormalParameter:
+			valueUnit := &object.ValueUnit{}
+			for _, eachSub := range each.SubUnits {
+				switch eachSub.Kind {
+				case KindJavaModifiers:
+				case KindJavaIdentifier:
+					valueUnit.Name = eachSub.Content
+				case KindJavaDimensions:
+					// `String args[]`
+					valueUnit.Type += eachSub.Content
+				default:
+					valueUnit.Type = eachSub.Content
+				}
+			}
+			ret = append(ret, valueUnit)
+		case KindJavaSpreadParameter:
+			valueUnit := &object.ValueUnit{}
+			for _, eachSub := range each.SubUnits {
+				switch eachSub.Kind {
+				case KindJavaModifiers:
+				case KindJavaVariableDeclarator:
+					if name := findName(eachSub); name != nil {
+						valueUnit.Name = name.Content
+					}
+				default:
+					valueUnit.Type = eachSub.Content + "..."
+				}
+			}
+			ret = append(ret, valueUnit)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,43 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindJavaImportDeclaration
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) {
+			continue
+		}
+		identifiers := core.FindAllByKindsInSubs(eachUnit, KindJavaScopeIdentifier, KindJavaIdentifier)
+		if len(identifiers) == 0 {
+			continue
+		}
+		identifier := identifiers[0]
+		imp := object.NewImport()
+		imp.Span = eachUnit.Span
+		imp.Lang = extractor.GetLang()
+		imp.Static = strings.HasPrefix(eachUnit.Content, "import static")
+
+		if core.FindFirstByKindInSubs(eachUnit, KindJavaAsterisk) != nil {
+			// import java.util.*;
+			imp.Source = identifier.Content
+			imp.Wildcard = true
+		} else {
+			// import java.util.List;
+			name := lastSegment(identifier.Content)
+			imp.Source = strings.TrimSuffix(strings.TrimSuffix(identifier.Content, name), ".")
+			imp.AddName(name, "")
+		}
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
@@ -0,0 +1,106 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+/*
+Javadoc
+
+	/**
+	 * Read all the bytes.          <- summary (the first sentence)
+	 * More detail here.            <- description
+	 *
+	 * @param path the file path    <- params
+	 * @return bytes read           <- return
+	 * @throws IOException failed   <- throws
+	 *\/
+*/
+type Javadoc struct {
+	Summary     string            `json:"summary"`
+	Description string            `json:"description"`
+	Params      map[string]string `json:"params"`
+	Return      string            `json:"return"`
+	Throws      map[string]string `json:"throws"`
+	// Tags other block tags, eg: `@deprecated` -> `use xxx instead`
+	Tags map[string]string `json:"tags"`
+}
+
+// findJavadoc the doc comment right before this declaration
+func findJavadoc(unit *core.Unit) *Javadoc {
+	parent := unit.ParentUnit
+	if parent == nil {
+		return nil
+	}
+	var prev *core.Unit
+	for _, each := range parent.SubUnits {
+		if each == unit {
+			break
+		}
+		prev = each
+	}
+	if prev == nil || prev.Kind != KindJavaBlockComment || !strings.HasPrefix(prev.Content, "/**") {
+		return nil
+	}
+	return ParseJavadoc(prev.Content)
+}
+
+func ParseJavadoc(comment string) *Javadoc {
+	ret := &Javadoc{
+		Params: make(map[string]string),
+		Throws: make(map[string]string),
+		Tags:   make(map[string]string),
+	}
+	comment = strings.TrimPrefix(comment, "/**")
+	comment = strings.TrimSuffix(comment, "*/")
+
+	var description []string
+	var tag, tagContent string
+	flush := func() {
+		if tag == "" {
+			return
+		}
+		content := strings.TrimSpace(tagContent)
+		switch tag {
+		case "@param":
+			name, text, _ := strings.Cut(content, " ")
+			ret.Params[name] = strings.TrimSpace(text)
+		case "@return":
+			ret.Return = content
+		case "@throws", "@exception":
+			name, text, _ := strings.Cut(content, " ")
+			ret.Throws[name] = strings.TrimSpace(text)
+		default:
+			ret.Tags[strings.TrimPrefix(tag, "@")] = content
+		}
+	}
+
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimSpace(strings.TrimPrefix(line, "*"))
+		if strings.HasPrefix(line, "@") {
+			flush()
+			tag, tagContent, _ = strings.Cut(line, " ")
+			continue
+		}
+		if tag != "" {
+			// multi lines tag
+			tagContent += " " + line
+			continue
+		}
+		description = append(description, line)
+	}
+	flush()
+
+	ret.Description = strings.TrimSpace(strings.Join(description, "\n"))
+	// the first sentence, ends with a period followed by a blank
+	flat := strings.Join(strings.Fields(ret.Description), " ")
+	if i := strings.Index(flat, ". "); i != -1 {
+		ret.Summary = flat[:i+1]
+	} else {
+		ret.Summary = flat
+	}
+	return ret
+}
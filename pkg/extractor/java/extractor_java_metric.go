@@ -0,0 +1,44 @@
+package java
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindJavaIfStatement,
+			KindJavaForStatement,
+			KindJavaEnhancedForStatement,
+			KindJavaWhileStatement,
+			KindJavaDoStatement,
+			KindJavaSwitchLabel,
+			KindJavaCatchClause,
+			KindJavaTernaryExpression,
+		},
+		FlowKinds: []core.KindRepr{
+			KindJavaIfStatement,
+			KindJavaForStatement,
+			KindJavaEnhancedForStatement,
+			KindJavaWhileStatement,
+			KindJavaDoStatement,
+			KindJavaSwitchExpression,
+			KindJavaCatchClause,
+			KindJavaTernaryExpression,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindJavaLambdaExpression,
+		},
+		BinaryKinds: []core.KindRepr{
+			KindJavaBinaryExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindJavaReturnStatement,
+		},
+		CommentKinds: []core.KindRepr{
+			KindJavaLineComment,
+			KindJavaBlockComment,
+		},
+	}
+}
@@ -1,34 +1,94 @@
 package java
 
 import (
-	"strings"
-
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
 )
 
 func (extractor *Extractor) IsSymbol(unit *core.Unit) bool {
-	// todo: use grammar.js instead
-	if strings.HasSuffix(unit.Kind, "identifier") {
+	// scoped identifiers, eg: `a.b`, are made of identifiers
+	switch unit.Kind {
+	case KindJavaIdentifier, KindJavaTypeIdentifier:
 		return true
 	}
 	return false
 }
 
 func (extractor *Extractor) ExtractSymbols(units []*core.Unit) ([]*object.Symbol, error) {
-	ret := make([]*object.Symbol, 0)
-	for _, eachUnit := range units {
-		if !extractor.IsSymbol(eachUnit) {
-			continue
+	return object.ExtractSymbols(extractor, units, classifySymbol, extractor.scopeResolver())
+}
+
+// classifySymbol by its parent
+func classifySymbol(unit *core.Unit) (object.NodeType, object.SyntaxType) {
+	parent := unit.ParentUnit
+	if parent == nil {
+		return object.NodeTypeReference, object.SyntaxTypeVariable
+	}
+	first := core.FindFirstByKindInSubs(parent, unit.Kind) == unit
+
+	// `package a.b;` and `import a.b.C;`
+	if header := core.FindFirstByOneOfKindInParent(unit, KindJavaProgramDeclaration, KindJavaImportDeclaration); header != nil {
+		if header.Kind == KindJavaProgramDeclaration {
+			return object.NodeTypeDefinition, object.SyntaxTypeModule
+		}
+		return object.NodeTypeReference, object.SyntaxTypeModule
+	}
+
+	if unit.Kind == KindJavaTypeIdentifier {
+		// `new A()` and `new A<>()`
+		if parent.Kind == KindJavaGenericType && first {
+			parent = parent.ParentUnit
 		}
-		symbol := &object.Symbol{
-			Symbol:    eachUnit.Content,
-			Kind:      eachUnit.Kind,
-			Span:      eachUnit.Span,
-			FieldName: eachUnit.FieldName,
-			Unit:      eachUnit,
+		if parent != nil && parent.Kind == KindJavaObjectCreation {
+			return object.NodeTypeReference, object.SyntaxTypeCall
 		}
-		ret = append(ret, symbol)
+		return object.NodeTypeReference, object.SyntaxTypeType
+	}
+
+	switch parent.Kind {
+	case KindJavaMethodDeclaration, KindJavaConstructorDecl, KindJavaCompactConstructor:
+		return object.NodeTypeDefinition, object.SyntaxTypeMethod
+	case KindJavaClassDeclaration, KindJavaEnumDeclaration, KindJavaInterfaceDeclaration,
+		KindJavaRecordDeclaration, KindJavaAnnotationTypeDecl:
+		return object.NodeTypeDefinition, object.SyntaxTypeClass
+	case KindJavaFormalParameter, KindJavaSpreadParameter, KindJavaCatchFormalParameter,
+		KindJavaInferredParameters, KindJavaEnumConstant:
+		return object.NodeTypeDefinition, object.SyntaxTypeVariable
+	case KindJavaVariableDeclarator, KindJavaEnhancedForStatement, KindJavaLambdaExpression:
+		// `int a = 1`, `for (String s : items)` and `s -> s`
+		if first {
+			return object.NodeTypeDefinition, object.SyntaxTypeVariable
+		}
+	case KindJavaMethodInvocation:
+		// `a()` and `b.a()`
+		if next := core.FindNextSibling(unit); next != nil && next.Kind == KindJavaArgumentList {
+			return object.NodeTypeReference, object.SyntaxTypeCall
+		}
+	}
+	return object.NodeTypeReference, object.SyntaxTypeVariable
+}
+
+func (extractor *Extractor) scopeResolver() *object.ScopeResolver {
+	scopes := []core.KindRepr{
+		KindJavaBlock,
+		KindJavaConstructorBody,
+		KindJavaLambdaExpression,
+		KindJavaForStatement,
+		KindJavaEnhancedForStatement,
+		KindJavaCatchClause,
+	}
+	return &object.ScopeResolver{
+		IsScope: func(unit *core.Unit) bool {
+			return extractor.IsFunction(unit) || slices.Contains(scopes, unit.Kind)
+		},
+		IsMember: func(symbol *object.Symbol) bool {
+			// `a.b` and `a.b()`
+			parent := symbol.Unit.ParentUnit
+			if parent == nil || (parent.Kind != KindJavaFieldAccess && parent.Kind != KindJavaMethodInvocation) {
+				return false
+			}
+			return core.FindPrevSibling(symbol.Unit) != nil
+		},
 	}
-	return ret, nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -87,6 +88,9 @@ func TestJavaExtractor_ExtractFunctions(t *testing.T) {
 			assert.Equal(t, each.BodySpan.String(), "21:71,24:5")
 			assert.NotNil(t, each.Extras.(*FunctionExtras).ClassInfo.Annotations)
 			assert.Equal(t, each.Namespace, "com.williamfzc.sibyl.core.listener.java8")
+			assert.Equal(t, "void", each.Returns[0].Type)
+			assert.Equal(t, "ctx", each.Parameters[0].Name)
+			assert.Equal(t, "Java8Parser.MethodDeclarationWithoutMethodBodyContext", each.Parameters[0].Type)
 		}
 	}
 }
@@ -111,3 +115,668 @@ func TestExtractor_ExtractClasses(t *testing.T) {
 		core.Log.Debugf("class implements: %v", each.Extras.(*ClassExtras).Implements)
 	}
 }
+
+var javaNestedCode = `
+package com.x;
+
+public class Outer {
+    private final int a = 1, b;
+
+    public Outer(int a, String... names) {
+        this.a = a;
+    }
+
+    class Inner {
+        void run() {
+            new Thread(new Runnable() {
+                public void run() {
+                    go();
+                }
+            }).start();
+        }
+    }
+
+    public record Point(int x, @Nonnull int y) {
+        public Point(int x) {
+            this(x, 0);
+        }
+    }
+}
+
+public @interface Route {
+    String value() default "/";
+    int[] codes();
+    int LIMIT = 10;
+}
+`
+
+func TestExtractor_ExtractNested(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaNestedCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	var signatures []string
+	for _, each := range functions {
+		signatures = append(signatures, each.GetSignature())
+	}
+	assert.Equal(t, []string{
+		"com.x|com.x.Outer|Outer|int,String...|",
+		"com.x|com.x.Outer.Inner|run||void",
+		"com.x|com.x.Outer.Inner$1|run||void",
+		"com.x|com.x.Outer.Point|Point|int|",
+	}, signatures)
+	assert.Equal(t, "names", functions[0].Parameters[1].Name)
+	assert.Equal(t, "Outer.Inner$1", functions[2].Extras.(*FunctionExtras).ClassInfo.ClassName)
+
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	for _, each := range calls {
+		if each.Caller == "go" {
+			assert.Equal(t, functions[2].GetSignature(), each.Src)
+		}
+	}
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 4)
+
+	outer := classes[0].Extras.(*ClassExtras)
+	assert.Equal(t, ClassKindClass, outer.Kind)
+	assert.Len(t, outer.Fields, 2)
+	assert.Equal(t, "b", outer.Fields[1].Name)
+	assert.Equal(t, []string{"private", "final"}, outer.Fields[1].Modifiers)
+
+	assert.Equal(t, "com.x.Outer.Inner", classes[1].GetSignature())
+
+	point := classes[2]
+	assert.Equal(t, "Outer.Point", point.Name)
+	assert.Equal(t, ClassKindRecord, point.Extras.(*ClassExtras).Kind)
+	assert.Len(t, point.Extras.(*ClassExtras).Fields, 2)
+	assert.Equal(t, "y", point.Extras.(*ClassExtras).Fields[1].Name)
+
+	route := classes[3].Extras.(*ClassExtras)
+	assert.Equal(t, ClassKindAnnotation, route.Kind)
+	assert.Len(t, route.Fields, 3)
+	assert.Equal(t, "value", route.Fields[0].Name)
+	assert.Equal(t, `"/"`, route.Fields[0].Default)
+	assert.Equal(t, "int[]", route.Fields[1].Type)
+	assert.Equal(t, "LIMIT", route.Fields[2].Name)
+}
+
+var javaHierarchyCode = `
+package com.x;
+
+import java.util.List;
+import com.y.*;
+import com.z.Base;
+
+class A<T, K extends Comparable<K>> extends Base<T> implements List<T>, com.q.Runner {
+    class Inner extends Sibling implements Runnable {
+        public void run() {}
+    }
+
+    class Sibling {}
+}
+
+interface I extends J, com.y.K<String> {}
+`
+
+func TestExtractor_ExtractHierarchy(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaHierarchyCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 4)
+
+	a := classes[0].Extras.(*ClassExtras)
+	assert.Equal(t, "Base<T>", a.Extends)
+	assert.Equal(t, []string{"List<T>", "com.q.Runner"}, a.Implements)
+	assert.Equal(t, []string{"T", "K extends Comparable<K>"}, a.TypeParameters)
+	assert.Equal(t, []string{"com.z.Base", "java.util.List", "com.q.Runner"}, a.SuperTypes)
+
+	inner := classes[1].Extras.(*ClassExtras)
+	assert.Equal(t, "Sibling", inner.Extends)
+	assert.Equal(t, []string{"com.x.A.Sibling", "java.lang.Runnable"}, inner.SuperTypes)
+
+	i := classes[3].Extras.(*ClassExtras)
+	assert.Empty(t, i.Extends)
+	assert.Equal(t, []string{"J", "com.y.K<String>"}, i.ExtendedInterfaces)
+	assert.Equal(t, []string{"com.x.J", "com.y.K"}, i.SuperTypes)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, inner.SuperTypes, functions[0].Extras.(*FunctionExtras).ClassInfo.SuperTypes)
+}
+
+var javaImportCode = `
+package a;
+import java.util.List;
+import java.util.*;
+import static org.junit.Assert.assertEquals;
+import static org.junit.Assert.*;
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaImportCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 4)
+
+	assert.Equal(t, "java.util", imports[0].Source)
+	assert.Equal(t, []string{"List"}, imports[0].Names)
+	assert.False(t, imports[0].Wildcard)
+	assert.Equal(t, "java.util", imports[1].Source)
+	assert.True(t, imports[1].Wildcard)
+	assert.Empty(t, imports[1].Names)
+	assert.Equal(t, "org.junit.Assert", imports[2].Source)
+	assert.Equal(t, []string{"assertEquals"}, imports[2].Names)
+	assert.True(t, imports[2].Static)
+	assert.True(t, imports[3].Static)
+	assert.True(t, imports[3].Wildcard)
+}
+
+var javaCommentCode = `
+package com.a;
+
+/**
+ * Service of users.
+ */
+public class UserService {
+    // deprecated, use find instead
+    @Deprecated
+    public User get(String id) {
+        return null; // not found
+    }
+}
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, object.CommentDoc, comments[0].Kind)
+	assert.Equal(t, "Service of users.", comments[0].Text)
+	assert.Equal(t, "com.a.UserService", comments[0].Owner)
+	assert.Equal(t, "com.a|com.a.UserService|get|String|User", comments[1].Owner)
+	assert.Empty(t, comments[2].Owner)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "deprecated, use find instead", functions[0].Extras.(*FunctionExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "Service of users.", classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var javaVariableCode = `
+package a;
+
+public class A {
+    public static final int MAX = 10;
+    private final String name = "a", alias;
+    int count;
+
+    void f() {
+        var y = 2;
+    }
+}
+
+interface I {
+    int LIMIT = 5;
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 6)
+
+	assert.Equal(t, "MAX", variables[0].Name)
+	assert.Equal(t, "int", variables[0].Type)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeClass, variables[0].Scope)
+	assert.Equal(t, "a.A", variables[0].Receiver)
+
+	assert.Equal(t, "alias", variables[2].Name)
+	assert.Equal(t, "String", variables[2].Type)
+	assert.Empty(t, variables[2].Value)
+	assert.Equal(t, object.MutabilityFinal, variables[2].Mutability)
+
+	assert.Equal(t, object.MutabilityVar, variables[3].Mutability)
+
+	// local, type inferred
+	assert.Equal(t, "y", variables[4].Name)
+	assert.Empty(t, variables[4].Type)
+	assert.Equal(t, object.VariableScopeLocal, variables[4].Scope)
+
+	// fields of interfaces are constants
+	assert.Equal(t, object.MutabilityConst, variables[5].Mutability)
+	assert.Equal(t, "a.I", variables[5].Receiver)
+}
+
+var javaMetricCode = `
+class A {
+  int f(int a) {
+    if (a > 0 && a < 3) { return 1; } else if (a < 0) { return 2; } else {}
+    for (int i = 0; i < 3; i++) {}
+    for (int x : xs) {}
+    while (a > 0) {}
+    do {} while (a > 0);
+    switch (a) { case 1: break; default: }
+    try {} catch (Exception e) {} finally {}
+    int b = a > 0 ? 1 : 2;
+    Runnable r = () -> {};
+    return 0;
+  }
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 11, metrics.Cyclomatic)
+	assert.Equal(t, 10, metrics.Cognitive)
+	assert.Equal(t, 12, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
+
+var javaSymbolCode = `
+package com.a;
+import java.util.List;
+class A {
+  private int count = 0;
+  int run(List<String> items) {
+    int x = items.size();
+    helper(x);
+    return new A().count;
+  }
+}
+`
+
+func TestExtractor_ExtractSymbolKinds(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaSymbolCode))
+	if err != nil {
+		panic(err)
+	}
+
+	extractor := &Extractor{}
+	symbols, err := extractor.ExtractSymbols(units)
+	assert.Nil(t, err)
+	find := func(name string, nodeType object.NodeType) *object.Symbol {
+		for _, each := range symbols {
+			if each.Symbol == name && each.NodeType == nodeType {
+				return each
+			}
+		}
+		return nil
+	}
+
+	assert.Equal(t, object.SyntaxTypeModule, find("com", object.NodeTypeDefinition).SyntaxType)
+	// `com.a` is made of identifiers, not a symbol itself
+	assert.Nil(t, find("com.a", object.NodeTypeDefinition))
+	assert.Equal(t, object.SyntaxTypeModule, find("List", object.NodeTypeReference).SyntaxType)
+
+	a := find("A", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeClass, a.SyntaxType)
+	assert.Empty(t, a.Scope)
+	count := find("count", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeVariable, count.SyntaxType)
+	assert.Equal(t, "com.a.A", count.Scope)
+
+	run := find("run", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeMethod, run.SyntaxType)
+	assert.Equal(t, "com.a.A", run.Scope)
+
+	x := find("x", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeVariable, x.SyntaxType)
+	assert.Equal(t, "com.a|com.a.A|run|List<String>|int", x.Scope)
+	assert.Equal(t, object.SyntaxTypeVariable, find("items", object.NodeTypeDefinition).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeType, find("String", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeCall, find("size", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeCall, find("helper", object.NodeTypeReference).SyntaxType)
+	// constructor call
+	assert.Equal(t, object.SyntaxTypeCall, find("A", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeVariable, find("count", object.NodeTypeReference).SyntaxType)
+}
+
+var javaResolveCode = `
+package com.a;
+import java.util.List;
+class A {
+  void handle() {}
+  void run(int handle) {
+    List<String> items = null;
+    for (String s : items) {
+      print(s, handle);
+    }
+    this.handle();
+    print(s);
+  }
+}
+`
+
+func TestExtractor_ResolveSymbols(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaResolveCode))
+	if err != nil {
+		panic(err)
+	}
+
+	extractor := &Extractor{}
+	symbols, err := extractor.ExtractSymbols(units)
+	assert.Nil(t, err)
+	resolutions := make(map[string][]object.Resolution)
+	for _, each := range symbols {
+		if each.NodeType == object.NodeTypeReference && each.Scope != "" {
+			resolutions[each.Symbol] = append(resolutions[each.Symbol], each.Resolution)
+		}
+	}
+
+	assert.Equal(t, []object.Resolution{object.ResolutionImported}, resolutions["List"])
+	assert.Equal(t, []object.Resolution{object.ResolutionLocal}, resolutions["items"])
+	// parameter, and the member call
+	assert.Equal(t, []object.Resolution{object.ResolutionLocal, object.ResolutionUnresolved}, resolutions["handle"])
+	// out of the loop
+	assert.Equal(t, []object.Resolution{object.ResolutionLocal, object.ResolutionUnresolved}, resolutions["s"])
+	assert.Equal(t, []object.Resolution{object.ResolutionUnresolved, object.ResolutionUnresolved}, resolutions["print"])
+}
+
+var javaTestCode = `
+package com.a;
+
+class ATest {
+  @Test
+  void a() {}
+
+  @ParameterizedTest
+  @ValueSource(ints = {1})
+  void b(int x) {}
+
+  @org.junit.jupiter.api.Test
+  public void c() {}
+
+  void helper() {}
+}
+`
+
+func TestExtractor_ExtractTests(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaTestCode))
+	if err != nil {
+		panic(err)
+	}
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, functions, 4)
+	for _, each := range functions[:3] {
+		assert.Equal(t, object.TestFrameworkJUnit, each.GetTestFramework())
+	}
+	assert.False(t, functions[3].IsTest())
+}
+
+var javaEntryCode = `
+package com.a;
+
+@RestController
+@RequestMapping("/api")
+public class UserController {
+    @GetMapping("/users/{id}")
+    public String get(String id) { return ""; }
+
+    @RequestMapping(value = "/users", method = {RequestMethod.POST, RequestMethod.PUT})
+    public void save() {}
+
+    @Scheduled(cron = "0 0 * * * *")
+    public void clean() {}
+
+    public void helper() {}
+}
+
+@Path("/items")
+class ItemResource {
+    @GET
+    @Path("{id}")
+    public String get(String id) { return ""; }
+
+    @Path("sub")
+    public ItemResource sub() { return this; }
+}
+`
+
+func TestExtractor_DetectEntryPoints(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaEntryCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	entries, err := extractor.DetectEntryPoints(units)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 5)
+
+	var routes []string
+	for _, each := range entries {
+		routes = append(routes, each.Framework+" "+each.Method+" "+each.Route+" "+each.Handler)
+	}
+	assert.Equal(t, []string{
+		"spring GET /api/users/{id} get",
+		"spring POST /api/users save",
+		"spring PUT /api/users save",
+		"spring  0 0 * * * * clean",
+		"jax-rs GET /items/{id} get",
+	}, routes)
+	assert.Equal(t, object.EntryKindSchedule, entries[3].Kind)
+}
+
+var javaCallCode = `
+package com.a;
+
+import com.b.Util;
+
+class C {
+  private Repo repo;
+
+  void handle(Request req, int n) {
+    this.helper();
+    req.validate();
+    Util.clean(n);
+    new D().run();
+    List<String> items = new ArrayList<>();
+    items.add("a");
+    var d = new D();
+    d.stop();
+    repo.save(req);
+  }
+}
+`
+
+func TestExtractor_ExtractCallReceiverType(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaCallCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	receiverTypes := make(map[string]string)
+	arguments := make(map[string][]string)
+	for _, each := range calls {
+		receiverTypes[each.Caller] = each.ReceiverType
+		arguments[each.Caller] = each.Arguments
+	}
+	assert.Equal(t, []string{"n"}, arguments["Util.clean"])
+	assert.Equal(t, []string{"req"}, arguments["repo.save"])
+	assert.Empty(t, arguments["d.stop"])
+	assert.Equal(t, map[string]string{
+		"this.helper":  "",
+		"req.validate": "Request",
+		"Util.clean":   "",
+		"new D().run":  "D",
+		"items.add":    "List",
+		"d.stop":       "",
+		"repo.save":    "Repo",
+	}, receiverTypes)
+}
+
+var javaModifierCode = `
+package com.a;
+
+public abstract class Repo<E> {
+    /**
+     * Find all the entities.
+     * Results are sorted by id.
+     *
+     * @param type entity class,
+     *             never null
+     * @param limit max size
+     * @return sorted entities
+     * @throws IOException if storage is broken
+     * @throws IllegalStateException if closed
+     * @since 1.2
+     */
+    public static synchronized <T extends E, K> List<T> findAll(Class<T> type, int limit) throws IOException, IllegalStateException {
+        return null;
+    }
+
+    // not a javadoc
+    protected abstract void close();
+}
+
+interface Named {
+    default String name() {
+        return "";
+    }
+}
+`
+
+func TestExtractor_ExtractModifiers(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(javaModifierCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, functions, 3)
+
+	findAll := functions[0].Extras.(*FunctionExtras)
+	assert.Equal(t, []string{"public", "static", "synchronized"}, findAll.Modifiers)
+	assert.Equal(t, []string{"T extends E", "K"}, findAll.TypeParameters)
+	assert.Equal(t, []string{"IOException", "IllegalStateException"}, findAll.Throws)
+	assert.NotNil(t, findAll.Javadoc)
+	assert.Equal(t, "Find all the entities.", findAll.Javadoc.Summary)
+	assert.Equal(t, map[string]string{"type": "entity class, never null", "limit": "max size"}, findAll.Javadoc.Params)
+	assert.Equal(t, "sorted entities", findAll.Javadoc.Return)
+	assert.Equal(t, "if storage is broken", findAll.Javadoc.Throws["IOException"])
+	assert.Equal(t, "if closed", findAll.Javadoc.Throws["IllegalStateException"])
+	assert.Equal(t, map[string]string{"since": "1.2"}, findAll.Javadoc.Tags)
+
+	closeFunc := functions[1].Extras.(*FunctionExtras)
+	assert.Equal(t, []string{"protected", "abstract"}, closeFunc.Modifiers)
+	assert.Empty(t, closeFunc.TypeParameters)
+	assert.Empty(t, closeFunc.Throws)
+	// line comments are never javadoc
+	assert.Nil(t, closeFunc.Javadoc)
+	assert.Equal(t, "not a javadoc", closeFunc.Doc)
+
+	assert.Equal(t, []string{"default"}, functions[2].Extras.(*FunctionExtras).Modifiers)
+	assert.Nil(t, functions[2].Extras.(*FunctionExtras).Javadoc)
+}
+
+func TestSplitModifiers(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJava)
+	units, err := parser.Parse([]byte(`class A { @Override @SuppressWarnings("all") public final void a() {} }`))
+	assert.Nil(t, err)
+
+	var modifiers *core.Unit
+	for _, each := range units {
+		if each.Kind == KindJavaMethodDeclaration {
+			modifiers = core.FindFirstByKindInSubs(each, KindJavaModifiers)
+		}
+	}
+	annotations, keywords := splitModifiers(modifiers)
+	assert.Equal(t, []string{"@Override", `@SuppressWarnings("all")`}, annotations)
+	assert.Equal(t, []string{"public", "final"}, keywords)
+
+	annotations, keywords = splitModifiers(nil)
+	assert.Nil(t, annotations)
+	assert.Nil(t, keywords)
+}
+
+func TestParseJavadoc(t *testing.T) {
+	t.Parallel()
+	doc := ParseJavadoc(`/**
+	 * Parse a <b>raw</b> value. Never throws
+	 * on empty input.
+	 *
+	 * @param raw the raw value
+	 * @return parsed value,
+	 *         or null
+	 * @exception ParseException if invalid
+	 * @deprecated use {@link #parse2} instead
+	 * @author someone
+	 */`)
+	assert.Equal(t, "Parse a <b>raw</b> value.", doc.Summary)
+	assert.Equal(t, "Parse a <b>raw</b> value. Never throws\non empty input.", doc.Description)
+	assert.Equal(t, map[string]string{"raw": "the raw value"}, doc.Params)
+	assert.Equal(t, "parsed value, or null", doc.Return)
+	assert.Equal(t, map[string]string{"ParseException": "if invalid"}, doc.Throws)
+	assert.Equal(t, map[string]string{
+		"deprecated": "use {@link #parse2} instead",
+		"author":     "someone",
+	}, doc.Tags)
+
+	// summary only
+	doc = ParseJavadoc("/** Hello world */")
+	assert.Equal(t, "Hello world", doc.Summary)
+	assert.Empty(t, doc.Params)
+	assert.Empty(t, doc.Return)
+	assert.Empty(t, doc.Throws)
+	assert.Empty(t, doc.Tags)
+}
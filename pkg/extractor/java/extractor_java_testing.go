@@ -0,0 +1,19 @@
+package java
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extras *FunctionExtras) GetTestFramework() object.TestFramework {
+	return extras.TestFramework
+}
+
+// testFramework junit (and testng, which shares `@Test`) methods
+func testFramework(annotations []string) object.TestFramework {
+	for _, each := range annotations {
+		if object.IsJUnitAnnotation(each) {
+			return object.TestFrameworkJUnit
+		}
+	}
+	return ""
+}
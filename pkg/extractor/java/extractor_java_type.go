@@ -0,0 +1,180 @@
+package java
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+// javaLangTypes the most common types which can be used without imports
+var javaLangTypes = map[string]struct{}{
+	"Object":                   {},
+	"String":                   {},
+	"Enum":                     {},
+	"Record":                   {},
+	"Thread":                   {},
+	"Runnable":                 {},
+	"Comparable":               {},
+	"Iterable":                 {},
+	"AutoCloseable":            {},
+	"Cloneable":                {},
+	"CharSequence":             {},
+	"Throwable":                {},
+	"Exception":                {},
+	"Error":                    {},
+	"RuntimeException":         {},
+	"IllegalArgumentException": {},
+	"IllegalStateException":    {},
+}
+
+// typeResolver resolve type names to full names in a file
+type typeResolver struct {
+	pkg string
+	// simple name -> full name
+	imports map[string]string
+	// simple name -> full name, classes declared in this file
+	locals map[string]string
+}
+
+func newTypeResolver(unit *core.Unit) *typeResolver {
+	ret := &typeResolver{
+		pkg:     findPackage(unit),
+		imports: make(map[string]string),
+		locals:  make(map[string]string),
+	}
+	program := core.FindFirstByKindInParent(unit, KindJavaProgram)
+	if program == nil {
+		return ret
+	}
+	for _, each := range program.SubUnits {
+		if each.Kind != KindJavaImportDeclaration {
+			continue
+		}
+		// static and wildcard imports can not be used for resolving types
+		if strings.HasPrefix(each.Content, "import static") || core.FindFirstByKindInSubs(each, KindJavaAsterisk) != nil {
+			continue
+		}
+		identifier := core.FindFirstByKindInSubs(each, KindJavaScopeIdentifier)
+		if identifier == nil {
+			continue
+		}
+		ret.imports[lastSegment(identifier.Content)] = identifier.Content
+	}
+	for _, each := range core.NewQuery(program).Dfs().MatchKind(KindJavaClassDeclaration).
+		MatchKind(KindJavaEnumDeclaration).MatchKind(KindJavaInterfaceDeclaration).
+		MatchKind(KindJavaRecordDeclaration).MatchKind(KindJavaAnnotationTypeDecl).All() {
+		name := findName(each)
+		if name == nil {
+			continue
+		}
+		if _, ok := ret.locals[name.Content]; ok {
+			continue
+		}
+		ret.locals[name.Content] = qualify(ret.pkg, classPath(each))
+	}
+	return ret
+}
+
+// Resolve full name without type arguments, eg: `Base<T>` -> `com.z.Base`
+func (r *typeResolver) Resolve(typeName string) string {
+	typeName, _, _ = strings.Cut(typeName, "<")
+	typeName = strings.TrimSpace(typeName)
+	first, rest, qualified := strings.Cut(typeName, ".")
+	if qualified {
+		if first != "" && strings.ToLower(first[:1]) == first[:1] {
+			// already qualified by package
+			return typeName
+		}
+		// nested: Outer.Inner
+		return r.Resolve(first) + "." + rest
+	}
+	if fullName, ok := r.locals[typeName]; ok {
+		return fullName
+	}
+	if fullName, ok := r.imports[typeName]; ok {
+		return fullName
+	}
+	if _, ok := javaLangTypes[typeName]; ok {
+		return "java.lang." + typeName
+	}
+	// same package
+	return qualify(r.pkg, typeName)
+}
+
+// superTypes direct super types written in this class declaration
+// returns: extends, implements, extended interfaces (of interfaces)
+func superTypes(unit *core.Unit) (string, []string, []string) {
+	var extends string
+	var implements []string
+	var extendedInterfaces []string
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindJavaSuperClass:
+			// extends Base<T>
+			if len(each.SubUnits) != 0 {
+				extends = each.SubUnits[0].Content
+			}
+		case KindJavaSuperInterface:
+			implements = append(implements, typeListContents(each)...)
+		case KindJavaExtendsInterfaces:
+			extendedInterfaces = append(extendedInterfaces, typeListContents(each)...)
+		}
+	}
+	return extends, implements, extendedInterfaces
+}
+
+func typeListContents(unit *core.Unit) []string {
+	var ret []string
+	typeList := core.FindFirstByKindInSubs(unit, KindJavaTypeList)
+	if typeList == nil {
+		return ret
+	}
+	for _, each := range typeList.SubUnits {
+		ret = append(ret, each.Content)
+	}
+	return ret
+}
+
+// resolveSuperTypes full names of all the direct super types
+func resolveSuperTypes(unit *core.Unit) []string {
+	if unit == nil {
+		return nil
+	}
+	if anonymousClassBody(unit) != nil {
+		// new Runnable() {...}
+		for _, each := range unit.SubUnits {
+			switch each.Kind {
+			case KindJavaTypeIdentifier, KindJavaGenericType, KindJavaScopedTypeIdentifier:
+				return []string{newTypeResolver(unit).Resolve(each.Content)}
+			}
+		}
+		return nil
+	}
+	if !isNamedClass(unit) {
+		return nil
+	}
+	extends, implements, extendedInterfaces := superTypes(unit)
+	resolver := newTypeResolver(unit)
+	var ret []string
+	if extends != "" {
+		ret = append(ret, resolver.Resolve(extends))
+	}
+	for _, each := range append(implements, extendedInterfaces...) {
+		ret = append(ret, resolver.Resolve(each))
+	}
+	return ret
+}
+
+func qualify(pkg string, name string) string {
+	if pkg == "" {
+		return name
+	}
+	return pkg + "." + name
+}
+
+func lastSegment(name string) string {
+	if i := strings.LastIndex(name, "."); i != -1 {
+		return name[i+1:]
+	}
+	return name
+}
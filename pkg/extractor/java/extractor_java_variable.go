@@ -0,0 +1,95 @@
+package java
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	return unit.Kind == KindJavaFieldDeclaration ||
+		unit.Kind == KindJavaConstantDeclaration ||
+		unit.Kind == KindJavaLocalVariableDecl
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		variables, err := extractor.extractVariable(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, variables...)
+	}
+	return ret, nil
+}
+
+// extractVariable [modifiers], type, declarators...
+func (extractor *Extractor) extractVariable(unit *core.Unit) ([]*object.Variable, error) {
+	var modifiers []string
+	var typeDecl *core.Unit
+	var declarators []*core.Unit
+	for _, each := range unit.SubUnits {
+		switch {
+		case each.Kind == KindJavaModifiers:
+			_, modifiers = splitModifiers(each)
+		case each.Kind == KindJavaVariableDeclarator:
+			declarators = append(declarators, each)
+		case typeDecl == nil:
+			typeDecl = each
+		}
+	}
+	if typeDecl == nil {
+		return nil, errors.New("no type found in variable decl: " + unit.Content)
+	}
+
+	pkgName := findPackage(unit)
+	mutability := object.MutabilityVar
+	switch {
+	// fields in interfaces are implicitly static final
+	case unit.Kind == KindJavaConstantDeclaration,
+		slices.Contains(modifiers, "static") && slices.Contains(modifiers, "final"):
+		mutability = object.MutabilityConst
+	case slices.Contains(modifiers, "final"):
+		mutability = object.MutabilityFinal
+	}
+
+	ret := make([]*object.Variable, 0, len(declarators))
+	for _, eachDeclarator := range declarators {
+		name := findName(eachDeclarator)
+		if name == nil {
+			return nil, errors.New("no name found in variable declarator: " + eachDeclarator.Content)
+		}
+		v := object.NewVariable()
+		v.Name = name.Content
+		// `var` in java 10
+		if typeDecl.Content != "var" {
+			v.Type = typeDecl.Content
+		}
+		// declarator: identifier, [dimensions], [value]
+		for _, each := range eachDeclarator.SubUnits {
+			if each != name && each.Kind != KindJavaDimensions {
+				v.Value = each.Content
+			}
+		}
+		v.Mutability = mutability
+		v.Namespace = pkgName
+		if unit.Kind == KindJavaLocalVariableDecl {
+			v.Scope = object.VariableScopeLocal
+		} else {
+			v.Scope = object.VariableScopeClass
+			if clazzDecl := findOwnerClass(unit); clazzDecl != nil {
+				v.Receiver = pkgName + "." + classPath(clazzDecl)
+			}
+		}
+		v.Span = unit.Span
+		v.Lang = extractor.GetLang()
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
@@ -6,14 +6,47 @@ import (
 
 // https://github.com/tree-sitter/tree-sitter-javascript/blob/master/src/node-types.json
 const (
-	KindJavaScriptClassDeclaration    core.KindRepr = "class_declaration"
-	KindJavaScriptMethodDefinition    core.KindRepr = "method_definition"
-	KindJavaScriptFunctionDeclaration core.KindRepr = "function_declaration"
-	KindJavaScriptIdentifier          core.KindRepr = "identifier"
-	KindJavaScriptFormalParameters    core.KindRepr = "formal_parameters"
-	KindJavaScriptStatementBlock      core.KindRepr = "statement_block"
-	FieldJavaScriptName               core.KindRepr = "name"
-	FieldJavaScriptParameters         core.KindRepr = "parameters"
+	KindJavaScriptClassDeclaration            core.KindRepr = "class_declaration"
+	KindJavaScriptMethodDefinition            core.KindRepr = "method_definition"
+	KindJavaScriptFunctionDeclaration         core.KindRepr = "function_declaration"
+	KindJavaScriptIdentifier                  core.KindRepr = "identifier"
+	KindJavaScriptFormalParameters            core.KindRepr = "formal_parameters"
+	KindJavaScriptStatementBlock              core.KindRepr = "statement_block"
+	KindJavaScriptImportStatement             core.KindRepr = "import_statement"
+	KindJavaScriptImportClause                core.KindRepr = "import_clause"
+	KindJavaScriptNamespaceImport             core.KindRepr = "namespace_import"
+	KindJavaScriptNamedImports                core.KindRepr = "named_imports"
+	KindJavaScriptImportSpecifier             core.KindRepr = "import_specifier"
+	KindJavaScriptString                      core.KindRepr = "string"
+	KindJavaScriptComment                     core.KindRepr = "comment"
+	KindJavaScriptVariableDeclarator          core.KindRepr = "variable_declarator"
+	KindJavaScriptFieldDefinition             core.KindRepr = "field_definition"
+	KindJavaScriptPropertyIdentifier          core.KindRepr = "property_identifier"
+	KindJavaScriptShorthandPropertyIdentifier core.KindRepr = "shorthand_property_identifier"
+	KindJavaScriptPrivatePropertyIdentifier   core.KindRepr = "private_property_identifier"
+	KindJavaScriptClassBody                   core.KindRepr = "class_body"
+	KindJavaScriptForStatement                core.KindRepr = "for_statement"
+	KindJavaScriptForInStatement              core.KindRepr = "for_in_statement"
+	KindJavaScriptIfStatement                 core.KindRepr = "if_statement"
+	KindJavaScriptWhileStatement              core.KindRepr = "while_statement"
+	KindJavaScriptDoStatement                 core.KindRepr = "do_statement"
+	KindJavaScriptSwitchCase                  core.KindRepr = "switch_case"
+	KindJavaScriptCatchClause                 core.KindRepr = "catch_clause"
+	KindJavaScriptTernaryExpression           core.KindRepr = "ternary_expression"
+	KindJavaScriptSwitchStatement             core.KindRepr = "switch_statement"
+	KindJavaScriptArrowFunction               core.KindRepr = "arrow_function"
+	KindJavaScriptFunction                    core.KindRepr = "function_expression"
+	KindJavaScriptBinaryExpression            core.KindRepr = "binary_expression"
+	KindJavaScriptReturnStatement             core.KindRepr = "return_statement"
+	KindJavaScriptGeneratorFunctionDecl       core.KindRepr = "generator_function_declaration"
+	KindJavaScriptCallExpression              core.KindRepr = "call_expression"
+	KindJavaScriptMemberExpression            core.KindRepr = "member_expression"
+	KindJavaScriptNewExpression               core.KindRepr = "new_expression"
+	KindJavaScriptArguments                   core.KindRepr = "arguments"
+	KindJavaScriptTemplateString              core.KindRepr = "template_string"
+	KindJavaScriptProgram                     core.KindRepr = "program"
+	FieldJavaScriptName                       core.KindRepr = "name"
+	FieldJavaScriptParameters                 core.KindRepr = "parameters"
 )
 
 type Extractor struct {
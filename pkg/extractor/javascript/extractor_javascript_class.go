@@ -5,6 +5,11 @@ import (
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 )
 
+type ClassExtras struct {
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
 func (extractor *Extractor) IsClass(unit *core.Unit) bool {
 	if unit.Kind == KindJavaScriptClassDeclaration {
 		return true
@@ -39,6 +44,9 @@ func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error)
 	} else {
 		clazz.Name = nameNode.Content
 	}
+	clazz.Extras = &ClassExtras{
+		Doc: object.NewCommentRule(extractor).FindDoc(unit),
+	}
 
 	return clazz, nil
 }
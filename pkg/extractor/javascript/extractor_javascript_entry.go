@@ -0,0 +1,150 @@
+package javascript
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+// express routers, `all` means all the http methods
+var routeMethods = []string{"get", "post", "put", "delete", "patch", "head", "options", "all"}
+
+var requireExpressRegex = regexp.MustCompile(`require\(\s*['"]express['"]\s*\)`)
+
+/*
+DetectEntryPoints express handlers, only in files which import express
+
+	app.get("/users", auth, listUsers)     -> GET /users, listUsers
+	router.post("/users", (req, res) => {}) -> POST /users, the inline handler
+*/
+func (extractor *Extractor) DetectEntryPoints(units []*core.Unit) ([]*object.EntryPoint, error) {
+	if len(units) == 0 || !usesExpress(units[0]) {
+		return nil, nil
+	}
+	var ret []*object.EntryPoint
+	for _, eachUnit := range units {
+		method, route, handler := splitRouteCall(eachUnit)
+		if handler == nil {
+			continue
+		}
+		entry := &object.EntryPoint{
+			Kind:      object.EntryKindHttp,
+			Framework: object.EntryFrameworkExpress,
+			Method:    method,
+			Route:     route,
+			Span:      eachUnit.Span,
+		}
+		if isCallback(handler) {
+			entry.Unit = handler
+		} else {
+			entry.Handler = handler.Content
+		}
+		ret = append(ret, entry)
+	}
+	return ret, nil
+}
+
+// usesExpress `import express from "express"` or `require("express")` at top level
+func usesExpress(root *core.Unit) bool {
+	if root == nil || root.Kind != KindJavaScriptProgram {
+		return false
+	}
+	for _, each := range root.SubUnits {
+		if each.Kind == KindJavaScriptImportStatement {
+			source := core.FindFirstByKindInSubs(each, KindJavaScriptString)
+			if source != nil && strings.Trim(source.Content, "'\"`") == "express" {
+				return true
+			}
+			continue
+		}
+		if requireExpressRegex.MatchString(each.Content) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitRouteCall `app.get("/users", auth, handler)` -> GET, /users, handler
+func splitRouteCall(unit *core.Unit) (string, string, *core.Unit) {
+	if unit.Kind != KindJavaScriptCallExpression || len(unit.SubUnits) != 2 {
+		return "", "", nil
+	}
+	callee, args := unit.SubUnits[0], unit.SubUnits[1]
+	if callee.Kind != KindJavaScriptMemberExpression || args.Kind != KindJavaScriptArguments {
+		return "", "", nil
+	}
+	property := core.FindFirstByKindInSubs(callee, KindJavaScriptPropertyIdentifier)
+	if property == nil || !slices.Contains(routeMethods, property.Content) {
+		return "", "", nil
+	}
+
+	var argUnits []*core.Unit
+	for _, each := range args.SubUnits {
+		if each.Kind != KindJavaScriptComment {
+			argUnits = append(argUnits, each)
+		}
+	}
+	if len(argUnits) < 2 || argUnits[0].Kind != KindJavaScriptString {
+		return "", "", nil
+	}
+	route := strings.Trim(argUnits[0].Content, "'\"`")
+	if !strings.HasPrefix(route, "/") {
+		return "", "", nil
+	}
+
+	method := strings.ToUpper(property.Content)
+	if property.Content == "all" {
+		method = ""
+	}
+	// middlewares come first
+	return method, route, argUnits[len(argUnits)-1]
+}
+
+func isCallback(unit *core.Unit) bool {
+	return unit.Kind == KindJavaScriptArrowFunction || unit.Kind == KindJavaScriptFunction
+}
+
+// isRouteHandler inline handlers of express routes, eg: `app.get("/", (req, res) => {})`
+func isRouteHandler(unit *core.Unit) bool {
+	return findRouteCall(unit) != nil
+}
+
+// findRouteCall the `app.get(...)` which this handler passed to, nil if none
+func findRouteCall(unit *core.Unit) *core.Unit {
+	if !isCallback(unit) {
+		return nil
+	}
+	args := unit.ParentUnit
+	if args == nil || args.Kind != KindJavaScriptArguments {
+		return nil
+	}
+	call := args.ParentUnit
+	if call == nil {
+		return nil
+	}
+	if _, _, handler := splitRouteCall(call); handler != unit {
+		return nil
+	}
+	if !usesExpress(core.FindFirstByKindInParent(call, KindJavaScriptProgram)) {
+		return nil
+	}
+	return call
+}
+
+// extractFromRouteHandler named with its method and route, eg: `GET /users`
+func (extractor *Extractor) extractFromRouteHandler(unit *core.Unit, function *object.Function) error {
+	call := findRouteCall(unit)
+	if call == nil {
+		return errors.New("not a route handler: " + unit.Content)
+	}
+	method, route, _ := splitRouteCall(call)
+	function.Name = strings.TrimSpace(method + " " + route)
+	function.Receiver = core.FindFirstByKindInSubs(call, KindJavaScriptMemberExpression).SubUnits[0].Content
+	function.DefLine = int(call.Span.Start.Row + 1)
+	fillCallback(unit, function)
+	return nil
+}
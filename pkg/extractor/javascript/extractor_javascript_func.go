@@ -8,12 +8,23 @@ import (
 	"golang.org/x/exp/slices"
 )
 
+type FunctionExtras struct {
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+	// TestFramework empty if this function is not a test
+	TestFramework object.TestFramework `json:"testFramework"`
+}
+
 func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
 	allowed := []core.KindRepr{
 		KindJavaScriptFunctionDeclaration,
 		KindJavaScriptMethodDefinition,
 	}
-	return slices.Contains(allowed, unit.Kind)
+	if slices.Contains(allowed, unit.Kind) {
+		return true
+	}
+	// anonymous functions are only extracted as test blocks and route handlers
+	return isTestBlock(unit) || isRouteHandler(unit)
 }
 
 func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Function, error) {
@@ -48,11 +59,25 @@ func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function,
 		err = extractor.extractFromFunc(unit, funcUnit)
 	case KindJavaScriptMethodDefinition:
 		err = extractor.extractFromMethod(unit, funcUnit)
+	default:
+		if isTestBlock(unit) {
+			err = extractor.extractFromTestBlock(unit, funcUnit)
+		} else {
+			err = extractor.extractFromRouteHandler(unit, funcUnit)
+		}
 	}
 	if err != nil {
 		return nil, err
 	}
+	extras := &FunctionExtras{
+		Doc: object.NewCommentRule(extractor).FindDoc(unit),
+	}
+	if isTestBlock(unit) {
+		extras.TestFramework = object.TestFrameworkJest
+	}
+	funcUnit.Extras = extras
 
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
 	return funcUnit, nil
 }
 
@@ -0,0 +1,61 @@
+package javascript
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindJavaScriptImportStatement
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) {
+			continue
+		}
+		source := core.FindFirstByKindInSubs(eachUnit, KindJavaScriptString)
+		if source == nil {
+			continue
+		}
+		imp := object.NewImport()
+		imp.Span = eachUnit.Span
+		imp.Lang = extractor.GetLang()
+		imp.Source = strings.Trim(source.Content, "'\"`")
+
+		// import './side'; has no clause
+		clause := core.FindFirstByKindInSubs(eachUnit, KindJavaScriptImportClause)
+		if clause != nil {
+			for _, each := range clause.SubUnits {
+				switch each.Kind {
+				case KindJavaScriptIdentifier:
+					// import React from 'react';
+					imp.AddName("default", each.Content)
+				case KindJavaScriptNamespaceImport:
+					// import * as ns from 'x';
+					imp.Wildcard = true
+					if len(each.SubUnits) != 0 {
+						imp.Alias = each.SubUnits[0].Content
+					}
+				case KindJavaScriptNamedImports:
+					// { a as b, c }
+					for _, eachSpecifier := range core.FindAllByKindInSubs(each, KindJavaScriptImportSpecifier) {
+						if len(eachSpecifier.SubUnits) == 0 {
+							continue
+						}
+						var alias string
+						if len(eachSpecifier.SubUnits) > 1 {
+							alias = eachSpecifier.SubUnits[1].Content
+						}
+						imp.AddName(eachSpecifier.SubUnits[0].Content, alias)
+					}
+				}
+			}
+		}
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
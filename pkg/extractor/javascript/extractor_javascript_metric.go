@@ -0,0 +1,52 @@
+package javascript
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindJavaScriptIfStatement,
+			KindJavaScriptForStatement,
+			KindJavaScriptForInStatement,
+			KindJavaScriptWhileStatement,
+			KindJavaScriptDoStatement,
+			KindJavaScriptSwitchCase,
+			KindJavaScriptCatchClause,
+			KindJavaScriptTernaryExpression,
+		},
+		FlowKinds: []core.KindRepr{
+			KindJavaScriptIfStatement,
+			KindJavaScriptForStatement,
+			KindJavaScriptForInStatement,
+			KindJavaScriptWhileStatement,
+			KindJavaScriptDoStatement,
+			KindJavaScriptSwitchStatement,
+			KindJavaScriptCatchClause,
+			KindJavaScriptTernaryExpression,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindJavaScriptArrowFunction,
+			KindJavaScriptFunction,
+			KindJavaScriptFunctionDeclaration,
+		},
+		BinaryKinds: []core.KindRepr{
+			KindJavaScriptBinaryExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindJavaScriptReturnStatement,
+		},
+		CommentKinds: []core.KindRepr{
+			KindJavaScriptComment,
+		},
+		ParamCounter: func(unit *core.Unit) int {
+			params := core.FindFirstByKindInSubs(unit, KindJavaScriptFormalParameters)
+			if params == nil {
+				return 0
+			}
+			return len(params.SubUnits) - len(core.FindAllByKindInSubs(params, KindJavaScriptComment))
+		},
+	}
+}
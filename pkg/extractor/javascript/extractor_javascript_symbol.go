@@ -1,34 +1,92 @@
 package javascript
 
 import (
-	"strings"
-
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
 )
 
 func (extractor *Extractor) IsSymbol(unit *core.Unit) bool {
-	// todo: use grammar.js instead
-	if strings.HasSuffix(unit.Kind, "identifier") {
+	// labels are not symbols
+	switch unit.Kind {
+	case KindJavaScriptIdentifier, KindJavaScriptPropertyIdentifier, KindJavaScriptShorthandPropertyIdentifier, KindJavaScriptPrivatePropertyIdentifier:
 		return true
 	}
 	return false
 }
 
 func (extractor *Extractor) ExtractSymbols(units []*core.Unit) ([]*object.Symbol, error) {
-	ret := make([]*object.Symbol, 0)
-	for _, eachUnit := range units {
-		if !extractor.IsSymbol(eachUnit) {
-			continue
+	return object.ExtractSymbols(extractor, units, classifySymbol, extractor.scopeResolver())
+}
+
+// classifySymbol by its parent
+func classifySymbol(unit *core.Unit) (object.NodeType, object.SyntaxType) {
+	parent := unit.ParentUnit
+	if parent == nil {
+		return object.NodeTypeReference, object.SyntaxTypeVariable
+	}
+	first := parent.SubUnits[0] == unit
+
+	switch parent.Kind {
+	case KindJavaScriptFunctionDeclaration, KindJavaScriptGeneratorFunctionDecl:
+		if unit.Kind == KindJavaScriptIdentifier {
+			return object.NodeTypeDefinition, object.SyntaxTypeFunction
+		}
+	case KindJavaScriptMethodDefinition:
+		if unit.Kind == KindJavaScriptPropertyIdentifier {
+			return object.NodeTypeDefinition, object.SyntaxTypeMethod
+		}
+	case KindJavaScriptClassDeclaration:
+		if first {
+			return object.NodeTypeDefinition, object.SyntaxTypeClass
 		}
-		symbol := &object.Symbol{
-			Symbol:    eachUnit.Content,
-			Kind:      eachUnit.Kind,
-			Span:      eachUnit.Span,
-			FieldName: eachUnit.FieldName,
-			Unit:      eachUnit,
+	case KindJavaScriptFormalParameters, KindJavaScriptFieldDefinition, KindJavaScriptCatchClause:
+		return object.NodeTypeDefinition, object.SyntaxTypeVariable
+	case KindJavaScriptVariableDeclarator, KindJavaScriptArrowFunction:
+		// `let a = 1` and `a => a`
+		if first {
+			return object.NodeTypeDefinition, object.SyntaxTypeVariable
 		}
-		ret = append(ret, symbol)
+	case KindJavaScriptImportClause, KindJavaScriptNamespaceImport:
+		return object.NodeTypeDefinition, object.SyntaxTypeModule
+	case KindJavaScriptImportSpecifier:
+		// `import { a as b }`, b is the local name
+		if parent.SubUnits[len(parent.SubUnits)-1] == unit {
+			return object.NodeTypeDefinition, object.SyntaxTypeModule
+		}
+		return object.NodeTypeReference, object.SyntaxTypeModule
+	case KindJavaScriptCallExpression, KindJavaScriptNewExpression:
+		// `a()` and `new A()`
+		if first {
+			return object.NodeTypeReference, object.SyntaxTypeCall
+		}
+	case KindJavaScriptMemberExpression:
+		// `b.a()`
+		grand := parent.ParentUnit
+		if !first && grand != nil && grand.Kind == KindJavaScriptCallExpression && grand.SubUnits[0] == parent {
+			return object.NodeTypeReference, object.SyntaxTypeCall
+		}
+	}
+	return object.NodeTypeReference, object.SyntaxTypeVariable
+}
+
+func (extractor *Extractor) scopeResolver() *object.ScopeResolver {
+	scopes := []core.KindRepr{
+		KindJavaScriptGeneratorFunctionDecl,
+		KindJavaScriptFunction,
+		KindJavaScriptArrowFunction,
+		KindJavaScriptStatementBlock,
+		KindJavaScriptForStatement,
+		KindJavaScriptForInStatement,
+		KindJavaScriptCatchClause,
+	}
+	return &object.ScopeResolver{
+		IsScope: func(unit *core.Unit) bool {
+			return extractor.IsFunction(unit) || slices.Contains(scopes, unit.Kind)
+		},
+		IsMember: func(symbol *object.Symbol) bool {
+			// `a.b`
+			return symbol.Kind == KindJavaScriptPropertyIdentifier
+		},
 	}
-	return ret, nil
 }
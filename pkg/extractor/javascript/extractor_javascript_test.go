@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -89,3 +90,290 @@ func TestExtractor_ExtractClasses(t *testing.T) {
 		core.Log.Debugf("class: %v", each.Name)
 	}
 }
+
+var jsImportCode = `
+import React from 'react';
+import * as ns from "x";
+import def, { a as b, c } from './y';
+import './side';
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJavaScript)
+	units, err := parser.Parse([]byte(jsImportCode))
+	if err != nil {
+		panic(err)
+	}
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 4)
+	assert.Equal(t, "react", imports[0].Source)
+	assert.Equal(t, "React", imports[0].Aliases["default"])
+	assert.True(t, imports[1].Wildcard)
+	assert.Equal(t, "ns", imports[1].Alias)
+	assert.Equal(t, "./y", imports[2].Source)
+	assert.Equal(t, []string{"default", "a", "c"}, imports[2].Names)
+	assert.Equal(t, "b", imports[2].Aliases["a"])
+	assert.Empty(t, imports[3].Names)
+}
+
+var jsCommentCode = `
+/**
+ * Sum of a and b.
+ */
+export function sum(a, b) {
+  return a + b;
+}
+
+// a shape
+class Shape {
+  // area of this shape
+  area() {}
+}
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJavaScript)
+	units, err := parser.Parse([]byte(jsCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "Sum of a and b.", comments[0].Text)
+	assert.Equal(t, ".Shape", comments[1].Owner)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, functions[0].GetSignature(), comments[0].Owner)
+	assert.Equal(t, "Sum of a and b.", functions[0].Extras.(*FunctionExtras).Doc)
+	assert.Equal(t, "area of this shape", functions[1].Extras.(*FunctionExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "a shape", classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var jsVariableCode = `
+const MAX = 10;
+let count = 0, other;
+export const flag = true;
+const { a, b } = obj;
+
+class A {
+  x = 1;
+
+  f() {
+    var z = 3;
+  }
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJavaScript)
+	units, err := parser.Parse([]byte(jsVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 6)
+
+	assert.Equal(t, "MAX", variables[0].Name)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeModule, variables[0].Scope)
+
+	assert.Equal(t, "other", variables[2].Name)
+	assert.Empty(t, variables[2].Value)
+	assert.Equal(t, object.MutabilityVar, variables[2].Mutability)
+
+	// exported
+	assert.Equal(t, "flag", variables[3].Name)
+
+	assert.Equal(t, "x", variables[4].Name)
+	assert.Equal(t, object.VariableScopeClass, variables[4].Scope)
+	assert.Equal(t, "A", variables[4].Receiver)
+
+	assert.Equal(t, object.VariableScopeLocal, variables[5].Scope)
+}
+
+var jsMetricCode = `
+function f(a) {
+  if (a > 0 && a < 3) { return 1; } else if (a < 0) { return 2; } else {}
+  for (let i = 0; i < 3; i++) {}
+  for (const x of xs) {}
+  for (const x in xs) {}
+  while (a > 0) {}
+  do {} while (a > 0);
+  switch (a) { case 1: break; default: }
+  try {} catch (e) {} finally {}
+  const b = a > 0 ? 1 : 2;
+  const c = a ?? 1;
+  const l = () => {};
+  return 0;
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJavaScript)
+	units, err := parser.Parse([]byte(jsMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 12, metrics.Cyclomatic)
+	assert.Equal(t, 11, metrics.Cognitive)
+	assert.Equal(t, 14, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
+
+var jsSymbolCode = `
+import { foo as bar } from "x";
+class A {
+  count = 0;
+  run(items) {
+    const x = items.length;
+    helper(x);
+    this.other(x);
+    return new A();
+  }
+}
+function helper(a) {}
+`
+
+func TestExtractor_ExtractSymbolKinds(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJavaScript)
+	units, err := parser.Parse([]byte(jsSymbolCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	symbols, err := extractor.ExtractSymbols(units)
+	assert.Nil(t, err)
+	find := func(name string, nodeType object.NodeType) *object.Symbol {
+		for _, each := range symbols {
+			if each.Symbol == name && each.NodeType == nodeType {
+				return each
+			}
+		}
+		return nil
+	}
+
+	assert.Equal(t, object.SyntaxTypeModule, find("foo", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeModule, find("bar", object.NodeTypeDefinition).SyntaxType)
+
+	a := find("A", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeClass, a.SyntaxType)
+	assert.Empty(t, a.Scope)
+	count := find("count", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeVariable, count.SyntaxType)
+	assert.Equal(t, ".A", count.Scope)
+
+	assert.Equal(t, object.SyntaxTypeMethod, find("run", object.NodeTypeDefinition).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeFunction, find("helper", object.NodeTypeDefinition).SyntaxType)
+
+	x := find("x", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeVariable, x.SyntaxType)
+	assert.Equal(t, "|A|run||", x.Scope)
+	assert.Equal(t, object.SyntaxTypeVariable, find("items", object.NodeTypeDefinition).SyntaxType)
+
+	assert.Equal(t, object.SyntaxTypeCall, find("helper", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeCall, find("other", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeCall, find("A", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeVariable, find("length", object.NodeTypeReference).SyntaxType)
+}
+
+var jsTestCode = `
+function helper() {}
+
+describe("math", () => {
+  it("adds", function () {
+    expect(helper()).toBe(1);
+  });
+
+  test.each([1])("each %i", (a) => {});
+  it.only("only", async () => {});
+});
+
+[1].map((a) => a);
+`
+
+func TestExtractor_ExtractTests(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJavaScript)
+	units, err := parser.Parse([]byte(jsTestCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	// other anonymous functions are ignored
+	assert.Len(t, functions, 5)
+
+	assert.False(t, functions[0].IsTest())
+
+	describe := functions[1]
+	assert.Equal(t, "math", describe.Name)
+	assert.Empty(t, describe.Receiver)
+	assert.Equal(t, object.TestFrameworkJest, describe.GetTestFramework())
+
+	it := functions[2]
+	assert.Equal(t, "adds", it.Name)
+	assert.Equal(t, "math", it.Receiver)
+	assert.Equal(t, 5, it.DefLine)
+	assert.Equal(t, object.TestFrameworkJest, it.GetTestFramework())
+
+	each := functions[3]
+	assert.Equal(t, "each %i", each.Name)
+	assert.Len(t, each.Parameters, 1)
+	assert.Equal(t, "only", functions[4].Name)
+}
+
+var jsEntryCode = `
+const express = require('express');
+const router = express.Router();
+
+router.get('/users', auth, listUsers);
+router.post('/users', (req, res) => {
+  res.send(req.body);
+});
+
+function listUsers(req, res) {}
+`
+
+func TestExtractor_DetectEntryPoints(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangJavaScript)
+	units, err := parser.Parse([]byte(jsEntryCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	entries, err := extractor.DetectEntryPoints(units)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "GET", entries[0].Method)
+	assert.Equal(t, "listUsers", entries[0].Handler)
+	assert.Equal(t, "POST", entries[1].Method)
+	assert.NotNil(t, entries[1].Unit)
+
+	// inline handlers are extracted as functions
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, functions, 2)
+	assert.Equal(t, "POST /users", functions[0].Name)
+	assert.Equal(t, "router", functions[0].Receiver)
+	assert.Equal(t, entries[1].Unit, functions[0].Unit)
+}
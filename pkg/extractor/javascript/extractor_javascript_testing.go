@@ -0,0 +1,117 @@
+package javascript
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+// jest and mocha
+var testBlockNames = []string{"describe", "it", "test"}
+
+func (extras *FunctionExtras) GetTestFramework() object.TestFramework {
+	return extras.TestFramework
+}
+
+// isTestBlock callbacks of `describe`, `it` and `test`, eg: `it("adds", () => {})`
+func isTestBlock(unit *core.Unit) bool {
+	return findTestCall(unit) != nil
+}
+
+// findTestCall the `it(...)` which this callback passed to, nil if none
+func findTestCall(unit *core.Unit) *core.Unit {
+	if unit.Kind != KindJavaScriptArrowFunction && unit.Kind != KindJavaScriptFunction {
+		return nil
+	}
+	args := unit.ParentUnit
+	if args == nil || args.Kind != KindJavaScriptArguments {
+		return nil
+	}
+	call := args.ParentUnit
+	if call == nil || call.Kind != KindJavaScriptCallExpression || len(call.SubUnits) == 0 {
+		return nil
+	}
+	if !slices.Contains(testBlockNames, calleeRoot(call.SubUnits[0])) {
+		return nil
+	}
+	return call
+}
+
+// calleeRoot `it` of `it.only(...)` and `test.each([...])(...)`
+func calleeRoot(unit *core.Unit) string {
+	for {
+		switch unit.Kind {
+		case KindJavaScriptIdentifier:
+			return unit.Content
+		case KindJavaScriptMemberExpression, KindJavaScriptCallExpression:
+			if len(unit.SubUnits) == 0 {
+				return ""
+			}
+			unit = unit.SubUnits[0]
+		default:
+			return ""
+		}
+	}
+}
+
+// testDescription the first argument, without quotes
+func testDescription(call *core.Unit) string {
+	args := call.SubUnits[len(call.SubUnits)-1]
+	if args.Kind != KindJavaScriptArguments || len(args.SubUnits) == 0 {
+		return ""
+	}
+	desc := args.SubUnits[0]
+	if desc.Kind == KindJavaScriptString || desc.Kind == KindJavaScriptTemplateString {
+		return strings.Trim(desc.Content, "\"'`")
+	}
+	return desc.Content
+}
+
+// extractFromTestBlock named with descriptions, and the enclosing block as receiver:
+//
+//	describe("math", () => {   -> math
+//		it("adds", () => {})   -> math.adds
+//	})
+func (extractor *Extractor) extractFromTestBlock(unit *core.Unit, function *object.Function) error {
+	call := findTestCall(unit)
+	if call == nil {
+		return errors.New("not a test block: " + unit.Content)
+	}
+	function.Name = testDescription(call)
+	function.DefLine = int(call.Span.Start.Row + 1)
+
+	for parent := call.ParentUnit; parent != nil; parent = parent.ParentUnit {
+		if parentCall := findTestCall(parent); parentCall != nil {
+			function.Receiver = testDescription(parentCall)
+			break
+		}
+	}
+	fillCallback(unit, function)
+	return nil
+}
+
+// fillCallback body and params of anonymous functions
+func fillCallback(unit *core.Unit, function *object.Function) {
+	// body is the direct one, `() => expr` has no block
+	function.BodySpan = unit.Span
+	if bodyUnit := core.FindFirstByKindInSubs(unit, KindJavaScriptStatementBlock); bodyUnit != nil {
+		function.BodySpan = bodyUnit.Span
+	}
+
+	// params, `(a, b) => {}` and `a => {}`
+	params := core.FindAllByKindInSubs(unit, KindJavaScriptIdentifier)
+	if parametersNode := core.FindFirstByKindInSubs(unit, KindJavaScriptFormalParameters); parametersNode != nil {
+		params = core.FindAllByKindInSubs(parametersNode, KindJavaScriptIdentifier)
+	} else if len(params) > 1 {
+		params = params[:1]
+	}
+	for _, each := range params {
+		function.Parameters = append(function.Parameters, &object.ValueUnit{
+			Type: "",
+			Name: each.Content,
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package javascript
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	switch unit.Kind {
+	case KindJavaScriptVariableDeclarator:
+		// destructuring patterns are ignored
+		return len(unit.SubUnits) > 0 && unit.SubUnits[0].Kind == KindJavaScriptIdentifier
+	case KindJavaScriptFieldDefinition:
+		return core.FindFirstByKindInSubs(unit, KindJavaScriptPropertyIdentifier) != nil
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		ret = append(ret, extractor.extractVariable(eachUnit))
+	}
+	return ret, nil
+}
+
+// extractVariable variable_declarator or public_field_definition: name, [value]
+func (extractor *Extractor) extractVariable(unit *core.Unit) *object.Variable {
+	v := object.NewVariable()
+	v.Span = unit.Span
+	v.Lang = extractor.GetLang()
+
+	var name *core.Unit
+	if unit.Kind == KindJavaScriptFieldDefinition {
+		name = core.FindFirstByKindInSubs(unit, KindJavaScriptPropertyIdentifier)
+		v.Scope = object.VariableScopeClass
+		clazzDecl := core.FindFirstByKindInParent(unit, KindJavaScriptClassDeclaration)
+		if clazzName := core.FindFirstByKindInSubs(clazzDecl, KindJavaScriptIdentifier); clazzName != nil {
+			v.Receiver = clazzName.Content
+		}
+	} else {
+		name = unit.SubUnits[0]
+		// `const`, `let` or `var`
+		if strings.HasPrefix(unit.ParentUnit.Content, "const") {
+			v.Mutability = object.MutabilityConst
+		}
+		owner := core.FindFirstByOneOfKindInParent(unit, KindJavaScriptStatementBlock, KindJavaScriptClassBody,
+			KindJavaScriptForStatement, KindJavaScriptForInStatement)
+		if owner != nil {
+			v.Scope = object.VariableScopeLocal
+		}
+	}
+	v.Name = name.Content
+	for _, each := range unit.SubUnits {
+		if each != name {
+			v.Value = each.Content
+		}
+	}
+	return v
+}
@@ -7,14 +7,50 @@ import (
 // NOTICE: kotlin grammar is not official
 // https://github.com/fwcd/tree-sitter-kotlin/blob/main/src/node-types.json
 const (
-	KindKotlinFunctionDecl     core.KindRepr = "function_declaration"
-	KindKotlinFunctionBody     core.KindRepr = "function_body"
-	KindKotlinPackageHeader    core.KindRepr = "package_header"
-	KindKotlinIdentifier       core.KindRepr = "identifier"
-	KindKotlinTypeIdentifier   core.KindRepr = "type_identifier"
-	KindKotlinClassDecl        core.KindRepr = "class_declaration"
-	KindKotlinSourceFile       core.KindRepr = "source_file"
-	KindKotlinSimpleIdentifier core.KindRepr = "simple_identifier"
+	KindKotlinFunctionDecl            core.KindRepr = "function_declaration"
+	KindKotlinFunctionBody            core.KindRepr = "function_body"
+	KindKotlinPackageHeader           core.KindRepr = "package_header"
+	KindKotlinIdentifier              core.KindRepr = "identifier"
+	KindKotlinTypeIdentifier          core.KindRepr = "type_identifier"
+	KindKotlinClassDecl               core.KindRepr = "class_declaration"
+	KindKotlinSourceFile              core.KindRepr = "source_file"
+	KindKotlinSimpleIdentifier        core.KindRepr = "simple_identifier"
+	KindKotlinImportHeader            core.KindRepr = "import_header"
+	KindKotlinImportAlias             core.KindRepr = "import_alias"
+	KindKotlinLineComment             core.KindRepr = "line_comment"
+	KindKotlinMultilineComment        core.KindRepr = "multiline_comment"
+	KindKotlinPropertyDecl            core.KindRepr = "property_declaration"
+	KindKotlinVariableDecl            core.KindRepr = "variable_declaration"
+	KindKotlinModifiers               core.KindRepr = "modifiers"
+	KindKotlinObjectDecl              core.KindRepr = "object_declaration"
+	KindKotlinClassBody               core.KindRepr = "class_body"
+	KindKotlinAnonymousInit           core.KindRepr = "anonymous_initializer"
+	KindKotlinLambdaLiteral           core.KindRepr = "lambda_literal"
+	KindKotlinIfExpression            core.KindRepr = "if_expression"
+	KindKotlinForStatement            core.KindRepr = "for_statement"
+	KindKotlinWhileStatement          core.KindRepr = "while_statement"
+	KindKotlinDoWhileStatement        core.KindRepr = "do_while_statement"
+	KindKotlinWhenEntry               core.KindRepr = "when_entry"
+	KindKotlinCatchBlock              core.KindRepr = "catch_block"
+	KindKotlinElvisExpression         core.KindRepr = "elvis_expression"
+	KindKotlinWhenExpression          core.KindRepr = "when_expression"
+	KindKotlinAnonymousFunction       core.KindRepr = "anonymous_function"
+	KindKotlinConjunctionExpression   core.KindRepr = "conjunction_expression"
+	KindKotlinDisjunctionExpression   core.KindRepr = "disjunction_expression"
+	KindKotlinJumpExpression          core.KindRepr = "jump_expression"
+	KindKotlinParameter               core.KindRepr = "parameter"
+	KindKotlinClassParameter          core.KindRepr = "class_parameter"
+	KindKotlinCallExpression          core.KindRepr = "call_expression"
+	KindKotlinNavigationExpression    core.KindRepr = "navigation_expression"
+	KindKotlinNavigationSuffix        core.KindRepr = "navigation_suffix"
+	KindKotlinStatements              core.KindRepr = "statements"
+	KindKotlinAnnotation              core.KindRepr = "annotation"
+	KindKotlinParameterModifiers      core.KindRepr = "parameter_modifiers"
+	KindKotlinFunctionValueParameters core.KindRepr = "function_value_parameters"
+	KindKotlinCallSuffix              core.KindRepr = "call_suffix"
+	KindKotlinValueArguments          core.KindRepr = "value_arguments"
+	KindKotlinValueArgument           core.KindRepr = "value_argument"
+	KindKotlinAnnotatedLambda         core.KindRepr = "annotated_lambda"
 )
 
 type Extractor struct {
@@ -1,16 +1,96 @@
 package kotlin
 
 import (
+	"errors"
+
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 )
 
 func (extractor *Extractor) IsCall(unit *core.Unit) bool {
-	// TODO implement me
-	panic("implement me")
+	if unit.Kind != KindKotlinCallExpression {
+		return false
+	}
+	// `foo(1) { }` is parsed as a call of `foo(1)`, the lambda belongs to the inner one
+	return !isTrailingLambdaCall(unit)
 }
 
 func (extractor *Extractor) ExtractCalls(units []*core.Unit) ([]*object.Call, error) {
-	// TODO implement me
-	panic("implement me")
+	var ret []*object.Call
+	for _, eachUnit := range units {
+		if !extractor.IsCall(eachUnit) {
+			continue
+		}
+
+		eachCall, err := extractor.unit2Call(eachUnit)
+		if err != nil {
+			core.Log.Warnf("err: %v", err)
+			continue
+		}
+		ret = append(ret, eachCall)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
+	// calls in lambdas belong to the enclosing function
+	funcUnit := core.FindFirstByKindInParent(unit, KindKotlinFunctionDecl)
+	var srcFunc *object.Function
+	var err error
+	if funcUnit != nil {
+		srcFunc, err = extractor.ExtractFunction(funcUnit)
+		if err != nil {
+			return nil, errors.New("convert func failed: " + funcUnit.Content)
+		}
+	}
+
+	// headless, give up (temp
+	if srcFunc == nil {
+		return nil, errors.New("headless call")
+	}
+	if len(unit.SubUnits) != 2 {
+		return nil, errors.New("invalid call: " + unit.Content)
+	}
+
+	arguments := callArguments(unit.SubUnits[1])
+	if parent := unit.ParentUnit; parent != nil && isTrailingLambdaCall(parent) {
+		arguments = append(arguments, callArguments(parent.SubUnits[1])...)
+	}
+	ret := &object.Call{
+		Src:       srcFunc.GetSignature(),
+		Caller:    unit.SubUnits[0].Content,
+		Arguments: arguments,
+		Span:      unit.Span,
+	}
+	return ret, nil
+}
+
+// isTrailingLambdaCall `foo(1) { }`: a call of another call, with only a lambda
+func isTrailingLambdaCall(unit *core.Unit) bool {
+	if unit.Kind != KindKotlinCallExpression || len(unit.SubUnits) != 2 {
+		return false
+	}
+	callee, suffix := unit.SubUnits[0], unit.SubUnits[1]
+	return callee.Kind == KindKotlinCallExpression &&
+		core.FindFirstByKindInSubs(suffix, KindKotlinValueArguments) == nil &&
+		core.FindFirstByKindInSubs(suffix, KindKotlinAnnotatedLambda) != nil
+}
+
+// callArguments value arguments and the trailing lambda, one for each
+func callArguments(suffix *core.Unit) []string {
+	var ret []string
+	if suffix.Kind != KindKotlinCallSuffix {
+		return ret
+	}
+	for _, each := range suffix.SubUnits {
+		switch each.Kind {
+		case KindKotlinValueArguments:
+			for _, eachArg := range core.FindAllByKindInSubs(each, KindKotlinValueArgument) {
+				ret = append(ret, eachArg.Content)
+			}
+		case KindKotlinAnnotatedLambda:
+			ret = append(ret, each.Content)
+		}
+	}
+	return ret
 }
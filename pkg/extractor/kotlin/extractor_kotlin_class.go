@@ -7,6 +7,11 @@ import (
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 )
 
+type ClassExtras struct {
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
 func (extractor *Extractor) IsClass(unit *core.Unit) bool {
 	// current kotlin grammar only has one class decl type (no interface and something else
 	if unit.Kind == KindKotlinClassDecl {
@@ -59,5 +64,8 @@ func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error)
 
 	clazz.Module = pkgName
 	clazz.Name = clazzName
+	clazz.Extras = &ClassExtras{
+		Doc: object.NewCommentRule(extractor).FindDoc(unit),
+	}
 	return clazz, nil
 }
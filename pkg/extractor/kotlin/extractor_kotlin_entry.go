@@ -0,0 +1,49 @@
+package kotlin
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+// DetectEntryPoints spring and jax-rs handlers, and spring scheduled jobs
+func (extractor *Extractor) DetectEntryPoints(units []*core.Unit) ([]*object.EntryPoint, error) {
+	var ret []*object.EntryPoint
+	for _, eachUnit := range units {
+		if eachUnit.Kind != KindKotlinFunctionDecl {
+			continue
+		}
+		annotations := findAnnotations(eachUnit)
+		if len(annotations) == 0 {
+			continue
+		}
+		var classAnnotations []string
+		if clazzDecl := core.FindFirstByOneOfKindInParent(eachUnit, KindKotlinClassDecl); clazzDecl != nil {
+			classAnnotations = findAnnotations(clazzDecl)
+		}
+		var handler string
+		if nameUnit := core.FindFirstByKindInSubs(eachUnit, KindKotlinSimpleIdentifier); nameUnit != nil {
+			handler = nameUnit.Content
+		}
+
+		for _, each := range object.NewAnnotationEntryPoints(classAnnotations, annotations) {
+			each.Handler = handler
+			each.Span = eachUnit.Span
+			each.Unit = eachUnit
+			ret = append(ret, each)
+		}
+	}
+	return ret, nil
+}
+
+// findAnnotations from the direct modifiers
+func findAnnotations(unit *core.Unit) []string {
+	modifiers := core.FindFirstByKindInSubs(unit, KindKotlinModifiers)
+	if modifiers == nil {
+		return nil
+	}
+	var ret []string
+	for _, each := range core.FindAllByKindInSubs(modifiers, KindKotlinAnnotation) {
+		ret = append(ret, each.Content)
+	}
+	return ret
+}
@@ -2,11 +2,20 @@ package kotlin
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
 )
 
+type FunctionExtras struct {
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+	// TestFramework empty if this function is not a test
+	TestFramework object.TestFramework `json:"testFramework"`
+}
+
 func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
 	if unit.Kind == KindKotlinFunctionDecl {
 		return true
@@ -70,6 +79,69 @@ func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function,
 	}
 	funcUnit.Name = funcIdentifier.Content
 	funcUnit.DefLine = int(funcIdentifier.Span.Start.Row + 1)
+	funcUnit.Parameters = extractParameters(unit)
+	funcUnit.Extras = &FunctionExtras{
+		Doc:           object.NewCommentRule(extractor).FindDoc(unit),
+		TestFramework: testFramework(unit),
+	}
 
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
 	return funcUnit, nil
 }
+
+// kinds which can follow a parameter but are not its default value
+var notDefaultKinds = []core.KindRepr{
+	KindKotlinParameter,
+	KindKotlinParameterModifiers,
+	KindKotlinLineComment,
+	KindKotlinMultilineComment,
+}
+
+/*
+extractParameters parameters and their defaults are flattened in the list
+
+	fun go(vararg a: Int, b: String = "b"): Int
+
+	function_value_parameters
+		parameter_modifiers (vararg)
+		parameter (a: Int)      -> Int...
+		parameter (b: String)
+		string_literal ("b")    -> default of b
+*/
+func extractParameters(unit *core.Unit) []*object.ValueUnit {
+	paramList := core.FindFirstByKindInSubs(unit, KindKotlinFunctionValueParameters)
+	if paramList == nil {
+		return nil
+	}
+	var ret []*object.ValueUnit
+	variadic := false
+	var last *object.ValueUnit
+	for _, each := range paramList.SubUnits {
+		switch each.Kind {
+		case KindKotlinParameterModifiers:
+			variadic = strings.Contains(each.Content, "vararg")
+		case KindKotlinParameter:
+			valueUnit := &object.ValueUnit{}
+			for _, eachSub := range each.SubUnits {
+				if eachSub.Kind == KindKotlinSimpleIdentifier {
+					valueUnit.Name = eachSub.Content
+				} else {
+					valueUnit.Type = eachSub.Content
+				}
+			}
+			if variadic {
+				valueUnit.Type += "..."
+				variadic = false
+			}
+			ret = append(ret, valueUnit)
+			last = valueUnit
+			continue
+		default:
+			if last != nil && !slices.Contains(notDefaultKinds, each.Kind) {
+				last.Default = each.Content
+			}
+		}
+		last = nil
+	}
+	return ret
+}
@@ -0,0 +1,49 @@
+package kotlin
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindKotlinImportHeader
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) {
+			continue
+		}
+		identifier := core.FindFirstByKindInSubs(eachUnit, KindKotlinIdentifier)
+		if identifier == nil {
+			continue
+		}
+		imp := object.NewImport()
+		imp.Span = eachUnit.Span
+		imp.Lang = extractor.GetLang()
+
+		// `.*` is not a node in this grammar
+		if strings.HasSuffix(strings.TrimSpace(eachUnit.Content), ".*") {
+			imp.Source = identifier.Content
+			imp.Wildcard = true
+			ret = append(ret, imp)
+			continue
+		}
+
+		// import a.b.C as D
+		var alias string
+		if aliasUnit := core.FindFirstByKindInSubs(eachUnit, KindKotlinImportAlias); aliasUnit != nil && len(aliasUnit.SubUnits) != 0 {
+			alias = aliasUnit.SubUnits[0].Content
+		}
+		name := identifier.Content
+		if i := strings.LastIndex(name, "."); i != -1 {
+			imp.Source, name = name[:i], name[i+1:]
+		}
+		imp.AddName(name, alias)
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
@@ -0,0 +1,43 @@
+package kotlin
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindKotlinIfExpression,
+			KindKotlinForStatement,
+			KindKotlinWhileStatement,
+			KindKotlinDoWhileStatement,
+			KindKotlinWhenEntry,
+			KindKotlinCatchBlock,
+			KindKotlinElvisExpression,
+		},
+		FlowKinds: []core.KindRepr{
+			KindKotlinIfExpression,
+			KindKotlinForStatement,
+			KindKotlinWhileStatement,
+			KindKotlinDoWhileStatement,
+			KindKotlinWhenExpression,
+			KindKotlinCatchBlock,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindKotlinLambdaLiteral,
+			KindKotlinAnonymousFunction,
+		},
+		LogicalKinds: []core.KindRepr{
+			KindKotlinConjunctionExpression,
+			KindKotlinDisjunctionExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindKotlinJumpExpression,
+		},
+		CommentKinds: []core.KindRepr{
+			KindKotlinLineComment,
+			KindKotlinMultilineComment,
+		},
+	}
+}
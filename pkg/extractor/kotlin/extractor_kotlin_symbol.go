@@ -1,34 +1,92 @@
 package kotlin
 
 import (
-	"strings"
-
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
 )
 
 func (extractor *Extractor) IsSymbol(unit *core.Unit) bool {
-	// todo: use grammar.js instead
-	if strings.HasSuffix(unit.Kind, "identifier") {
+	// identifiers, eg: `a.b` in headers, are made of simple identifiers
+	switch unit.Kind {
+	case KindKotlinSimpleIdentifier, KindKotlinTypeIdentifier:
 		return true
 	}
 	return false
 }
 
 func (extractor *Extractor) ExtractSymbols(units []*core.Unit) ([]*object.Symbol, error) {
-	ret := make([]*object.Symbol, 0)
-	for _, eachUnit := range units {
-		if !extractor.IsSymbol(eachUnit) {
-			continue
+	return object.ExtractSymbols(extractor, units, classifySymbol, extractor.scopeResolver())
+}
+
+// classifySymbol by its parent
+func classifySymbol(unit *core.Unit) (object.NodeType, object.SyntaxType) {
+	parent := unit.ParentUnit
+	if parent == nil {
+		return object.NodeTypeReference, object.SyntaxTypeVariable
+	}
+	first := parent.SubUnits[0] == unit
+
+	// `package a.b` and `import a.b.C`
+	if header := core.FindFirstByOneOfKindInParent(unit, KindKotlinPackageHeader, KindKotlinImportHeader); header != nil {
+		if header.Kind == KindKotlinPackageHeader || parent.Kind == KindKotlinImportAlias {
+			return object.NodeTypeDefinition, object.SyntaxTypeModule
+		}
+		return object.NodeTypeReference, object.SyntaxTypeModule
+	}
+
+	switch parent.Kind {
+	case KindKotlinFunctionDecl:
+		if unit.Kind == KindKotlinSimpleIdentifier {
+			grand := parent.ParentUnit
+			if grand != nil && grand.Kind == KindKotlinClassBody {
+				return object.NodeTypeDefinition, object.SyntaxTypeMethod
+			}
+			return object.NodeTypeDefinition, object.SyntaxTypeFunction
 		}
-		symbol := &object.Symbol{
-			Symbol:    eachUnit.Content,
-			Kind:      eachUnit.Kind,
-			Span:      eachUnit.Span,
-			FieldName: eachUnit.FieldName,
-			Unit:      eachUnit,
+	case KindKotlinClassDecl, KindKotlinObjectDecl:
+		if unit.Kind == KindKotlinTypeIdentifier {
+			return object.NodeTypeDefinition, object.SyntaxTypeClass
 		}
-		ret = append(ret, symbol)
+	case KindKotlinVariableDecl, KindKotlinParameter, KindKotlinClassParameter:
+		if unit.Kind == KindKotlinSimpleIdentifier {
+			return object.NodeTypeDefinition, object.SyntaxTypeVariable
+		}
+	case KindKotlinCallExpression:
+		// `a()`
+		if first {
+			return object.NodeTypeReference, object.SyntaxTypeCall
+		}
+	case KindKotlinNavigationSuffix:
+		// `b.a()`
+		nav := parent.ParentUnit
+		if nav != nil && nav.Kind == KindKotlinNavigationExpression && nav.ParentUnit != nil &&
+			nav.ParentUnit.Kind == KindKotlinCallExpression && nav.ParentUnit.SubUnits[0] == nav {
+			return object.NodeTypeReference, object.SyntaxTypeCall
+		}
+	}
+	if unit.Kind == KindKotlinTypeIdentifier {
+		return object.NodeTypeReference, object.SyntaxTypeType
+	}
+	return object.NodeTypeReference, object.SyntaxTypeVariable
+}
+
+func (extractor *Extractor) scopeResolver() *object.ScopeResolver {
+	scopes := []core.KindRepr{
+		KindKotlinStatements,
+		KindKotlinLambdaLiteral,
+		KindKotlinAnonymousFunction,
+		KindKotlinForStatement,
+		KindKotlinCatchBlock,
+	}
+	return &object.ScopeResolver{
+		IsScope: func(unit *core.Unit) bool {
+			return extractor.IsFunction(unit) || slices.Contains(scopes, unit.Kind)
+		},
+		IsMember: func(symbol *object.Symbol) bool {
+			// `a.b`
+			parent := symbol.Unit.ParentUnit
+			return parent != nil && parent.Kind == KindKotlinNavigationSuffix
+		},
 	}
-	return ret, nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor/kotlin"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -91,3 +92,320 @@ func TestExtractor_ExtractClasses(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(classes))
 }
+
+var kotlinImportCode = `
+package a.b
+import java.util.List
+import java.util.*
+import a.b.C as D
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangKotlin)
+	units, err := parser.Parse([]byte(kotlinImportCode))
+	if err != nil {
+		panic(err)
+	}
+
+	extractor := &kotlin.Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(imports))
+	assert.Equal(t, "java.util", imports[0].Source)
+	assert.Equal(t, []string{"List"}, imports[0].Names)
+	assert.True(t, imports[1].Wildcard)
+	assert.Equal(t, "java.util", imports[1].Source)
+	assert.Equal(t, "a.b", imports[2].Source)
+	assert.Equal(t, "D", imports[2].Aliases["C"])
+}
+
+var kotlinCommentCode = `
+package a.b
+
+/** A user. */
+class User {
+    // full name
+    fun name(): String = ""
+}
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangKotlin)
+	units, err := parser.Parse([]byte(kotlinCommentCode))
+	if err != nil {
+		panic(err)
+	}
+
+	extractor := &kotlin.Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(comments))
+	assert.Equal(t, "A user.", comments[0].Text)
+	assert.Equal(t, "a.b.User", comments[0].Owner)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "full name", functions[0].Extras.(*kotlin.FunctionExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "A user.", classes[0].Extras.(*kotlin.ClassExtras).Doc)
+}
+
+var kotlinVariableCode = `
+package a
+
+const val MAX = 10
+val name: String = "a"
+var count = 0
+
+class A {
+    val x = 1
+
+    fun f() {
+        var y: Int = 2
+    }
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangKotlin)
+	units, err := parser.Parse([]byte(kotlinVariableCode))
+	if err != nil {
+		panic(err)
+	}
+	extractor := &kotlin.Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 5)
+
+	assert.Equal(t, "MAX", variables[0].Name)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeModule, variables[0].Scope)
+	assert.Equal(t, "a", variables[0].Namespace)
+
+	assert.Equal(t, "String", variables[1].Type)
+	assert.Equal(t, object.MutabilityFinal, variables[1].Mutability)
+	assert.Equal(t, object.MutabilityVar, variables[2].Mutability)
+
+	assert.Equal(t, object.VariableScopeClass, variables[3].Scope)
+	assert.Equal(t, "a.A", variables[3].Receiver)
+
+	assert.Equal(t, "y", variables[4].Name)
+	assert.Equal(t, object.VariableScopeLocal, variables[4].Scope)
+}
+
+var kotlinMetricCode = `
+fun f(a: Int): Int {
+    if (a > 0 && a < 3) { return 1 } else if (a < 0) { return 2 }
+    for (i in 0..3) {}
+    while (a > 0) {}
+    do {} while (a > 0)
+    when (a) { 1 -> {} else -> {} }
+    try {} catch (e: Exception) {}
+    val x = a ?: 0
+    val l = { x: Int -> x }
+    return 0
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangKotlin)
+	units, err := parser.Parse([]byte(kotlinMetricCode))
+	if err != nil {
+		panic(err)
+	}
+	extractor := &kotlin.Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 10, metrics.Cyclomatic)
+	assert.Equal(t, 8, metrics.Cognitive)
+	assert.Equal(t, 11, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
+
+var kotlinSymbolCode = `
+package com.a
+class A(val c: Int) {
+  fun run(items: List<String>): Int {
+    val x = items.size
+    helper(x)
+    items.forEach { s -> println(s) }
+    return c
+  }
+}
+fun helper(a: Int) {}
+`
+
+func TestExtractor_ExtractSymbolKinds(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangKotlin)
+	units, err := parser.Parse([]byte(kotlinSymbolCode))
+	if err != nil {
+		panic(err)
+	}
+	extractor := &kotlin.Extractor{}
+	symbols, err := extractor.ExtractSymbols(units)
+	assert.Nil(t, err)
+	find := func(name string, nodeType object.NodeType) *object.Symbol {
+		for _, each := range symbols {
+			if each.Symbol == name && each.NodeType == nodeType {
+				return each
+			}
+		}
+		return nil
+	}
+
+	assert.Equal(t, object.SyntaxTypeModule, find("com", object.NodeTypeDefinition).SyntaxType)
+	a := find("A", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeClass, a.SyntaxType)
+	assert.Empty(t, a.Scope)
+
+	c := find("c", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeVariable, c.SyntaxType)
+	assert.Equal(t, "com.a.A", c.Scope)
+
+	assert.Equal(t, object.SyntaxTypeMethod, find("run", object.NodeTypeDefinition).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeFunction, find("helper", object.NodeTypeDefinition).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeType, find("String", object.NodeTypeReference).SyntaxType)
+
+	x := find("x", object.NodeTypeDefinition)
+	assert.Equal(t, object.SyntaxTypeVariable, x.SyntaxType)
+	assert.Equal(t, "com.a|com.a.A|run|List<String>|", x.Scope)
+	// lambda parameter
+	assert.Equal(t, object.SyntaxTypeVariable, find("s", object.NodeTypeDefinition).SyntaxType)
+
+	assert.Equal(t, object.SyntaxTypeCall, find("helper", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeCall, find("forEach", object.NodeTypeReference).SyntaxType)
+	assert.Equal(t, object.SyntaxTypeVariable, find("size", object.NodeTypeReference).SyntaxType)
+}
+
+var kotlinTestCode = `
+package com.a
+
+class ATest {
+    @Test
+    fun works() {}
+
+    @org.junit.jupiter.api.Test fun works2() {}
+
+    fun helper() {}
+}
+`
+
+func TestExtractor_ExtractTests(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangKotlin)
+	units, err := parser.Parse([]byte(kotlinTestCode))
+	if err != nil {
+		panic(err)
+	}
+	extractor := &kotlin.Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, functions, 3)
+
+	assert.Equal(t, object.TestFrameworkJUnit, functions[0].GetTestFramework())
+	assert.Equal(t, object.TestFrameworkJUnit, functions[1].GetTestFramework())
+	assert.False(t, functions[2].IsTest())
+}
+
+var kotlinEntryCode = `
+package com.a
+
+@RestController
+@RequestMapping("/api")
+class UserController {
+    @PostMapping(path = ["/users"])
+    fun save() {}
+
+    @Scheduled(fixedRate = 5000)
+    fun clean() {}
+
+    fun helper() {}
+}
+`
+
+func TestExtractor_DetectEntryPoints(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangKotlin)
+	units, err := parser.Parse([]byte(kotlinEntryCode))
+	if err != nil {
+		panic(err)
+	}
+	extractor := &kotlin.Extractor{}
+	entries, err := extractor.DetectEntryPoints(units)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+
+	assert.Equal(t, object.EntryKindHttp, entries[0].Kind)
+	assert.Equal(t, "POST", entries[0].Method)
+	assert.Equal(t, "/api/users", entries[0].Route)
+	assert.Equal(t, "save", entries[0].Handler)
+
+	assert.Equal(t, object.EntryKindSchedule, entries[1].Kind)
+	assert.Equal(t, "fixedRate = 5000", entries[1].Route)
+	assert.Equal(t, "clean", entries[1].Handler)
+}
+
+var kotlinOverloadCode = `
+class Worker {
+    fun process(a: String, b: Int = 1, vararg c: String) {
+        process("x", 2)
+        items.forEach(1) { println(it) }
+    }
+}
+`
+
+func TestExtractor_ExtractParameters(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangKotlin)
+	units, err := parser.Parse([]byte(kotlinOverloadCode))
+	if err != nil {
+		panic(err)
+	}
+	extractor := &kotlin.Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 1)
+
+	params := funcs[0].Parameters
+	assert.Len(t, params, 3)
+	assert.Equal(t, "a", params[0].Name)
+	assert.Equal(t, "String", params[0].Type)
+	assert.Equal(t, "1", params[1].Default)
+	assert.True(t, params[2].IsVariadic())
+
+	minCount, maxCount := funcs[0].Arity()
+	assert.Equal(t, 1, minCount)
+	assert.Equal(t, -1, maxCount)
+}
+
+func TestExtractor_ExtractCalls(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangKotlin)
+	units, err := parser.Parse([]byte(kotlinOverloadCode))
+	if err != nil {
+		panic(err)
+	}
+	extractor := &kotlin.Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	assert.Len(t, calls, 3)
+
+	assert.Equal(t, "process", calls[0].Caller)
+	assert.Equal(t, []string{"\"x\"", "2"}, calls[0].Arguments)
+	// trailing lambda is the last argument
+	assert.Equal(t, "items.forEach", calls[1].Caller)
+	assert.Len(t, calls[1].Arguments, 2)
+	assert.Equal(t, "println", calls[2].Caller)
+}
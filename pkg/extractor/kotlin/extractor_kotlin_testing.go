@@ -0,0 +1,20 @@
+package kotlin
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extras *FunctionExtras) GetTestFramework() object.TestFramework {
+	return extras.TestFramework
+}
+
+// testFramework junit and kotlin.test methods, both of them use `@Test`
+func testFramework(unit *core.Unit) object.TestFramework {
+	for _, each := range findAnnotations(unit) {
+		if object.IsJUnitAnnotation(each) {
+			return object.TestFrameworkJUnit
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package kotlin
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	// destructuring declarations are ignored
+	return unit.Kind == KindKotlinPropertyDecl && core.FindFirstByKindInSubs(unit, KindKotlinVariableDecl) != nil
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		ret = append(ret, extractor.extractVariable(eachUnit))
+	}
+	return ret, nil
+}
+
+// extractVariable property_declaration: [modifiers], variable_declaration(name, [type]), [value]
+func (extractor *Extractor) extractVariable(unit *core.Unit) *object.Variable {
+	v := object.NewVariable()
+	v.Span = unit.Span
+	v.Lang = extractor.GetLang()
+
+	// `val` and `var` are not named nodes
+	keyword := unit.Content
+	var declFound bool
+	for _, each := range unit.SubUnits {
+		switch {
+		case each.Kind == KindKotlinModifiers:
+			keyword = strings.TrimSpace(strings.TrimPrefix(keyword, each.Content))
+			for _, eachModifier := range each.SubUnits {
+				if eachModifier.Content == "const" {
+					v.Mutability = object.MutabilityConst
+				}
+			}
+		case each.Kind == KindKotlinVariableDecl:
+			declFound = true
+			if len(each.SubUnits) > 0 {
+				v.Name = each.SubUnits[0].Content
+			}
+			if len(each.SubUnits) > 1 {
+				v.Type = each.SubUnits[1].Content
+			}
+		case declFound && v.Value == "":
+			v.Value = each.Content
+		}
+	}
+	if v.Mutability != object.MutabilityConst && strings.HasPrefix(keyword, "val") {
+		v.Mutability = object.MutabilityFinal
+	}
+
+	root := core.FindFirstByKindInParent(unit, KindKotlinSourceFile)
+	packageDecl := core.FindFirstByKindInSubsWithDfs(root, KindKotlinPackageHeader)
+	if packageIdentifier := core.FindFirstByKindInSubsWithDfs(packageDecl, KindKotlinIdentifier); packageIdentifier != nil {
+		v.Namespace = packageIdentifier.Content
+	}
+
+	owner := core.FindFirstByOneOfKindInParent(unit.ParentUnit,
+		KindKotlinClassBody, KindKotlinFunctionBody, KindKotlinAnonymousInit, KindKotlinLambdaLiteral)
+	switch {
+	case owner == nil:
+	case owner.Kind == KindKotlinClassBody:
+		v.Scope = object.VariableScopeClass
+		clazzIdentifier := core.FindFirstByKindInSubs(owner.ParentUnit, KindKotlinTypeIdentifier)
+		if clazzIdentifier != nil {
+			v.Receiver = v.Namespace + "." + clazzIdentifier.Content
+		}
+	default:
+		v.Scope = object.VariableScopeLocal
+	}
+	return v
+}
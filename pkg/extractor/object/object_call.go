@@ -22,6 +22,8 @@ type Call struct {
 	Caller    string    `json:"caller"`
 	Arguments []string  `json:"arguments"`
 	Span      core.Span `json:"span"`
+	// ReceiverType type of `a` in `a.b()`, only if it can be told in place, eg: `a` is a typed parameter
+	ReceiverType string `json:"receiverType"`
 }
 
 func (c *Call) GetIndexName() string {
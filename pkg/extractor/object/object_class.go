@@ -19,6 +19,10 @@ type Clazz struct {
 	// which contains language-specific contents
 	Extras interface{} `json:"extras" bson:"extras,omitempty"`
 
+	// hash of content without comments and formats, and hash of signature
+	BodyHash      string `json:"bodyHash" bson:"bodyHash"`
+	SignatureHash string `json:"signatureHash" bson:"signatureHash"`
+
 	// ptr to origin Unit
 	Unit *core.Unit `json:"-" bson:"-"`
 
@@ -0,0 +1,261 @@
+package object
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+type CommentKind = string
+
+const (
+	CommentLine  CommentKind = "line"
+	CommentBlock CommentKind = "block"
+	// CommentDoc doc comments such as `/** */`, `///` and python docstrings
+	CommentDoc CommentKind = "doc"
+)
+
+/*
+Comment line comments, block comments and docstrings
+
+	// Foo does foo.          -> text: Foo does foo., owner: signature of Foo
+	func Foo() {}
+*/
+type Comment struct {
+	// raw content, with comment markers
+	Content string `json:"content" bson:"content"`
+	// content without comment markers
+	Text string      `json:"text" bson:"text"`
+	Kind CommentKind `json:"kind" bson:"kind"`
+	Span core.Span   `json:"span" bson:"span"`
+	// signature of the function or class which this comment attached to, empty if none
+	Owner string `json:"owner" bson:"owner"`
+
+	// language
+	Lang core.LangType `json:"lang" bson:"lang"`
+}
+
+func NewComment(content string) *Comment {
+	ret := &Comment{
+		Content: content,
+		Text:    CleanComment(content),
+	}
+	switch {
+	case strings.HasPrefix(content, "/**") && content != "/**/",
+		strings.HasPrefix(content, "///"),
+		strings.HasPrefix(content, "//!"),
+		strings.HasPrefix(content, `"""`),
+		strings.HasPrefix(content, "'''"):
+		ret.Kind = CommentDoc
+	case strings.HasPrefix(content, "/*"), strings.HasPrefix(content, "=begin"):
+		ret.Kind = CommentBlock
+	default:
+		ret.Kind = CommentLine
+	}
+	return ret
+}
+
+func (c *Comment) GetIndexName() string {
+	return c.Owner
+}
+
+func (c *Comment) GetDesc() string {
+	return fmt.Sprintf("<comment %s %s>", c.Kind, c.Text)
+}
+
+func (c *Comment) GetSpan() *core.Span {
+	return &c.Span
+}
+
+// CleanComment remove comment markers, eg: `// abc` -> `abc`
+func CleanComment(content string) string {
+	content = strings.TrimSpace(content)
+	var block, docstring bool
+	switch {
+	case strings.HasPrefix(content, "/*"):
+		block = true
+		content = strings.TrimSuffix(strings.TrimLeft(content, "/*!"), "*/")
+	case strings.HasPrefix(content, "=begin"):
+		block = true
+		content = strings.TrimSuffix(strings.TrimPrefix(content, "=begin"), "=end")
+	default:
+		// python docstrings, `r"""abc"""`
+		trimmed := strings.TrimLeft(content, "rRuUbB")
+		for _, quote := range []string{`"""`, "'''", `"`, "'"} {
+			if strings.HasPrefix(trimmed, quote) && strings.HasSuffix(trimmed, quote) && len(trimmed) >= 2*len(quote) {
+				docstring = true
+				content = trimmed[len(quote) : len(trimmed)-len(quote)]
+				break
+			}
+		}
+	}
+
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case docstring:
+		case block:
+			line = strings.TrimLeft(line, "*")
+		case strings.HasPrefix(line, "//"):
+			line = strings.TrimLeft(line, "/!")
+		case strings.HasPrefix(line, "#"):
+			line = strings.TrimLeft(line, "#")
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+/*
+CommentRule how comments are attached to declarations in a language.
+
+The nearest following function or class will be used. Comments and something like annotations (skipped)
+can be put between them, but blank lines can not. Decorators and exports wrapping the declaration are also supported.
+
+	// comment            -> attached to abc
+	@decorator
+	def abc():
+*/
+type CommentRule struct {
+	IsComment func(*core.Unit) bool
+	// units which can be put between comments and declarations, comments by default
+	IsSkipped func(*core.Unit) bool
+	// functions and classes
+	IsOwner func(*core.Unit) bool
+}
+
+// CommentExtractor extractors which comments can be extracted by
+type CommentExtractor interface {
+	DeclExtractor
+	IsComment(*core.Unit) bool
+}
+
+// NewCommentRule comments attached to the nearest function or class of this extractor
+func NewCommentRule(extractor CommentExtractor) *CommentRule {
+	return &CommentRule{
+		IsComment: extractor.IsComment,
+		IsOwner: func(unit *core.Unit) bool {
+			return extractor.IsFunction(unit) || extractor.IsClass(unit)
+		},
+	}
+}
+
+// ExtractComments and their owners with this rule, NewCommentRule will be used if rule is nil
+func ExtractComments(extractor CommentExtractor, rule *CommentRule, units []*core.Unit) ([]*Comment, error) {
+	if rule == nil {
+		rule = NewCommentRule(extractor)
+	}
+	ret := make([]*Comment, 0)
+	owners := NewDeclSignatures(extractor)
+	for _, eachUnit := range units {
+		if !extractor.IsComment(eachUnit) {
+			continue
+		}
+		comment := NewComment(eachUnit.Content)
+		comment.Span = eachUnit.Span
+		comment.Lang = extractor.GetLang()
+		if owner := rule.FindOwner(eachUnit); owner != nil {
+			signature, err := owners.Get(owner)
+			if err != nil {
+				return nil, err
+			}
+			comment.Owner = signature
+		}
+		ret = append(ret, comment)
+	}
+	return ret, nil
+}
+
+// FindOwner the declaration which this comment attached to, nil if none
+func (r *CommentRule) FindOwner(comment *core.Unit) *core.Unit {
+	// trailing comments, `a := 1 // comment`
+	if prev := core.FindPrevSibling(comment); prev != nil && prev.Span.End.Row == comment.Span.Start.Row {
+		return nil
+	}
+	cur := comment
+	for {
+		next := core.FindNextSibling(cur)
+		if next == nil && r.isAbsorbed(cur) {
+			next = core.FindNextSibling(cur.ParentUnit)
+		}
+		if next == nil || next.Span.Start.Row > cur.Span.End.Row+1 {
+			return nil
+		}
+		if r.IsOwner(next) {
+			return next
+		}
+		if r.isSkipped(next) {
+			cur = next
+			continue
+		}
+		// wrapped
+		for _, each := range next.SubUnits {
+			if r.IsOwner(each) {
+				return each
+			}
+		}
+		return nil
+	}
+}
+
+// FindDocs comments attached to this declaration
+func (r *CommentRule) FindDocs(unit *core.Unit) []*core.Unit {
+	var candidates []*core.Unit
+	for _, start := range []*core.Unit{unit, unit.ParentUnit} {
+		for prev := core.FindPrevSibling(start); prev != nil; prev = core.FindPrevSibling(prev) {
+			if !r.isSkipped(prev) {
+				candidates = append(candidates, r.absorbedComments(prev)...)
+				break
+			}
+			if r.IsComment(prev) {
+				candidates = append(candidates, prev)
+			}
+		}
+	}
+
+	var ret []*core.Unit
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if r.FindOwner(candidates[i]) == unit {
+			ret = append(ret, candidates[i])
+		}
+	}
+	return ret
+}
+
+// FindDoc text of the comments attached to this declaration, one comment per line
+func (r *CommentRule) FindDoc(unit *core.Unit) string {
+	docs := r.FindDocs(unit)
+	texts := make([]string, 0, len(docs))
+	for _, each := range docs {
+		texts = append(texts, CleanComment(each.Content))
+	}
+	return strings.Join(texts, "\n")
+}
+
+// isAbsorbed comments at the end of the previous node, eg: `package a /** doc */` in kotlin
+func (r *CommentRule) isAbsorbed(comment *core.Unit) bool {
+	parent := comment.ParentUnit
+	return parent != nil && r.IsComment(comment) && parent.Span.End == comment.Span.End
+}
+
+// absorbedComments comments at the end of this node, the last one first
+func (r *CommentRule) absorbedComments(unit *core.Unit) []*core.Unit {
+	var ret []*core.Unit
+	for i := len(unit.SubUnits) - 1; i >= 0; i-- {
+		each := unit.SubUnits[i]
+		if !r.IsComment(each) || each.Span.End.After(unit.Span.End) || (len(ret) == 0 && !r.isAbsorbed(each)) {
+			break
+		}
+		ret = append(ret, each)
+	}
+	return ret
+}
+
+func (r *CommentRule) isSkipped(unit *core.Unit) bool {
+	if r.IsSkipped != nil {
+		return r.IsSkipped(unit)
+	}
+	return r.IsComment(unit)
+}
@@ -0,0 +1,51 @@
+package object
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+// DeclExtractor extracts functions and classes, which comments and symbols belong to
+type DeclExtractor interface {
+	GetLang() core.LangType
+	IsFunction(*core.Unit) bool
+	IsClass(*core.Unit) bool
+	ExtractFunction(*core.Unit) (*Function, error)
+	ExtractClass(*core.Unit) (*Clazz, error)
+}
+
+// DeclSignatures signatures of functions and classes, cached by unit.
+// A declaration usually owns many comments or symbols, it should be extracted only once.
+type DeclSignatures struct {
+	extractor DeclExtractor
+	cache     map[*core.Unit]string
+}
+
+func NewDeclSignatures(extractor DeclExtractor) *DeclSignatures {
+	return &DeclSignatures{
+		extractor: extractor,
+		cache:     make(map[*core.Unit]string),
+	}
+}
+
+// Get signature of this function or class
+func (s *DeclSignatures) Get(unit *core.Unit) (string, error) {
+	if signature, ok := s.cache[unit]; ok {
+		return signature, nil
+	}
+	var signature string
+	if s.extractor.IsFunction(unit) {
+		f, err := s.extractor.ExtractFunction(unit)
+		if err != nil {
+			return "", err
+		}
+		signature = f.GetSignature()
+	} else {
+		clazz, err := s.extractor.ExtractClass(unit)
+		if err != nil {
+			return "", err
+		}
+		signature = clazz.GetSignature()
+	}
+	s.cache[unit] = signature
+	return signature, nil
+}
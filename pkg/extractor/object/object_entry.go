@@ -0,0 +1,218 @@
+package object
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+type EntryKind = string
+
+const (
+	// EntryKindHttp http handlers, registered by annotations, decorators or router calls
+	EntryKindHttp EntryKind = "http"
+	// EntryKindSchedule scheduled jobs, eg: `@Scheduled` and cron
+	EntryKindSchedule EntryKind = "schedule"
+)
+
+type EntryFramework = string
+
+const (
+	EntryFrameworkSpring  EntryFramework = "spring"
+	EntryFrameworkJaxRs   EntryFramework = "jax-rs"
+	EntryFrameworkGin     EntryFramework = "gin"
+	EntryFrameworkEcho    EntryFramework = "echo"
+	EntryFrameworkNetHttp EntryFramework = "net/http"
+	EntryFrameworkCron    EntryFramework = "cron"
+	EntryFrameworkFlask   EntryFramework = "flask"
+	EntryFrameworkFastAPI EntryFramework = "fastapi"
+	EntryFrameworkExpress EntryFramework = "express"
+)
+
+/*
+EntryPoint where a function can be reached from outside
+
+	@GetMapping("/users")       -> http, spring, GET, /users
+	r.POST("/users", create)    -> http, gin, POST, /users, create
+	@Scheduled(cron = "0 0 *")  -> schedule, spring, "", 0 0 *
+*/
+type EntryPoint struct {
+	Kind      EntryKind      `json:"kind" bson:"kind"`
+	Framework EntryFramework `json:"framework" bson:"framework"`
+	// Method http method in upper case, empty means any
+	Method string `json:"method" bson:"method"`
+	// Route path of http handlers, or spec of scheduled jobs
+	Route string `json:"route" bson:"route"`
+	// Handler as it was written in the registration, eg: `listUsers`, `h.List`
+	Handler string `json:"handler" bson:"handler"`
+	// Span of the registration
+	Span core.Span `json:"span" bson:"span"`
+
+	// ptr to the handler Unit, if it can be found in place, eg: annotated methods and closures
+	Unit *core.Unit `json:"-" bson:"-"`
+}
+
+// HandlerName the name of handler function, `List` of `h.List`
+func (e *EntryPoint) HandlerName() string {
+	name := e.Handler
+	if index := strings.LastIndexAny(name, ".:"); index != -1 {
+		name = name[index+1:]
+	}
+	return name
+}
+
+func (f *Function) IsEntryPoint() bool {
+	return len(f.EntryPoints) != 0
+}
+
+// JoinRoute join the prefix of groups or classes, and the route
+func JoinRoute(prefix string, route string) string {
+	if prefix == "" {
+		return route
+	}
+	if route == "" {
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(route, "/")
+}
+
+var requestMethodRegex = regexp.MustCompile(`RequestMethod\.(\w+)`)
+
+/*
+NewAnnotationEntryPoints from annotations of java and kotlin methods, with annotations of their classes
+
+- spring: `@RequestMapping`, `@GetMapping` and so on
+- jax-rs: `@Path` and `@GET` and so on
+- spring scheduled jobs: `@Scheduled`
+*/
+func NewAnnotationEntryPoints(classAnnotations []string, annotations []string) []*EntryPoint {
+	var springPrefix, jaxRsPrefix string
+	for _, each := range classAnnotations {
+		name, args := parseAnnotation(each)
+		switch name {
+		case "RequestMapping":
+			springPrefix = annotationRoute(args)
+		case "Path":
+			jaxRsPrefix = annotationRoute(args)
+		}
+	}
+
+	var ret []*EntryPoint
+	var jaxRsMethod, jaxRsRoute string
+	for _, each := range annotations {
+		name, args := parseAnnotation(each)
+		switch name {
+		case "RequestMapping":
+			methods := requestMethodRegex.FindAllStringSubmatch(args["method"], -1)
+			if len(methods) == 0 {
+				methods = [][]string{{"", ""}}
+			}
+			for _, method := range methods {
+				ret = append(ret, &EntryPoint{
+					Kind:      EntryKindHttp,
+					Framework: EntryFrameworkSpring,
+					Method:    method[1],
+					Route:     JoinRoute(springPrefix, annotationRoute(args)),
+				})
+			}
+		case "GetMapping", "PostMapping", "PutMapping", "DeleteMapping", "PatchMapping":
+			ret = append(ret, &EntryPoint{
+				Kind:      EntryKindHttp,
+				Framework: EntryFrameworkSpring,
+				Method:    strings.ToUpper(strings.TrimSuffix(name, "Mapping")),
+				Route:     JoinRoute(springPrefix, annotationRoute(args)),
+			})
+		case "GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS":
+			jaxRsMethod = name
+		case "Path":
+			jaxRsRoute = annotationRoute(args)
+		case "Scheduled":
+			route := strings.Trim(args["cron"], "\"")
+			if route == "" {
+				// fixedRate, fixedDelay and so on
+				route = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(each, "@"+name), "("), ")")
+			}
+			ret = append(ret, &EntryPoint{
+				Kind:      EntryKindSchedule,
+				Framework: EntryFrameworkSpring,
+				Route:     route,
+			})
+		}
+	}
+	// methods with only `@Path` are sub-resource locators, not handlers
+	if jaxRsMethod != "" {
+		ret = append(ret, &EntryPoint{
+			Kind:      EntryKindHttp,
+			Framework: EntryFrameworkJaxRs,
+			Method:    jaxRsMethod,
+			Route:     JoinRoute(jaxRsPrefix, jaxRsRoute),
+		})
+	}
+	return ret
+}
+
+// annotationRoute `value`, `path` or the positional one, the first route of arrays
+func annotationRoute(args map[string]string) string {
+	for _, key := range []string{"", "value", "path"} {
+		if v, ok := args[key]; ok {
+			v = strings.Trim(v, "{}[] ")
+			if index := strings.Index(v, ","); index != -1 {
+				v = v[:index]
+			}
+			return strings.Trim(strings.TrimSpace(v), "\"")
+		}
+	}
+	return ""
+}
+
+// parseAnnotation `@a.b.Name(x, k = v)` -> Name, {"": x, k: v}
+func parseAnnotation(annotation string) (string, map[string]string) {
+	name := strings.TrimPrefix(strings.TrimSpace(annotation), "@")
+	argsStr := ""
+	if index := strings.Index(name, "("); index != -1 {
+		argsStr = strings.TrimSuffix(name[index+1:], ")")
+		name = name[:index]
+	}
+	if index := strings.LastIndex(name, "."); index != -1 {
+		name = name[index+1:]
+	}
+
+	args := make(map[string]string)
+	for _, each := range splitTopLevel(argsStr) {
+		key, value := "", each
+		if index := strings.Index(each, "="); index != -1 && !strings.ContainsAny(each[:index], "\"{[(") {
+			key, value = strings.TrimSpace(each[:index]), each[index+1:]
+		}
+		if _, ok := args[key]; !ok {
+			args[key] = strings.TrimSpace(value)
+		}
+	}
+	return strings.TrimSpace(name), args
+}
+
+// splitTopLevel split by commas outside quotes and brackets
+func splitTopLevel(s string) []string {
+	var ret []string
+	depth := 0
+	quoted := false
+	start := 0
+	for i, c := range s {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(' || c == '{' || c == '[':
+			depth++
+		case c == ')' || c == '}' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			ret = append(ret, s[start:i])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(s[start:]) != "" {
+		ret = append(ret, s[start:])
+	}
+	return ret
+}
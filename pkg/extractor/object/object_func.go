@@ -12,6 +12,13 @@ type FuncSignature = string
 type ValueUnit struct {
 	Type string `json:"type"`
 	Name string `json:"name"`
+	// Default value of optional parameters, eg: `1` in `b: Int = 1`
+	Default string `json:"default,omitempty"`
+}
+
+// IsVariadic `String...` in java, `...string` in golang, `...` in c
+func (v *ValueUnit) IsVariadic() bool {
+	return strings.HasPrefix(v.Type, "...") || strings.HasSuffix(v.Type, "...")
 }
 
 const DescSplit = "|,|"
@@ -36,6 +43,16 @@ type Function struct {
 	// which contains language-specific contents
 	Extras interface{} `json:"extras" bson:"extras,omitempty"`
 
+	// complexity, length and so on
+	Metrics *FuncMetrics `json:"metrics" bson:"metrics,omitempty"`
+
+	// hash of body without comments and formats, and hash of signature
+	BodyHash      string `json:"bodyHash" bson:"bodyHash"`
+	SignatureHash string `json:"signatureHash" bson:"signatureHash"`
+
+	// where this function can be reached from outside, eg: http handlers
+	EntryPoints []*EntryPoint `json:"entryPoints" bson:"entryPoints,omitempty"`
+
 	// ptr to origin Unit
 	Unit *core.Unit `json:"-" bson:"-"`
 
@@ -90,3 +107,23 @@ func (f *Function) GetSpan() *core.Span {
 func (f *Function) GetUnit() *core.Unit {
 	return f.Unit
 }
+
+// Arity min and max count of arguments it accepts, max is -1 for variadic functions
+func (f *Function) Arity() (int, int) {
+	minCount, maxCount := 0, len(f.Parameters)
+	for _, each := range f.Parameters {
+		switch {
+		case each.IsVariadic():
+			maxCount = -1
+		case each.Default == "":
+			minCount++
+		}
+	}
+	return minCount, maxCount
+}
+
+// AcceptArgs whether it can be called with this count of arguments
+func (f *Function) AcceptArgs(count int) bool {
+	minCount, maxCount := f.Arity()
+	return count >= minCount && (maxCount == -1 || count <= maxCount)
+}
@@ -0,0 +1,168 @@
+package object
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+/*
+Hash md5 of normalized contents, stable across revisions if nothing but formats and comments changed.
+
+	func f() {          ->  {return1}
+		// comment
+		return  1
+	}
+*/
+func Hash(content string) string {
+	sum := md5.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// FillHashes body hash and signature hash
+func (f *Function) FillHashes(isComment func(*core.Unit) bool) {
+	f.SignatureHash = Hash(f.GetSignature())
+	if f.Unit == nil {
+		return
+	}
+	f.BodyHash = Hash(NormalizeContent(f.Unit, f.GetBodySpan(), isComment))
+}
+
+// GetBodySpan body span, or the whole span if no body found.
+// Some languages have no body spans, and functions without bodies
+func (f *Function) GetBodySpan() core.Span {
+	if f.BodySpan.Start == f.BodySpan.End && f.Unit != nil {
+		return f.Unit.Span
+	}
+	return f.BodySpan
+}
+
+// FillHashes body hash and signature hash
+func (c *Clazz) FillHashes(isComment func(*core.Unit) bool) {
+	c.SignatureHash = Hash(c.GetSignature())
+	if c.Unit == nil {
+		return
+	}
+	c.BodyHash = Hash(NormalizeContent(c.Unit, c.Unit.Span, isComment))
+}
+
+// NormalizeContent content of unit inside span, without comments and formats.
+// Whitespaces are removed, except the ones between words: `return  a + b` -> `return a+b`
+func NormalizeContent(unit *core.Unit, span core.Span, isComment func(*core.Unit) bool) string {
+	return normalize(unit, span, isComment, false)
+}
+
+// NormalizeIdentifiers like NormalizeContent, and all the identifiers are replaced with `$`: `return a + b` -> `return$+$`
+func NormalizeIdentifiers(unit *core.Unit, span core.Span, isComment func(*core.Unit) bool) string {
+	return normalize(unit, span, isComment, true)
+}
+
+// IsIdentifier leaves which name something, eg: identifier, type_identifier, php name
+func IsIdentifier(unit *core.Unit) bool {
+	if len(unit.SubUnits) != 0 {
+		return false
+	}
+	return strings.HasSuffix(unit.Kind, "identifier") || unit.Kind == "name" || unit.Kind == "constant"
+}
+
+func normalize(unit *core.Unit, span core.Span, isComment func(*core.Unit) bool, renamed bool) string {
+	var comments []*core.Unit
+	var identifiers []*core.Unit
+	var walk func(*core.Unit)
+	walk = func(cur *core.Unit) {
+		for _, each := range cur.SubUnits {
+			if isComment(each) {
+				comments = append(comments, each)
+				continue
+			}
+			if renamed && IsIdentifier(each) {
+				identifiers = append(identifiers, each)
+				continue
+			}
+			walk(each)
+		}
+	}
+	walk(unit)
+	lines := blankUnits(unit, comments)
+	lines = markUnits(unit, lines, identifiers, "$")
+
+	var b strings.Builder
+	var prev rune
+	space := false
+	for i, line := range lines {
+		start, end, ok := columnsIn(unit, i, line, span)
+		if !ok {
+			continue
+		}
+		for _, r := range line[start:end] {
+			if unicode.IsSpace(r) {
+				space = true
+				continue
+			}
+			if space && isWordRune(prev) && isWordRune(r) {
+				b.WriteRune(' ')
+			}
+			b.WriteRune(r)
+			prev = r
+			space = false
+		}
+		space = true
+	}
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// blankUnits lines of unit, with contents of subs replaced by spaces
+func blankUnits(unit *core.Unit, subs []*core.Unit) []string {
+	return markUnits(unit, strings.Split(unit.Content, "\n"), subs, "")
+}
+
+// markUnits replace contents of subs with a mark, and keep columns by padding spaces
+func markUnits(unit *core.Unit, lines []string, subs []*core.Unit, mark string) []string {
+	for _, each := range subs {
+		marked := false
+		for i, line := range lines {
+			start, end, ok := columnsIn(unit, i, line, each.Span)
+			if !ok {
+				continue
+			}
+			replacement := strings.Repeat(" ", end-start)
+			if !marked && len(mark) <= end-start {
+				replacement = mark + replacement[len(mark):]
+				marked = true
+			}
+			lines[i] = line[:start] + replacement + line[end:]
+		}
+	}
+	return lines
+}
+
+// columnsIn [start, end) of the line (the index-th line of unit) covered by span
+func columnsIn(unit *core.Unit, index int, line string, span core.Span) (int, int, bool) {
+	row := unit.Span.Start.Row + uint32(index)
+	if row < span.Start.Row || row > span.End.Row {
+		return 0, 0, false
+	}
+	// columns of the first line are shifted
+	offset := 0
+	if index == 0 {
+		offset = int(unit.Span.Start.Column)
+	}
+	start, end := 0, len(line)
+	if row == span.Start.Row {
+		start = int(span.Start.Column) - offset
+	}
+	if row == span.End.Row {
+		end = int(span.End.Column) - offset
+	}
+	if start < 0 || end > len(line) || start > end {
+		return 0, 0, false
+	}
+	return start, end, true
+}
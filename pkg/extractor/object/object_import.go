@@ -0,0 +1,86 @@
+package object
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+/*
+Import dependency statements
+
+	import static org.junit.Assert.*;     -> source: org.junit.Assert, static, wildcard
+	from os import path as p, sep         -> source: os, names: [path, sep], aliases: {path: p}
+	import f "fmt"                        -> source: fmt, alias: f
+*/
+type Import struct {
+	// module, package or file path which has been imported
+	Source string `json:"source" bson:"source"`
+	// alias of the whole source, eg: `np` in `import numpy as np`
+	Alias string `json:"alias" bson:"alias"`
+	// names imported from source, empty means the source itself
+	Names []string `json:"names" bson:"names"`
+	// name -> alias, eg: `{HashSet: Set}` in `use std::collections::{HashMap, HashSet as Set};`
+	Aliases  map[string]string `json:"aliases" bson:"aliases"`
+	Static   bool              `json:"static" bson:"static"`
+	Wildcard bool              `json:"wildcard" bson:"wildcard"`
+	Span     core.Span         `json:"span" bson:"span"`
+
+	// language
+	Lang core.LangType `json:"lang" bson:"lang"`
+}
+
+func NewImport() *Import {
+	return &Import{
+		Names:   make([]string, 0),
+		Aliases: make(map[string]string),
+	}
+}
+
+func (i *Import) GetIndexName() string {
+	return i.Source
+}
+
+func (i *Import) GetDesc() string {
+	return fmt.Sprintf("<import %s %v>", i.Source, i.Names)
+}
+
+func (i *Import) GetSpan() *core.Span {
+	return &i.Span
+}
+
+// AddName add an imported name, with an optional alias
+func (i *Import) AddName(name string, alias string) {
+	i.Names = append(i.Names, name)
+	if alias != "" {
+		i.Aliases[name] = alias
+	}
+}
+
+// LocalNames names which this import brings into the file.
+// `import numpy as np` -> np, `import java.util.List` -> List, `import "a/b"` -> b, wildcards bring nothing
+func (i *Import) LocalNames() []string {
+	if i.Alias != "" {
+		return []string{i.Alias}
+	}
+	ret := make([]string, 0, len(i.Names))
+	for _, each := range i.Names {
+		if alias, ok := i.Aliases[each]; ok {
+			ret = append(ret, alias)
+		} else {
+			ret = append(ret, each)
+		}
+	}
+	if len(ret) != 0 || i.Wildcard {
+		return ret
+	}
+	source := strings.TrimRight(i.Source, "/.:")
+	if index := strings.LastIndexAny(source, "/.:"); index != -1 {
+		source = source[index+1:]
+	}
+	if source != "" {
+		ret = append(ret, source)
+	}
+	return ret
+}
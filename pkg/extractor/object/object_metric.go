@@ -0,0 +1,184 @@
+package object
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"golang.org/x/exp/slices"
+)
+
+// FuncMetrics code metrics of a function
+type FuncMetrics struct {
+	// 1 + decision points + logical operators
+	Cyclomatic int `json:"cyclomatic" bson:"cyclomatic"`
+	// https://www.sonarsource.com/docs/CognitiveComplexity.pdf , simplified
+	Cognitive int `json:"cognitive" bson:"cognitive"`
+	// lines of code, excluding blank lines and comments
+	Loc         int `json:"loc" bson:"loc"`
+	MaxNesting  int `json:"maxNesting" bson:"maxNesting"`
+	ParamCount  int `json:"paramCount" bson:"paramCount"`
+	ReturnCount int `json:"returnCount" bson:"returnCount"`
+}
+
+/*
+MetricRule node kinds which metrics are computed from, in a language.
+
+	func f(a int) int {         -> params: 1
+		if a > 0 && a < 3 {      -> cyclomatic: +2, cognitive: +2
+			for {                -> cyclomatic: +1, cognitive: +2 (nesting 1)
+			}
+		} else if a < 0 {        -> cyclomatic: +1, cognitive: +1
+			return 1             -> returns: 1
+		}
+		return 0                 -> returns: 2
+	}
+*/
+type MetricRule struct {
+	// decision points, eg: if, for, while, case, catch, ternary
+	PathKinds []core.KindRepr
+	// structures which break the linear flow and increase nesting, eg: if, for, switch, catch
+	FlowKinds []core.KindRepr
+	// else-if clauses which have their own kinds, eg: python elif_clause
+	ElseIfKinds []core.KindRepr
+	// nested functions, which increase nesting and own their returns
+	LambdaKinds []core.KindRepr
+	// binary expressions, `&&` and `||` inside them are decision points
+	BinaryKinds []core.KindRepr
+	// logical expressions which have their own kinds, eg: python boolean_operator
+	LogicalKinds []core.KindRepr
+	// statements start with `return`, eg: kotlin jump_expression
+	ReturnKinds  []core.KindRepr
+	CommentKinds []core.KindRepr
+	// counts params from the function unit, for languages whose params are not extracted
+	ParamCounter func(*core.Unit) int
+}
+
+var logicalOperators = []string{"&&", "||", "and", "or"}
+
+// Compute metrics of a function from its unit
+func (r *MetricRule) Compute(f *Function) *FuncMetrics {
+	ret := &FuncMetrics{
+		Cyclomatic: 1,
+		ParamCount: len(f.Parameters),
+	}
+	if f.Unit == nil {
+		return ret
+	}
+	if r.ParamCounter != nil && len(f.Parameters) == 0 {
+		ret.ParamCount = r.ParamCounter(f.Unit)
+	}
+	var comments []*core.Unit
+	var walk func(unit *core.Unit, nesting int, inLambda bool)
+	walk = func(unit *core.Unit, nesting int, inLambda bool) {
+		childNesting := nesting
+		switch {
+		case slices.Contains(r.CommentKinds, unit.Kind):
+			comments = append(comments, unit)
+		case slices.Contains(r.ReturnKinds, unit.Kind):
+			if !inLambda && leadingWord(unit.Content) == "return" {
+				ret.ReturnCount++
+			}
+		case slices.Contains(r.LambdaKinds, unit.Kind):
+			inLambda = true
+			childNesting++
+		case r.isLogical(unit):
+			ret.Cyclomatic++
+			// sequences of the same operator only count once, eg: `a && b && c`
+			if unit.ParentUnit == nil || !r.isLogical(unit.ParentUnit) || operatorOf(unit) != operatorOf(unit.ParentUnit) {
+				ret.Cognitive++
+			}
+		}
+		if slices.Contains(r.PathKinds, unit.Kind) && !isDefaultBranch(unit) {
+			ret.Cyclomatic++
+		}
+		if slices.Contains(r.ElseIfKinds, unit.Kind) {
+			ret.Cognitive++
+		}
+		if slices.Contains(r.FlowKinds, unit.Kind) {
+			if isElseIf(unit) {
+				ret.Cognitive++
+			} else {
+				ret.Cognitive += 1 + nesting
+				childNesting++
+			}
+		}
+		if childNesting > ret.MaxNesting {
+			ret.MaxNesting = childNesting
+		}
+		for _, each := range unit.SubUnits {
+			walk(each, childNesting, inLambda)
+		}
+	}
+	for _, each := range f.Unit.SubUnits {
+		walk(each, 0, false)
+	}
+	ret.Loc = countLoc(f.Unit, comments)
+	return ret
+}
+
+func (r *MetricRule) isLogical(unit *core.Unit) bool {
+	if slices.Contains(r.LogicalKinds, unit.Kind) {
+		return true
+	}
+	return slices.Contains(r.BinaryKinds, unit.Kind) && slices.Contains(logicalOperators, operatorOf(unit))
+}
+
+// operatorOf logical operator of a binary expression, eg: `a && b` -> `&&`.
+// Operators are not named nodes, so they are located by contents.
+func operatorOf(unit *core.Unit) string {
+	if len(unit.SubUnits) < 2 {
+		return ""
+	}
+	rest := strings.TrimSpace(strings.TrimPrefix(unit.Content, unit.SubUnits[0].Content))
+	for _, each := range logicalOperators {
+		if !strings.HasPrefix(rest, each) {
+			continue
+		}
+		// `and` and `or` are words
+		if len(each) > 2 && len(rest) > len(each) && !strings.ContainsAny(rest[len(each):len(each)+1], " \t\n(") {
+			continue
+		}
+		return each
+	}
+	return ""
+}
+
+// isDefaultBranch default labels are not decision points, eg: `default:`, kotlin `else ->`
+func isDefaultBranch(unit *core.Unit) bool {
+	word := leadingWord(unit.Content)
+	return word == "default" || word == "else"
+}
+
+// leadingWord eg: `return@label x` -> `return`
+func leadingWord(content string) string {
+	if end := strings.IndexFunc(content, func(r rune) bool { return !unicode.IsLetter(r) }); end != -1 {
+		return content[:end]
+	}
+	return content
+}
+
+// isElseIf `else if` has the same kind as `if`, and it is the last part of its parent if.
+// It can be wrapped by an else clause, eg: javascript else_clause
+func isElseIf(unit *core.Unit) bool {
+	cur := unit
+	parent := unit.ParentUnit
+	if parent != nil && parent.Kind != unit.Kind && len(parent.SubUnits) == 1 {
+		cur = parent
+		parent = parent.ParentUnit
+	}
+	if parent == nil || parent.Kind != unit.Kind {
+		return false
+	}
+	return parent.SubUnits[len(parent.SubUnits)-1] == cur
+}
+
+func countLoc(unit *core.Unit, comments []*core.Unit) int {
+	ret := 0
+	for _, each := range blankUnits(unit, comments) {
+		if strings.TrimSpace(each) != "" {
+			ret++
+		}
+	}
+	return ret
+}
@@ -0,0 +1,100 @@
+package object
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+type Resolution = string
+
+const (
+	// ResolutionLocal parameters and local variables of enclosing scopes
+	ResolutionLocal Resolution = "local"
+	// ResolutionImported names brought in by imports
+	ResolutionImported Resolution = "imported"
+	// ResolutionUnresolved globals, members and anything else which can not be resolved inside this file
+	ResolutionUnresolved Resolution = "unresolved"
+)
+
+/*
+ScopeResolver resolve the symbols of a file with lexical scopes
+
+	import "fmt"
+
+	func handle() {}
+
+	func f(handle int) {      -> handle: local
+		fmt.Println(handle)   -> fmt: imported, Println: unresolved (member), handle: local
+		other()               -> other: unresolved
+	}
+
+Local variable definitions are marked as local too, others are left empty.
+*/
+type ScopeResolver struct {
+	// units which open new scopes, eg: functions, blocks and lambdas
+	IsScope func(*core.Unit) bool
+	// names which can only be resolved with types, eg: `b` in `a.b`
+	IsMember func(*Symbol) bool
+}
+
+func (r *ScopeResolver) Resolve(symbols []*Symbol, imports []*Import) {
+	imported := make(map[string]struct{})
+	for _, each := range imports {
+		for _, name := range each.LocalNames() {
+			imported[name] = struct{}{}
+		}
+	}
+
+	// scope -> name -> definitions
+	locals := make(map[*core.Unit]map[string][]*Symbol)
+	for _, each := range symbols {
+		if !each.IsDefinition() || each.SyntaxType != SyntaxTypeVariable {
+			continue
+		}
+		scope := each.FindScope(r.IsScope)
+		if scope == nil {
+			// globals
+			continue
+		}
+		if _, ok := locals[scope]; !ok {
+			locals[scope] = make(map[string][]*Symbol)
+		}
+		locals[scope][each.Symbol] = append(locals[scope][each.Symbol], each)
+		each.Resolution = ResolutionLocal
+	}
+
+	for _, each := range symbols {
+		if each.IsDefinition() {
+			continue
+		}
+		switch {
+		case r.IsMember != nil && r.IsMember(each):
+			each.Resolution = ResolutionUnresolved
+		case r.isLocal(each, locals):
+			each.Resolution = ResolutionLocal
+		default:
+			if _, ok := imported[each.Symbol]; ok {
+				each.Resolution = ResolutionImported
+			} else {
+				each.Resolution = ResolutionUnresolved
+			}
+		}
+	}
+}
+
+// isLocal defined in one of the enclosing scopes, before this reference
+func (r *ScopeResolver) isLocal(symbol *Symbol, locals map[*core.Unit]map[string][]*Symbol) bool {
+	if symbol.Unit == nil {
+		return false
+	}
+	for cur := symbol.Unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		if !r.IsScope(cur) {
+			continue
+		}
+		for _, each := range locals[cur][symbol.Symbol] {
+			if !each.Span.Start.After(symbol.Span.Start) {
+				return true
+			}
+		}
+	}
+	return false
+}
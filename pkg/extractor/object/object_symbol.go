@@ -6,6 +6,25 @@ import (
 	"github.com/opensibyl/sibyl2/pkg/core"
 )
 
+type NodeType = string
+
+const (
+	NodeTypeDefinition NodeType = "definition"
+	NodeTypeReference  NodeType = "reference"
+)
+
+type SyntaxType = string
+
+const (
+	SyntaxTypeFunction SyntaxType = "function"
+	SyntaxTypeMethod   SyntaxType = "method"
+	SyntaxTypeClass    SyntaxType = "class"
+	SyntaxTypeModule   SyntaxType = "module"
+	SyntaxTypeCall     SyntaxType = "call"
+	SyntaxTypeType     SyntaxType = "type"
+	SyntaxTypeVariable SyntaxType = "variable"
+)
+
 /*
 Symbol
 Units are named identifiers driven by the ASTs
@@ -41,10 +60,16 @@ https://github.com/github/semantic/blob/main/proto/semantic.proto
 	}
 */
 type Symbol struct {
-	Symbol    string    `json:"symbol"`
-	Kind      string    `json:"kind"`
-	Span      core.Span `json:"span"`
-	FieldName string    `json:"fieldName"`
+	Symbol     string     `json:"symbol"`
+	Kind       string     `json:"kind"`
+	Span       core.Span  `json:"span"`
+	FieldName  string     `json:"fieldName"`
+	NodeType   NodeType   `json:"nodeType"`
+	SyntaxType SyntaxType `json:"syntaxType"`
+	// signature of the nearest function or class containing this symbol, empty if top level
+	Scope string `json:"scope"`
+	// where references point to, see ScopeResolver
+	Resolution Resolution `json:"resolution"`
 
 	// ptr to origin Unit
 	Unit *core.Unit `json:"-"`
@@ -65,3 +90,77 @@ func (s *Symbol) GetSpan() *core.Span {
 func (s *Symbol) GetUnit() *core.Unit {
 	return s.Unit
 }
+
+func (s *Symbol) IsDefinition() bool {
+	return s.NodeType == NodeTypeDefinition
+}
+
+// FindScope the nearest function or class containing this symbol, nil if top level.
+// Names of functions and classes belong to their outer scopes: `func A() {}`, A is not inside A.
+func (s *Symbol) FindScope(isScope func(*core.Unit) bool) *core.Unit {
+	if s.Unit == nil {
+		return nil
+	}
+	cur := s.Unit.ParentUnit
+	if s.IsDefinition() && s.SyntaxType != SyntaxTypeVariable && cur != nil && isScope(cur) {
+		cur = cur.ParentUnit
+	}
+	for ; cur != nil; cur = cur.ParentUnit {
+		if isScope(cur) {
+			return cur
+		}
+	}
+	return nil
+}
+
+// SymbolExtractor extractors which symbols can be extracted and resolved by
+type SymbolExtractor interface {
+	DeclExtractor
+	IsSymbol(*core.Unit) bool
+	ExtractImports([]*core.Unit) ([]*Import, error)
+}
+
+/*
+ExtractSymbols classify each symbol with classify, then resolve them with the imports of this file.
+Scope of a symbol is the signature of the nearest function or class containing it.
+*/
+func ExtractSymbols(
+	extractor SymbolExtractor,
+	units []*core.Unit,
+	classify func(*core.Unit) (NodeType, SyntaxType),
+	resolver *ScopeResolver,
+) ([]*Symbol, error) {
+	isScope := func(unit *core.Unit) bool {
+		return extractor.IsFunction(unit) || extractor.IsClass(unit)
+	}
+	ret := make([]*Symbol, 0)
+	scopes := NewDeclSignatures(extractor)
+	for _, eachUnit := range units {
+		if !extractor.IsSymbol(eachUnit) {
+			continue
+		}
+		symbol := &Symbol{
+			Symbol:    eachUnit.Content,
+			Kind:      eachUnit.Kind,
+			Span:      eachUnit.Span,
+			FieldName: eachUnit.FieldName,
+			Unit:      eachUnit,
+		}
+		symbol.NodeType, symbol.SyntaxType = classify(eachUnit)
+		if scope := symbol.FindScope(isScope); scope != nil {
+			signature, err := scopes.Get(scope)
+			if err != nil {
+				return nil, err
+			}
+			symbol.Scope = signature
+		}
+		ret = append(ret, symbol)
+	}
+
+	imports, err := extractor.ExtractImports(units)
+	if err != nil {
+		return nil, err
+	}
+	resolver.Resolve(ret, imports)
+	return ret, nil
+}
@@ -0,0 +1,56 @@
+package object
+
+import (
+	"strings"
+)
+
+type TestFramework = string
+
+const (
+	// TestFrameworkGo `func TestXxx(t *testing.T)`, benchmarks and fuzz tests in `_test.go`
+	TestFrameworkGo TestFramework = "go"
+	// TestFrameworkJUnit `@Test`, `@ParameterizedTest` and so on, in java and kotlin
+	TestFrameworkJUnit    TestFramework = "junit"
+	TestFrameworkPytest   TestFramework = "pytest"
+	TestFrameworkUnittest TestFramework = "unittest"
+	// TestFrameworkJest `it`, `test` and `describe` blocks. Mocha shares the same syntax.
+	TestFrameworkJest TestFramework = "jest"
+)
+
+// TestExtras extras of the languages which can mark test functions
+type TestExtras interface {
+	GetTestFramework() TestFramework
+}
+
+// GetTestFramework of this function, empty if it is not a test
+func (f *Function) GetTestFramework() TestFramework {
+	switch extras := f.Extras.(type) {
+	case TestExtras:
+		return extras.GetTestFramework()
+	case map[string]any:
+		// decoded from json, eg: uploaded to server
+		framework, _ := extras["testFramework"].(string)
+		return framework
+	}
+	return ""
+}
+
+func (f *Function) IsTest() bool {
+	return f.GetTestFramework() != ""
+}
+
+// IsJUnitAnnotation `@Test`, `@org.junit.jupiter.api.Test`, `@ParameterizedTest(...)` and so on
+func IsJUnitAnnotation(annotation string) bool {
+	name := strings.TrimPrefix(annotation, "@")
+	if index := strings.Index(name, "("); index != -1 {
+		name = name[:index]
+	}
+	if index := strings.LastIndex(name, "."); index != -1 {
+		name = name[index+1:]
+	}
+	switch strings.TrimSpace(name) {
+	case "Test", "ParameterizedTest", "RepeatedTest", "TestFactory", "TestTemplate":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package object
+
+import (
+	"fmt"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+type VariableScope = string
+
+const (
+	VariableScopeModule VariableScope = "module"
+	VariableScopeClass  VariableScope = "class"
+	VariableScopeLocal  VariableScope = "local"
+)
+
+type Mutability = string
+
+const (
+	// MutabilityConst compile-time constants, eg: `const`, `constexpr`, `static final`
+	MutabilityConst Mutability = "const"
+	// MutabilityFinal can not be reassigned, eg: `final`, `val`, `let`, `readonly`
+	MutabilityFinal Mutability = "final"
+	MutabilityVar   Mutability = "var"
+)
+
+/*
+Variable global variables, constants and fields, and local variables if required
+
+	const Max int = 10     -> name: Max, type: int, value: 10, mutability: const, scope: module
+*/
+type Variable struct {
+	Name string `json:"name" bson:"name"`
+	// declared type, empty if inferred
+	Type string `json:"type" bson:"type"`
+	// initializer, empty if not initialized
+	Value      string        `json:"value" bson:"value"`
+	Mutability Mutability    `json:"mutability" bson:"mutability"`
+	Scope      VariableScope `json:"scope" bson:"scope"`
+	Namespace  string        `json:"namespace" bson:"namespace"`
+	// class of fields
+	Receiver string    `json:"receiver" bson:"receiver"`
+	Span     core.Span `json:"span" bson:"span"`
+
+	// language
+	Lang core.LangType `json:"lang" bson:"lang"`
+}
+
+func NewVariable() *Variable {
+	return &Variable{
+		Mutability: MutabilityVar,
+		Scope:      VariableScopeModule,
+	}
+}
+
+func (v *Variable) GetIndexName() string {
+	return v.Name
+}
+
+func (v *Variable) GetDesc() string {
+	return fmt.Sprintf("<variable %s %s %s %s>", v.Scope, v.Mutability, v.Name, v.Type)
+}
+
+func (v *Variable) GetSpan() *core.Span {
+	return &v.Span
+}
@@ -24,6 +24,9 @@ type SymbolFileResult = BaseFileResult[*Symbol]
 type FunctionFileResult = BaseFileResult[*Function]
 type CallFileResult = BaseFileResult[*Call]
 type ClazzFileResult = BaseFileResult[*Clazz]
+type ImportFileResult = BaseFileResult[*Import]
+type CommentFileResult = BaseFileResult[*Comment]
+type VariableFileResult = BaseFileResult[*Variable]
 
 func PathStandardize(results []*FileResult, basedir string) error {
 	for _, each := range results {
@@ -65,3 +68,18 @@ type ClazzWithPath struct {
 	*Clazz `bson:",inline"`
 	Path   string `json:"path"`
 }
+
+type ImportWithPath struct {
+	*Import `bson:",inline"`
+	Path    string `json:"path"`
+}
+
+type CommentWithPath struct {
+	*Comment `bson:",inline"`
+	Path     string `json:"path"`
+}
+
+type VariableWithPath struct {
+	*Variable `bson:",inline"`
+	Path      string `json:"path"`
+}
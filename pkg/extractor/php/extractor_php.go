@@ -0,0 +1,189 @@
+package php
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+// https://github.com/tree-sitter/tree-sitter-php/blob/master/src/node-types.json
+const (
+	KindPhpProgram                core.KindRepr = "program"
+	KindPhpNamespaceDefinition    core.KindRepr = "namespace_definition"
+	KindPhpNamespaceName          core.KindRepr = "namespace_name"
+	KindPhpFunctionDefinition     core.KindRepr = "function_definition"
+	KindPhpMethodDeclaration      core.KindRepr = "method_declaration"
+	KindPhpClassDeclaration       core.KindRepr = "class_declaration"
+	KindPhpInterfaceDeclaration   core.KindRepr = "interface_declaration"
+	KindPhpTraitDeclaration       core.KindRepr = "trait_declaration"
+	KindPhpEnumDeclaration        core.KindRepr = "enum_declaration"
+	KindPhpName                   core.KindRepr = "name"
+	KindPhpVariableName           core.KindRepr = "variable_name"
+	KindPhpFormalParameters       core.KindRepr = "formal_parameters"
+	KindPhpSimpleParameter        core.KindRepr = "simple_parameter"
+	KindPhpVariadicParameter      core.KindRepr = "variadic_parameter"
+	KindPhpPropertyPromotionParam core.KindRepr = "property_promotion_parameter"
+	KindPhpCompoundStatement      core.KindRepr = "compound_statement"
+	KindPhpDeclarationList        core.KindRepr = "declaration_list"
+	KindPhpBaseClause             core.KindRepr = "base_clause"
+	KindPhpClassInterfaceClause   core.KindRepr = "class_interface_clause"
+	KindPhpUseDeclaration         core.KindRepr = "use_declaration"
+	KindPhpPropertyDeclaration    core.KindRepr = "property_declaration"
+	KindPhpPropertyElement        core.KindRepr = "property_element"
+	KindPhpConstDeclaration       core.KindRepr = "const_declaration"
+	KindPhpConstElement           core.KindRepr = "const_element"
+	KindPhpVisibilityModifier     core.KindRepr = "visibility_modifier"
+	KindPhpStaticModifier         core.KindRepr = "static_modifier"
+	KindPhpClassModifier          core.KindRepr = "class_modifier"
+	KindPhpFinalModifier          core.KindRepr = "final_modifier"
+	KindPhpAbstractModifier       core.KindRepr = "abstract_modifier"
+	KindPhpReadonlyModifier       core.KindRepr = "readonly_modifier"
+	KindPhpAttributeList          core.KindRepr = "attribute_list"
+	KindPhpAttribute              core.KindRepr = "attribute"
+	KindPhpComment                core.KindRepr = "comment"
+	KindPhpFunctionCallExpression core.KindRepr = "function_call_expression"
+	KindPhpMemberCallExpression   core.KindRepr = "member_call_expression"
+	KindPhpScopedCallExpression   core.KindRepr = "scoped_call_expression"
+	KindPhpNullsafeMemberCall     core.KindRepr = "nullsafe_member_call_expression"
+	KindPhpArguments              core.KindRepr = "arguments"
+	KindPhpNamespaceUseDecl       core.KindRepr = "namespace_use_declaration"
+	KindPhpNamespaceUseClause     core.KindRepr = "namespace_use_clause"
+	KindPhpNamespaceUseGroup      core.KindRepr = "namespace_use_group"
+	KindPhpNamespaceAliasing      core.KindRepr = "namespace_aliasing_clause"
+	KindPhpString                 core.KindRepr = "string"
+	KindPhpRequire                core.KindRepr = "require_expression"
+	KindPhpRequireOnce            core.KindRepr = "require_once_expression"
+	KindPhpInclude                core.KindRepr = "include_expression"
+	KindPhpIncludeOnce            core.KindRepr = "include_once_expression"
+	KindPhpPropertyInitializer    core.KindRepr = "property_initializer"
+	KindPhpExpressionStatement    core.KindRepr = "expression_statement"
+	KindPhpAssignmentExpression   core.KindRepr = "assignment_expression"
+	KindPhpAnonymousFunction      core.KindRepr = "anonymous_function_creation_expression"
+	KindPhpArrowFunction          core.KindRepr = "arrow_function"
+	KindPhpIfStatement            core.KindRepr = "if_statement"
+	KindPhpElseIfClause           core.KindRepr = "else_if_clause"
+	KindPhpForStatement           core.KindRepr = "for_statement"
+	KindPhpForeachStatement       core.KindRepr = "foreach_statement"
+	KindPhpWhileStatement         core.KindRepr = "while_statement"
+	KindPhpDoStatement            core.KindRepr = "do_statement"
+	KindPhpCaseStatement          core.KindRepr = "case_statement"
+	KindPhpCatchClause            core.KindRepr = "catch_clause"
+	KindPhpConditionalExpression  core.KindRepr = "conditional_expression"
+	KindPhpSwitchStatement        core.KindRepr = "switch_statement"
+	KindPhpBinaryExpression       core.KindRepr = "binary_expression"
+	KindPhpReturnStatement        core.KindRepr = "return_statement"
+	NamespaceSplit                              = "\\"
+)
+
+var modifierKinds = []core.KindRepr{
+	KindPhpVisibilityModifier,
+	KindPhpStaticModifier,
+	KindPhpClassModifier,
+	KindPhpFinalModifier,
+	KindPhpAbstractModifier,
+	KindPhpReadonlyModifier,
+}
+
+var classKinds = map[core.KindRepr]string{
+	KindPhpClassDeclaration:     "class",
+	KindPhpInterfaceDeclaration: "interface",
+	KindPhpTraitDeclaration:     "trait",
+	KindPhpEnumDeclaration:      "enum",
+}
+
+type Extractor struct {
+}
+
+func (extractor *Extractor) GetLang() core.LangType {
+	return core.LangPhp
+}
+
+// findNamespace supports both `namespace A;` and `namespace A { ... }`
+func findNamespace(unit *core.Unit) string {
+	top := unit
+	for cur := unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		if cur.Kind == KindPhpNamespaceDefinition {
+			return namespaceName(cur)
+		}
+		if cur.ParentUnit != nil {
+			top = cur
+		}
+	}
+	if top.ParentUnit == nil {
+		return ""
+	}
+
+	// statement style, the last definition before this unit
+	ret := ""
+	for _, each := range top.ParentUnit.SubUnits {
+		if each == top {
+			break
+		}
+		if each.Kind == KindPhpNamespaceDefinition {
+			ret = namespaceName(each)
+		}
+	}
+	return ret
+}
+
+func namespaceName(unit *core.Unit) string {
+	name := core.FindFirstByKindInSubs(unit, KindPhpNamespaceName)
+	if name == nil {
+		return ""
+	}
+	return name.Content
+}
+
+// findTypeName the closest class, interface or trait around this unit
+func findTypeName(unit *core.Unit) string {
+	for cur := unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		if _, ok := classKinds[cur.Kind]; !ok {
+			continue
+		}
+		name := core.FindFirstByKindInSubs(cur, KindPhpName)
+		if name != nil {
+			return name.Content
+		}
+	}
+	return ""
+}
+
+// findAttributes php 8 attributes
+// old versions of grammar take `#[...]` as comments, which are siblings of this unit.
+func findAttributes(unit *core.Unit) []string {
+	var ret []string
+	for _, eachList := range core.FindAllByKindInSubs(unit, KindPhpAttributeList) {
+		for _, each := range core.FindAllByKindInSubsWithDfs(eachList, KindPhpAttribute) {
+			ret = append(ret, each.Content)
+		}
+	}
+	if len(ret) != 0 || unit.ParentUnit == nil {
+		return ret
+	}
+
+	siblings := unit.ParentUnit.SubUnits
+	index := -1
+	for i, each := range siblings {
+		if each == unit {
+			index = i
+			break
+		}
+	}
+	for i := index - 1; i >= 0; i-- {
+		each := siblings[i]
+		if each.Kind != KindPhpComment || !strings.HasPrefix(each.Content, "#[") {
+			break
+		}
+		content := strings.TrimSuffix(strings.TrimPrefix(each.Content, "#["), "]")
+		ret = append([]string{content}, ret...)
+	}
+	return ret
+}
+
+func findModifiers(unit *core.Unit) []string {
+	var ret []string
+	for _, each := range core.FindAllByKindsInSubs(unit, modifierKinds...) {
+		ret = append(ret, each.Content)
+	}
+	return ret
+}
@@ -0,0 +1,78 @@
+package php
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+var callKinds = []core.KindRepr{
+	KindPhpFunctionCallExpression,
+	KindPhpMemberCallExpression,
+	KindPhpScopedCallExpression,
+	KindPhpNullsafeMemberCall,
+}
+
+func (extractor *Extractor) IsCall(unit *core.Unit) bool {
+	for _, each := range callKinds {
+		if unit.Kind == each {
+			return true
+		}
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractCalls(units []*core.Unit) ([]*object.Call, error) {
+	var ret []*object.Call
+	for _, eachUnit := range units {
+		if !extractor.IsCall(eachUnit) {
+			continue
+		}
+
+		eachCall, err := extractor.unit2Call(eachUnit)
+		if err != nil {
+			core.Log.Warnf("err: %v", err)
+			continue
+		}
+		ret = append(ret, eachCall)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
+	funcUnit := core.FindFirstByOneOfKindInParent(unit, KindPhpFunctionDefinition, KindPhpMethodDeclaration)
+	var srcFunc *object.Function
+	var err error
+	if funcUnit != nil {
+		srcFunc, err = extractor.ExtractFunction(funcUnit)
+		if err != nil {
+			return nil, errors.New("convert func failed: " + funcUnit.Content)
+		}
+	}
+
+	// headless, give up (temp
+	if srcFunc == nil {
+		return nil, errors.New("headless call")
+	}
+
+	// `$this->log("x")` -> `$this->log`
+	caller := unit.Content
+	var arguments []string
+	args := core.FindFirstByKindInSubs(unit, KindPhpArguments)
+	if args != nil {
+		caller = strings.TrimSpace(strings.TrimSuffix(caller, args.Content))
+		for _, each := range args.SubUnits {
+			arguments = append(arguments, each.Content)
+		}
+	}
+
+	ret := &object.Call{
+		Src:       srcFunc.GetSignature(),
+		Caller:    caller,
+		Arguments: arguments,
+		Span:      unit.Span,
+	}
+	return ret, nil
+}
@@ -0,0 +1,124 @@
+package php
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+type ClassField struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Modifiers []string `json:"modifiers"`
+}
+
+type ClassExtras struct {
+	// class, interface, trait or enum
+	Kind       string        `json:"kind"`
+	Attributes []string      `json:"attributes"`
+	Modifiers  []string      `json:"modifiers"`
+	Extends    []string      `json:"extends"`
+	Implements []string      `json:"implements"`
+	Traits     []string      `json:"traits"`
+	Fields     []*ClassField `json:"fields"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsClass(unit *core.Unit) bool {
+	_, ok := classKinds[unit.Kind]
+	return ok
+}
+
+func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
+	var ret []*object.Clazz
+	for _, eachUnit := range units {
+		if !extractor.IsClass(eachUnit) {
+			continue
+		}
+		eachClazz, err := extractor.ExtractClass(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachClazz)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error) {
+	clazz := object.NewClazz()
+	clazz.Span = unit.Span
+	clazz.Lang = extractor.GetLang()
+	clazz.Unit = unit
+	clazz.Module = findNamespace(unit)
+
+	name := core.FindFirstByKindInSubs(unit, KindPhpName)
+	if name == nil {
+		return nil, errors.New("no class name found in " + unit.Content)
+	}
+	clazz.Name = name.Content
+
+	extras := &ClassExtras{
+		Kind:       classKinds[unit.Kind],
+		Attributes: findAttributes(unit),
+		Modifiers:  findModifiers(unit),
+	}
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindPhpBaseClause:
+			for _, eachBase := range each.SubUnits {
+				extras.Extends = append(extras.Extends, eachBase.Content)
+			}
+		case KindPhpClassInterfaceClause:
+			for _, eachBase := range each.SubUnits {
+				extras.Implements = append(extras.Implements, eachBase.Content)
+			}
+		case KindPhpDeclarationList:
+			for _, eachMember := range each.SubUnits {
+				switch eachMember.Kind {
+				case KindPhpUseDeclaration:
+					for _, eachTrait := range eachMember.SubUnits {
+						extras.Traits = append(extras.Traits, eachTrait.Content)
+					}
+				case KindPhpPropertyDeclaration:
+					extras.Fields = append(extras.Fields, extractFields(eachMember, KindPhpPropertyElement, KindPhpVariableName)...)
+				case KindPhpConstDeclaration:
+					extras.Fields = append(extras.Fields, extractFields(eachMember, KindPhpConstElement, KindPhpName)...)
+				}
+			}
+		}
+	}
+	extras.Doc = extractor.commentRule().FindDoc(unit)
+	clazz.Extras = extras
+	return clazz, nil
+}
+
+func extractFields(unit *core.Unit, elementKind core.KindRepr, nameKind core.KindRepr) []*ClassField {
+	var ret []*ClassField
+	modifiers := findModifiers(unit)
+	typeName := ""
+	for _, each := range unit.SubUnits {
+		if each.Kind == elementKind {
+			break
+		}
+		if each.Kind == KindPhpAttributeList || slices.Contains(modifierKinds, each.Kind) {
+			continue
+		}
+		typeName = each.Content
+	}
+
+	for _, each := range core.FindAllByKindInSubs(unit, elementKind) {
+		name := core.FindFirstByKindInSubs(each, nameKind)
+		if name == nil {
+			continue
+		}
+		ret = append(ret, &ClassField{
+			Name:      name.Content,
+			Type:      typeName,
+			Modifiers: modifiers,
+		})
+	}
+	return ret
+}
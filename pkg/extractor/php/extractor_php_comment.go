@@ -0,0 +1,26 @@
+package php
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsComment(unit *core.Unit) bool {
+	// attributes are also parsed as comments, `#[Attr]`
+	return unit.Kind == KindPhpComment && !strings.HasPrefix(unit.Content, "#[")
+}
+
+func (extractor *Extractor) ExtractComments(units []*core.Unit) ([]*object.Comment, error) {
+	return object.ExtractComments(extractor, extractor.commentRule(), units)
+}
+
+func (extractor *Extractor) commentRule() *object.CommentRule {
+	rule := object.NewCommentRule(extractor)
+	// attributes can be put between comments and declarations
+	rule.IsSkipped = func(unit *core.Unit) bool {
+		return unit.Kind == KindPhpComment
+	}
+	return rule
+}
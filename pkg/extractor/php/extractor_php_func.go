@@ -0,0 +1,117 @@
+package php
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type FunctionExtras struct {
+	Attributes []string `json:"attributes"`
+	Modifiers  []string `json:"modifiers"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
+	if unit.Kind == KindPhpFunctionDefinition || unit.Kind == KindPhpMethodDeclaration {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Function, error) {
+	var ret []*object.Function
+	for _, eachUnit := range units {
+		if !extractor.IsFunction(eachUnit) {
+			continue
+		}
+		eachFunc, err := extractor.ExtractFunction(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachFunc)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
+	funcUnit.Span = unit.Span
+	funcUnit.Unit = unit
+	funcUnit.Lang = extractor.GetLang()
+
+	funcUnit.Namespace = findNamespace(unit)
+	// fully qualified class name, eg: App\Http\UserController
+	if typeName := findTypeName(unit); typeName != "" {
+		if funcUnit.Namespace == "" {
+			funcUnit.Receiver = typeName
+		} else {
+			funcUnit.Receiver = funcUnit.Namespace + NamespaceSplit + typeName
+		}
+	}
+
+	funcName := core.FindFirstByKindInSubs(unit, KindPhpName)
+	if funcName == nil {
+		return nil, errors.New("no func name found in " + unit.Content)
+	}
+	funcUnit.Name = funcName.Content
+	funcUnit.DefLine = int(funcName.Span.Start.Row + 1)
+
+	// function name(params): returns { body }
+	afterParams := false
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindPhpFormalParameters:
+			funcUnit.Parameters = extractParameters(each)
+			afterParams = true
+		case KindPhpCompoundStatement:
+			funcUnit.BodySpan = each.Span
+		case KindPhpComment:
+			continue
+		default:
+			if afterParams && len(funcUnit.Returns) == 0 {
+				funcUnit.Returns = append(funcUnit.Returns, &object.ValueUnit{
+					Type: each.Content,
+					// php has no named return value
+					Name: "",
+				})
+			}
+		}
+	}
+
+	funcUnit.Extras = &FunctionExtras{
+		Attributes: findAttributes(unit),
+		Modifiers:  findModifiers(unit),
+		Doc:        extractor.commentRule().FindDoc(unit),
+	}
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
+	return funcUnit, nil
+}
+
+func extractParameters(unit *core.Unit) []*object.ValueUnit {
+	var ret []*object.ValueUnit
+	for _, each := range core.FindAllByKindsInSubs(unit,
+		KindPhpSimpleParameter, KindPhpVariadicParameter, KindPhpPropertyPromotionParam) {
+		// [attributes] [modifiers] [type] $name [= default]
+		valueUnit := &object.ValueUnit{}
+		for i, eachSub := range each.SubUnits {
+			if eachSub.Kind != KindPhpVariableName {
+				continue
+			}
+			valueUnit.Name = eachSub.Content
+			if i > 0 {
+				typeUnit := each.SubUnits[i-1]
+				switch typeUnit.Kind {
+				case KindPhpAttributeList, KindPhpVisibilityModifier, KindPhpReadonlyModifier:
+				default:
+					valueUnit.Type = typeUnit.Content
+				}
+			}
+			break
+		}
+		ret = append(ret, valueUnit)
+	}
+	return ret
+}
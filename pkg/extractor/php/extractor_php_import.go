@@ -0,0 +1,99 @@
+package php
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+const namespaceSplit = "\\"
+
+var requireKinds = []core.KindRepr{
+	KindPhpRequire,
+	KindPhpRequireOnce,
+	KindPhpInclude,
+	KindPhpIncludeOnce,
+}
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindPhpNamespaceUseDecl || slices.Contains(requireKinds, unit.Kind)
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) {
+			continue
+		}
+		newImport := func() *object.Import {
+			imp := object.NewImport()
+			imp.Span = eachUnit.Span
+			imp.Lang = extractor.GetLang()
+			// use function A\f;
+			imp.Static = strings.HasPrefix(eachUnit.Content, "use function") || strings.HasPrefix(eachUnit.Content, "use const")
+			return imp
+		}
+
+		// require_once 'x.php';
+		if eachUnit.Kind != KindPhpNamespaceUseDecl {
+			file := core.FindFirstByKindInSubs(eachUnit, KindPhpString)
+			if file == nil {
+				continue
+			}
+			imp := newImport()
+			imp.Source = strings.Trim(file.Content, "'\"")
+			imp.Wildcard = true
+			ret = append(ret, imp)
+			continue
+		}
+
+		// use A\B\{C, D as E};
+		if group := core.FindFirstByKindInSubs(eachUnit, KindPhpNamespaceUseGroup); group != nil {
+			prefix := core.FindFirstByKindInSubs(eachUnit, KindPhpNamespaceName)
+			imp := newImport()
+			if prefix != nil {
+				imp.Source = prefix.Content
+			}
+			for _, each := range group.SubUnits {
+				name, alias := splitUseClause(each)
+				imp.AddName(name, alias)
+			}
+			ret = append(ret, imp)
+			continue
+		}
+
+		// use A\B\C, A\B\D as E;
+		for _, each := range core.FindAllByKindInSubs(eachUnit, KindPhpNamespaceUseClause) {
+			name, alias := splitUseClause(each)
+			imp := newImport()
+			if i := strings.LastIndex(name, namespaceSplit); i != -1 {
+				imp.Source = name[:i]
+				imp.AddName(name[i+len(namespaceSplit):], alias)
+			} else {
+				imp.Source = name
+				imp.Alias = alias
+			}
+			ret = append(ret, imp)
+		}
+	}
+	return ret, nil
+}
+
+// splitUseClause `A\B as C` -> `A\B`, `C`
+func splitUseClause(unit *core.Unit) (string, string) {
+	var name, alias string
+	for _, each := range unit.SubUnits {
+		if each.Kind == KindPhpNamespaceAliasing {
+			if len(each.SubUnits) != 0 {
+				alias = each.SubUnits[0].Content
+			}
+			continue
+		}
+		if name == "" {
+			name = each.Content
+		}
+	}
+	return strings.TrimPrefix(name, namespaceSplit), alias
+}
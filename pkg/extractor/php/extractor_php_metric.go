@@ -0,0 +1,48 @@
+package php
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindPhpIfStatement,
+			KindPhpElseIfClause,
+			KindPhpForStatement,
+			KindPhpForeachStatement,
+			KindPhpWhileStatement,
+			KindPhpDoStatement,
+			KindPhpCaseStatement,
+			KindPhpCatchClause,
+			KindPhpConditionalExpression,
+		},
+		FlowKinds: []core.KindRepr{
+			KindPhpIfStatement,
+			KindPhpForStatement,
+			KindPhpForeachStatement,
+			KindPhpWhileStatement,
+			KindPhpDoStatement,
+			KindPhpSwitchStatement,
+			KindPhpCatchClause,
+			KindPhpConditionalExpression,
+		},
+		ElseIfKinds: []core.KindRepr{
+			KindPhpElseIfClause,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindPhpAnonymousFunction,
+			KindPhpArrowFunction,
+		},
+		BinaryKinds: []core.KindRepr{
+			KindPhpBinaryExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindPhpReturnStatement,
+		},
+		CommentKinds: []core.KindRepr{
+			KindPhpComment,
+		},
+	}
+}
@@ -0,0 +1,269 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
+)
+
+var phpCode = `<?php
+namespace App\Http\Controllers;
+
+use App\Models\User;
+
+function helper(int $a, string ...$rest): ?string {
+    return strtoupper($rest[0]);
+}
+
+#[Attribute]
+#[Route("/users", methods: ["GET"])]
+abstract class UserController extends Controller implements Countable, JsonSerializable
+{
+    use Loggable;
+    private int $count = 0;
+    public const MAX = 10;
+
+    #[Get("/list")]
+    public static function index(Request $request, $limit = 10): array
+    {
+        $users = User::all();
+        $this->log("x");
+        return array_map(fn($u) => $u->name, $users);
+    }
+
+    abstract protected function build();
+}
+
+interface Countable { public function count(): int; }
+
+trait Loggable {
+    public function log(string $msg) { echo $msg; }
+}
+`
+
+func TestExtractor_ExtractFunctions(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPhp)
+	units, err := parser.Parse([]byte(phpCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 5)
+
+	helper := funcs[0]
+	assert.Equal(t, "helper", helper.Name)
+	assert.Equal(t, "App\\Http\\Controllers", helper.Namespace)
+	assert.Equal(t, "", helper.Receiver)
+	assert.Equal(t, "?string", helper.Returns[0].Type)
+	assert.Equal(t, "$rest", helper.Parameters[1].Name)
+	assert.Equal(t, "string", helper.Parameters[1].Type)
+
+	index := funcs[1]
+	assert.Equal(t, "index", index.Name)
+	assert.Equal(t, "App\\Http\\Controllers\\UserController", index.Receiver)
+	assert.Equal(t, 19, index.DefLine)
+	assert.Equal(t, []string{"Get(\"/list\")"}, index.Extras.(*FunctionExtras).Attributes)
+	assert.Equal(t, []string{"public", "static"}, index.Extras.(*FunctionExtras).Modifiers)
+	assert.Equal(t, "App\\Http\\Controllers|App\\Http\\Controllers\\UserController|index|Request,|array", index.GetSignature())
+
+	build := funcs[2]
+	assert.Empty(t, build.Returns)
+	assert.Equal(t, []string{"abstract", "protected"}, build.Extras.(*FunctionExtras).Modifiers)
+
+	assert.Equal(t, "App\\Http\\Controllers\\Countable", funcs[3].Receiver)
+	assert.Equal(t, "App\\Http\\Controllers\\Loggable", funcs[4].Receiver)
+}
+
+func TestExtractor_ExtractClasses(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPhp)
+	units, err := parser.Parse([]byte(phpCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 3)
+
+	controller := classes[0]
+	assert.Equal(t, "UserController", controller.Name)
+	assert.Equal(t, "App\\Http\\Controllers", controller.Module)
+	extras := controller.Extras.(*ClassExtras)
+	assert.Equal(t, "class", extras.Kind)
+	assert.Equal(t, []string{"Attribute", "Route(\"/users\", methods: [\"GET\"])"}, extras.Attributes)
+	assert.Equal(t, []string{"Controller"}, extras.Extends)
+	assert.Equal(t, []string{"Countable", "JsonSerializable"}, extras.Implements)
+	assert.Equal(t, []string{"Loggable"}, extras.Traits)
+	assert.Len(t, extras.Fields, 2)
+	assert.Equal(t, "$count", extras.Fields[0].Name)
+	assert.Equal(t, "MAX", extras.Fields[1].Name)
+
+	assert.Equal(t, "interface", classes[1].Extras.(*ClassExtras).Kind)
+	assert.Equal(t, "trait", classes[2].Extras.(*ClassExtras).Kind)
+}
+
+func TestExtractor_ExtractCalls(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPhp)
+	units, err := parser.Parse([]byte(phpCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, calls)
+	assert.Equal(t, "strtoupper", calls[0].Caller)
+	assert.Equal(t, []string{"$rest[0]"}, calls[0].Arguments)
+	assert.Equal(t, "User::all", calls[1].Caller)
+	assert.Equal(t, "$this->log", calls[2].Caller)
+}
+
+var phpImportCode = `<?php
+use A\B\C;
+use A\B\D as E;
+use function A\f;
+use A\B\{C, D as E};
+require_once 'x.php';
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPhp)
+	units, err := parser.Parse([]byte(phpImportCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 5)
+	assert.Equal(t, "A\\B", imports[0].Source)
+	assert.Equal(t, []string{"C"}, imports[0].Names)
+	assert.Equal(t, "E", imports[1].Aliases["D"])
+	assert.True(t, imports[2].Static)
+	assert.Equal(t, "A\\B", imports[3].Source)
+	assert.Equal(t, []string{"C", "D"}, imports[3].Names)
+	assert.Equal(t, "E", imports[3].Aliases["D"])
+	assert.Equal(t, "x.php", imports[4].Source)
+}
+
+var phpCommentCode = `<?php
+/**
+ * Find a user.
+ */
+#[Route("/user")]
+function find($id) {}
+
+# a user
+class User {}
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPhp)
+	units, err := parser.Parse([]byte(phpCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	// attributes are not comments
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "Find a user.", comments[0].Text)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, functions[0].GetSignature(), comments[0].Owner)
+	assert.Equal(t, "Find a user.", functions[0].Extras.(*FunctionExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "a user", classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var phpVariableCode = `<?php
+namespace App;
+
+const MAX = 10;
+$debug = false;
+
+class User {
+    const ROLE = 'admin';
+    private static $count = 2;
+
+    function f() {
+        $w = 3;
+        $this->name = 'a';
+    }
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPhp)
+	units, err := parser.Parse([]byte(phpVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 5)
+
+	assert.Equal(t, "MAX", variables[0].Name)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeModule, variables[0].Scope)
+	assert.Equal(t, "App", variables[0].Namespace)
+
+	assert.Equal(t, "$debug", variables[1].Name)
+	assert.Equal(t, object.MutabilityVar, variables[1].Mutability)
+
+	assert.Equal(t, "ROLE", variables[2].Name)
+	assert.Equal(t, object.VariableScopeClass, variables[2].Scope)
+	assert.Equal(t, "App\\User", variables[2].Receiver)
+
+	assert.Equal(t, "$count", variables[3].Name)
+	assert.Equal(t, "2", variables[3].Value)
+
+	assert.Equal(t, "$w", variables[4].Name)
+	assert.Equal(t, object.VariableScopeLocal, variables[4].Scope)
+}
+
+var phpMetricCode = `
+<?php
+function f($a) {
+  if ($a > 0 && $a < 3) { return 1; } elseif ($a < 0) { return 2; } else if ($a) {} else {}
+  for ($i = 0; $i < 3; $i++) {}
+  foreach ($xs as $x) {}
+  while ($a > 0) {}
+  do {} while ($a > 0);
+  switch ($a) { case 1: break; default: break; }
+  try {} catch (Exception $e) {}
+  $b = $a > 0 ? 1 : 2;
+  $l = function($x) { return $x; };
+  $m = fn($x) => $x;
+  return 0;
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPhp)
+	units, err := parser.Parse([]byte(phpMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 12, metrics.Cyclomatic)
+	assert.Equal(t, 11, metrics.Cognitive)
+	assert.Equal(t, 13, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
@@ -0,0 +1,91 @@
+package php
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	switch unit.Kind {
+	case KindPhpConstElement, KindPhpPropertyElement:
+		return true
+	case KindPhpAssignmentExpression:
+		// `$a = 1;`, but not `$this->a = 1;`
+		return unit.ParentUnit != nil && unit.ParentUnit.Kind == KindPhpExpressionStatement &&
+			len(unit.SubUnits) != 0 && unit.SubUnits[0].Kind == KindPhpVariableName
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		ret = append(ret, extractor.extractVariable(eachUnit))
+	}
+	return ret, nil
+}
+
+// extractVariable const_element, property_element or assignment_expression: name, [value]
+func (extractor *Extractor) extractVariable(unit *core.Unit) *object.Variable {
+	v := object.NewVariable()
+	v.Span = unit.Span
+	v.Lang = extractor.GetLang()
+	v.Namespace = findNamespace(unit)
+	if len(unit.SubUnits) != 0 {
+		v.Name = unit.SubUnits[0].Content
+	}
+	if len(unit.SubUnits) > 1 {
+		value := unit.SubUnits[len(unit.SubUnits)-1]
+		if value.Kind == KindPhpPropertyInitializer && len(value.SubUnits) != 0 {
+			value = value.SubUnits[0]
+		}
+		v.Value = value.Content
+	}
+
+	switch unit.Kind {
+	case KindPhpConstElement:
+		v.Mutability = object.MutabilityConst
+	case KindPhpPropertyElement:
+		decl := unit.ParentUnit
+		for _, each := range decl.SubUnits {
+			if each.Kind == KindPhpPropertyElement {
+				break
+			}
+			if each.Kind == KindPhpAttributeList || slices.Contains(modifierKinds, each.Kind) {
+				continue
+			}
+			v.Type = each.Content
+		}
+		// old versions of grammar can not parse `readonly` and take it as a part of type
+		if slices.Contains(findModifiers(decl), "readonly") || strings.HasPrefix(v.Type, "readonly") {
+			v.Mutability = object.MutabilityFinal
+			v.Type = strings.TrimSpace(strings.TrimPrefix(v.Type, "readonly"))
+		}
+	}
+
+	owner := core.FindFirstByOneOfKindInParent(unit, KindPhpFunctionDefinition, KindPhpMethodDeclaration,
+		KindPhpAnonymousFunction, KindPhpArrowFunction, KindPhpDeclarationList)
+	switch {
+	case owner == nil:
+	case owner.Kind == KindPhpDeclarationList:
+		if _, ok := classKinds[owner.ParentUnit.Kind]; !ok {
+			break
+		}
+		v.Scope = object.VariableScopeClass
+		// fully qualified class name, eg: App\Http\UserController
+		if v.Namespace == "" {
+			v.Receiver = findTypeName(unit)
+		} else {
+			v.Receiver = v.Namespace + NamespaceSplit + findTypeName(unit)
+		}
+	default:
+		v.Scope = object.VariableScopeLocal
+	}
+	return v
+}
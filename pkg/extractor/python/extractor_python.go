@@ -2,16 +2,45 @@ package python
 
 import (
 	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
 )
 
 // https://github.com/tree-sitter/tree-sitter-python/blob/master/src/node-types.json
 const (
-	KindPythonFunctionDefinition  core.KindRepr = "function_definition"
-	KindPythonIdentifier          core.KindRepr = "identifier"
-	KindPythonDecoratedDefinition core.KindRepr = "decorated_definition"
-	KindPythonDecorator           core.KindRepr = "decorator"
-	KindPythonBlock               core.KindRepr = "block"
-	KindPythonClassDefinition     core.KindRepr = "class_definition"
+	KindPythonFunctionDefinition    core.KindRepr = "function_definition"
+	KindPythonIdentifier            core.KindRepr = "identifier"
+	KindPythonDecoratedDefinition   core.KindRepr = "decorated_definition"
+	KindPythonDecorator             core.KindRepr = "decorator"
+	KindPythonBlock                 core.KindRepr = "block"
+	KindPythonClassDefinition       core.KindRepr = "class_definition"
+	KindPythonImportStatement       core.KindRepr = "import_statement"
+	KindPythonImportFrom            core.KindRepr = "import_from_statement"
+	KindPythonDottedName            core.KindRepr = "dotted_name"
+	KindPythonAliasedImport         core.KindRepr = "aliased_import"
+	KindPythonWildcardImport        core.KindRepr = "wildcard_import"
+	KindPythonComment               core.KindRepr = "comment"
+	KindPythonExpressionStatement   core.KindRepr = "expression_statement"
+	KindPythonString                core.KindRepr = "string"
+	KindPythonModule                core.KindRepr = "module"
+	KindPythonAssignment            core.KindRepr = "assignment"
+	KindPythonPatternList           core.KindRepr = "pattern_list"
+	KindPythonExpressionList        core.KindRepr = "expression_list"
+	KindPythonAttribute             core.KindRepr = "attribute"
+	KindPythonType                  core.KindRepr = "type"
+	KindPythonIfStatement           core.KindRepr = "if_statement"
+	KindPythonElifClause            core.KindRepr = "elif_clause"
+	KindPythonForStatement          core.KindRepr = "for_statement"
+	KindPythonWhileStatement        core.KindRepr = "while_statement"
+	KindPythonExceptClause          core.KindRepr = "except_clause"
+	KindPythonConditionalExpression core.KindRepr = "conditional_expression"
+	KindPythonLambda                core.KindRepr = "lambda"
+	KindPythonBooleanOperator       core.KindRepr = "boolean_operator"
+	KindPythonReturnStatement       core.KindRepr = "return_statement"
+	KindPythonParameters            core.KindRepr = "parameters"
+	KindPythonArgumentList          core.KindRepr = "argument_list"
+	KindPythonCall                  core.KindRepr = "call"
+	KindPythonKeywordArgument       core.KindRepr = "keyword_argument"
+	KindPythonList                  core.KindRepr = "list"
 )
 
 type Extractor struct {
@@ -19,6 +48,10 @@ type Extractor struct {
 
 type FunctionExtras struct {
 	Decorators []string `json:"decorators"`
+	// Doc docstring, or comments right before this function
+	Doc string `json:"doc"`
+	// TestFramework empty if this function is not a test
+	TestFramework object.TestFramework `json:"testFramework"`
 }
 
 func (extractor *Extractor) GetLang() core.LangType {
@@ -9,6 +9,8 @@ import (
 
 type ClassExtras struct {
 	Decorators []string `json:"decorators"`
+	// Doc docstring, or comments right before this class
+	Doc string `json:"doc"`
 }
 
 func (extractor *Extractor) IsClass(unit *core.Unit) bool {
@@ -60,6 +62,7 @@ func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error)
 		}
 		extras.Decorators = decoContents
 	}
+	extras.Doc = extractor.findDoc(unit)
 	clazz.Extras = extras
 
 	return clazz, nil
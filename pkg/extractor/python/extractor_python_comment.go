@@ -0,0 +1,97 @@
+package python
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsComment(unit *core.Unit) bool {
+	return unit.Kind == KindPythonComment || isDocstring(unit)
+}
+
+func (extractor *Extractor) ExtractComments(units []*core.Unit) ([]*object.Comment, error) {
+	ret := make([]*object.Comment, 0)
+	rule := extractor.commentRule()
+	owners := object.NewDeclSignatures(extractor)
+	for _, eachUnit := range units {
+		if !extractor.IsComment(eachUnit) {
+			continue
+		}
+		comment := object.NewComment(eachUnit.Content)
+		comment.Span = eachUnit.Span
+		comment.Lang = extractor.GetLang()
+
+		var owner *core.Unit
+		if isDocstring(eachUnit) {
+			comment.Kind = object.CommentDoc
+			// block -> definition, nil for module docstrings
+			owner = eachUnit.ParentUnit.ParentUnit
+		} else {
+			owner = rule.FindOwner(eachUnit)
+		}
+		if owner != nil {
+			signature, err := owners.Get(owner)
+			if err != nil {
+				return nil, err
+			}
+			comment.Owner = signature
+		}
+		ret = append(ret, comment)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) commentRule() *object.CommentRule {
+	rule := object.NewCommentRule(extractor)
+	// docstrings are parts of declarations
+	rule.IsComment = func(unit *core.Unit) bool {
+		return unit.Kind == KindPythonComment
+	}
+	return rule
+}
+
+// findDoc docstring of this function or class, or the comments before it
+func (extractor *Extractor) findDoc(unit *core.Unit) string {
+	block := core.FindFirstByKindInSubs(unit, KindPythonBlock)
+	if block == nil {
+		return extractor.commentRule().FindDoc(unit)
+	}
+	for _, each := range block.SubUnits {
+		if isDocstring(each) {
+			return object.CleanComment(each.Content)
+		}
+	}
+	return extractor.commentRule().FindDoc(unit)
+}
+
+/*
+isDocstring the first statement of a module, function or class, which is a string
+
+	def abc():
+		"""docstring"""
+*/
+func isDocstring(unit *core.Unit) bool {
+	if unit.Kind != KindPythonExpressionStatement || len(unit.SubUnits) != 1 || unit.SubUnits[0].Kind != KindPythonString {
+		return false
+	}
+	parent := unit.ParentUnit
+	if parent == nil {
+		return false
+	}
+	for _, each := range parent.SubUnits {
+		if each.Kind == KindPythonComment {
+			continue
+		}
+		if each != unit {
+			return false
+		}
+		break
+	}
+	if parent.Kind == KindPythonModule {
+		return true
+	}
+	if parent.Kind != KindPythonBlock || parent.ParentUnit == nil {
+		return false
+	}
+	return parent.ParentUnit.Kind == KindPythonFunctionDefinition || parent.ParentUnit.Kind == KindPythonClassDefinition
+}
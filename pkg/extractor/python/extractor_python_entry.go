@@ -0,0 +1,125 @@
+package python
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+// http methods which can be used as decorators, eg: `@app.get("/")`
+var routeMethods = []string{"get", "post", "put", "delete", "patch", "head", "options"}
+
+/*
+DetectEntryPoints flask and fastapi handlers
+
+	@app.route("/users", methods=["GET", "POST"])
+	@router.get("/users/{id}")
+*/
+func (extractor *Extractor) DetectEntryPoints(units []*core.Unit) ([]*object.EntryPoint, error) {
+	imports, err := extractor.ExtractImports(units)
+	if err != nil {
+		return nil, err
+	}
+	framework := object.EntryFrameworkFlask
+	for _, each := range imports {
+		if strings.Split(each.Source, ".")[0] == "fastapi" {
+			framework = object.EntryFrameworkFastAPI
+			break
+		}
+	}
+
+	var ret []*object.EntryPoint
+	for _, eachUnit := range units {
+		if eachUnit.Kind != KindPythonDecoratedDefinition {
+			continue
+		}
+		funcDef := core.FindFirstByKindInSubs(eachUnit, KindPythonFunctionDefinition)
+		if funcDef == nil {
+			continue
+		}
+		var handler string
+		if nameUnit := core.FindFirstByKindInSubs(funcDef, KindPythonIdentifier); nameUnit != nil {
+			handler = nameUnit.Content
+		}
+		for _, eachDecorator := range core.FindAllByKindInSubs(eachUnit, KindPythonDecorator) {
+			for _, each := range routeEntryPoints(eachDecorator) {
+				each.Framework = framework
+				each.Handler = handler
+				each.Span = eachDecorator.Span
+				each.Unit = funcDef
+				ret = append(ret, each)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// routeEntryPoints one for each method of this decorator
+func routeEntryPoints(decorator *core.Unit) []*object.EntryPoint {
+	call := core.FindFirstByKindInSubs(decorator, KindPythonCall)
+	if call == nil || len(call.SubUnits) != 2 || call.SubUnits[0].Kind != KindPythonAttribute {
+		return nil
+	}
+	attribute := call.SubUnits[0]
+	decoratorName := attribute.SubUnits[len(attribute.SubUnits)-1].Content
+
+	var route string
+	var methods []string
+	for _, each := range call.SubUnits[1].SubUnits {
+		switch each.Kind {
+		case KindPythonString:
+			if route == "" {
+				route = unquote(each.Content)
+			}
+		case KindPythonKeywordArgument:
+			name := core.FindFirstByKindInSubs(each, KindPythonIdentifier)
+			if name == nil {
+				continue
+			}
+			switch name.Content {
+			case "path", "rule":
+				if value := core.FindFirstByKindInSubs(each, KindPythonString); value != nil {
+					route = unquote(value.Content)
+				}
+			case "methods":
+				if value := core.FindFirstByKindInSubs(each, KindPythonList); value != nil {
+					for _, eachMethod := range core.FindAllByKindInSubs(value, KindPythonString) {
+						methods = append(methods, strings.ToUpper(unquote(eachMethod.Content)))
+					}
+				}
+			}
+		}
+	}
+	if !strings.HasPrefix(route, "/") {
+		return nil
+	}
+
+	switch {
+	case slices.Contains(routeMethods, decoratorName):
+		methods = []string{strings.ToUpper(decoratorName)}
+	case decoratorName == "route" || decoratorName == "api_route":
+		if len(methods) == 0 {
+			// both of flask and fastapi
+			methods = []string{"GET"}
+		}
+	default:
+		return nil
+	}
+
+	ret := make([]*object.EntryPoint, 0, len(methods))
+	for _, each := range methods {
+		ret = append(ret, &object.EntryPoint{
+			Kind:   object.EntryKindHttp,
+			Method: each,
+			Route:  route,
+		})
+	}
+	return ret
+}
+
+// unquote `"a"`, `'a'` and `r"a"`
+func unquote(s string) string {
+	return strings.Trim(strings.TrimLeft(s, "rbuf"), "\"'")
+}
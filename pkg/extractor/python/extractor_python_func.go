@@ -70,7 +70,10 @@ func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function,
 		}
 		extras.Decorators = decoContents
 	}
+	extras.Doc = extractor.findDoc(unit)
+	extras.TestFramework = testFramework(funcUnit.Name, extras.Decorators, clazz)
 	funcUnit.Extras = extras
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
 
 	// todo: returns and params?
 	return funcUnit, nil
@@ -0,0 +1,73 @@
+package python
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindPythonImportStatement || unit.Kind == KindPythonImportFrom
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) || len(eachUnit.SubUnits) == 0 {
+			continue
+		}
+
+		if eachUnit.Kind == KindPythonImportStatement {
+			// import os.path as p, sys
+			for _, each := range eachUnit.SubUnits {
+				name, alias := splitAliasedImport(each)
+				if name == "" {
+					continue
+				}
+				imp := extractor.newImport(eachUnit)
+				imp.Source = name
+				imp.Alias = alias
+				ret = append(ret, imp)
+			}
+			continue
+		}
+
+		// from ..a.b import c as d, e
+		// the first one is the module (maybe relative)
+		imp := extractor.newImport(eachUnit)
+		imp.Source = eachUnit.SubUnits[0].Content
+		for _, each := range eachUnit.SubUnits[1:] {
+			if each.Kind == KindPythonWildcardImport {
+				imp.Wildcard = true
+				continue
+			}
+			name, alias := splitAliasedImport(each)
+			if name == "" {
+				continue
+			}
+			imp.AddName(name, alias)
+		}
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) newImport(unit *core.Unit) *object.Import {
+	imp := object.NewImport()
+	imp.Span = unit.Span
+	imp.Lang = extractor.GetLang()
+	return imp
+}
+
+// splitAliasedImport `a.b as c` -> `a.b`, `c`
+func splitAliasedImport(unit *core.Unit) (string, string) {
+	switch unit.Kind {
+	case KindPythonDottedName:
+		return unit.Content, ""
+	case KindPythonAliasedImport:
+		if len(unit.SubUnits) < 2 {
+			return "", ""
+		}
+		return unit.SubUnits[0].Content, unit.SubUnits[1].Content
+	}
+	return "", ""
+}
@@ -0,0 +1,49 @@
+package python
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindPythonIfStatement,
+			KindPythonElifClause,
+			KindPythonForStatement,
+			KindPythonWhileStatement,
+			KindPythonExceptClause,
+			KindPythonConditionalExpression,
+		},
+		FlowKinds: []core.KindRepr{
+			KindPythonIfStatement,
+			KindPythonForStatement,
+			KindPythonWhileStatement,
+			KindPythonExceptClause,
+			KindPythonConditionalExpression,
+		},
+		ElseIfKinds: []core.KindRepr{
+			KindPythonElifClause,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindPythonLambda,
+			KindPythonFunctionDefinition,
+		},
+		LogicalKinds: []core.KindRepr{
+			KindPythonBooleanOperator,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindPythonReturnStatement,
+		},
+		CommentKinds: []core.KindRepr{
+			KindPythonComment,
+		},
+		ParamCounter: func(unit *core.Unit) int {
+			params := core.FindFirstByKindInSubs(unit, KindPythonParameters)
+			if params == nil {
+				return 0
+			}
+			return len(params.SubUnits) - len(core.FindAllByKindInSubs(params, KindPythonComment))
+		},
+	}
+}
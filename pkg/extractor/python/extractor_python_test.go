@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
 )
 
 var pythonCode = `
@@ -69,3 +71,260 @@ func TestExtractor_ExtractClasses(t *testing.T) {
 		core.Log.Infof("cls: %s %v", each.Name, each.Extras)
 	}
 }
+
+var pythonImportCode = `
+import os
+import os.path as p, sys
+from . import x
+from ..a.b import c as d, e
+from x import *
+from y import (m, n)
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	parser := core.NewParser(core.LangPython)
+	units, err := parser.Parse([]byte(pythonImportCode))
+	if err != nil {
+		panic(err)
+	}
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 7)
+	assert.Equal(t, "os", imports[0].Source)
+	assert.Equal(t, "os.path", imports[1].Source)
+	assert.Equal(t, "p", imports[1].Alias)
+	assert.Equal(t, "sys", imports[2].Source)
+	assert.Equal(t, ".", imports[3].Source)
+	assert.Equal(t, []string{"x"}, imports[3].Names)
+	assert.Equal(t, "..a.b", imports[4].Source)
+	assert.Equal(t, []string{"c", "e"}, imports[4].Names)
+	assert.Equal(t, "d", imports[4].Aliases["c"])
+	assert.True(t, imports[5].Wildcard)
+	assert.Equal(t, []string{"m", "n"}, imports[6].Names)
+}
+
+var pythonCommentCode = `
+"""module docstring"""
+
+# cached
+@cache
+def load(path):
+    """
+    Load all the bytes.
+    """
+    pass
+
+
+class Loader:
+    # not a docstring
+    x = "abc"
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPython)
+	units, err := parser.Parse([]byte(pythonCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 4)
+	assert.Equal(t, object.CommentDoc, comments[0].Kind)
+	assert.Empty(t, comments[0].Owner)
+	assert.Equal(t, "||load|", comments[1].Owner[:7])
+	assert.Equal(t, object.CommentDoc, comments[2].Kind)
+	assert.Equal(t, "Load all the bytes.", comments[2].Text)
+	assert.Equal(t, comments[1].Owner, comments[2].Owner)
+	assert.Empty(t, comments[3].Owner)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "Load all the bytes.", functions[0].Extras.(*FunctionExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Empty(t, classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var pythonVariableCode = `
+from typing import Final
+
+MAX_SIZE = 10
+debug: bool = False
+a, b = 1, 2
+LIMIT: Final = 5
+
+
+class A:
+    x = 1
+
+    def __init__(self):
+        self.y = 2
+
+    def f(self):
+        self.z = 3
+        w = 4
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPython)
+	units, err := parser.Parse([]byte(pythonVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 8)
+
+	// constants by convention
+	assert.Equal(t, "MAX_SIZE", variables[0].Name)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeModule, variables[0].Scope)
+
+	assert.Equal(t, "bool", variables[1].Type)
+	assert.Equal(t, "False", variables[1].Value)
+	assert.Equal(t, object.MutabilityVar, variables[1].Mutability)
+
+	assert.Equal(t, "b", variables[3].Name)
+	assert.Equal(t, "2", variables[3].Value)
+
+	assert.Equal(t, object.MutabilityFinal, variables[4].Mutability)
+
+	assert.Equal(t, "x", variables[5].Name)
+	assert.Equal(t, object.VariableScopeClass, variables[5].Scope)
+	assert.Equal(t, "A", variables[5].Receiver)
+
+	// instance attributes in __init__ only
+	assert.Equal(t, "y", variables[6].Name)
+	assert.Equal(t, object.VariableScopeClass, variables[6].Scope)
+	assert.Equal(t, "A", variables[6].Receiver)
+
+	assert.Equal(t, "w", variables[7].Name)
+	assert.Equal(t, object.VariableScopeLocal, variables[7].Scope)
+}
+
+var pythonMetricCode = `
+def f(a):
+    if a > 0 and a < 3:
+        return 1
+    elif a < 0:
+        return 2
+    else:
+        pass
+    for i in range(3):
+        pass
+    while a > 0:
+        pass
+    try:
+        pass
+    except Exception:
+        pass
+    x = 1 if a else 2
+    l = lambda x: x
+    y = [i for i in range(3) if i]
+    with open("a") as f:
+        pass
+    return 0
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPython)
+	units, err := parser.Parse([]byte(pythonMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 8, metrics.Cyclomatic)
+	assert.Equal(t, 7, metrics.Cognitive)
+	assert.Equal(t, 21, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
+
+var pythonTestCode = `
+import unittest
+import pytest
+
+class TestA(unittest.TestCase):
+    def test_a(self):
+        pass
+
+    def helper(self):
+        pass
+
+class TestB:
+    def test_b(self):
+        pass
+
+@pytest.fixture
+def test_data():
+    return 1
+
+def test_c(test_data):
+    pass
+`
+
+func TestExtractor_ExtractTests(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPython)
+	units, err := parser.Parse([]byte(pythonTestCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, functions, 5)
+
+	assert.Equal(t, object.TestFrameworkUnittest, functions[0].GetTestFramework())
+	assert.False(t, functions[1].IsTest())
+	assert.Equal(t, object.TestFrameworkPytest, functions[2].GetTestFramework())
+	// fixture
+	assert.False(t, functions[3].IsTest())
+	assert.Equal(t, object.TestFrameworkPytest, functions[4].GetTestFramework())
+}
+
+var pythonEntryCode = `
+from fastapi import APIRouter
+
+router = APIRouter()
+
+@router.get("/users/{id}")
+def get_user(id):
+    pass
+
+@router.api_route("/users", methods=["POST", "PUT"])
+def save_user():
+    pass
+
+@property
+def helper():
+    pass
+`
+
+func TestExtractor_DetectEntryPoints(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangPython)
+	units, err := parser.Parse([]byte(pythonEntryCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	entries, err := extractor.DetectEntryPoints(units)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 3)
+
+	var routes []string
+	for _, each := range entries {
+		assert.Equal(t, object.EntryFrameworkFastAPI, each.Framework)
+		routes = append(routes, each.Method+" "+each.Route+" "+each.Handler)
+	}
+	assert.Equal(t, []string{"GET /users/{id} get_user", "POST /users save_user", "PUT /users save_user"}, routes)
+}
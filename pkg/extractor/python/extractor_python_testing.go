@@ -0,0 +1,43 @@
+package python
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extras *FunctionExtras) GetTestFramework() object.TestFramework {
+	return extras.TestFramework
+}
+
+/*
+testFramework with the default discovery rules
+
+	class A(unittest.TestCase):   -> unittest
+		def test_a(self):
+	class TestA:                  -> pytest
+		def test_a(self):
+	def test_a():                 -> pytest
+*/
+func testFramework(name string, decorators []string, clazz *core.Unit) object.TestFramework {
+	if !strings.HasPrefix(name, "test") {
+		return ""
+	}
+	for _, each := range decorators {
+		// `@pytest.fixture` named like tests
+		if strings.Contains(each, "fixture") {
+			return ""
+		}
+	}
+	if clazz == nil {
+		return object.TestFrameworkPytest
+	}
+	if bases := core.FindFirstByKindInSubs(clazz, KindPythonArgumentList); bases != nil && strings.Contains(bases.Content, "TestCase") {
+		return object.TestFrameworkUnittest
+	}
+	if clazzName := core.FindFirstByKindInSubs(clazz, KindPythonIdentifier); clazzName != nil && strings.HasPrefix(clazzName.Content, "Test") {
+		return object.TestFrameworkPytest
+	}
+	return ""
+}
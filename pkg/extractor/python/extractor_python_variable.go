@@ -0,0 +1,123 @@
+package python
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	// the inner ones of chained assignments are ignored, eg: `a = b = 1`
+	return unit.Kind == KindPythonAssignment && unit.ParentUnit != nil && unit.ParentUnit.Kind == KindPythonExpressionStatement
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		ret = append(ret, extractor.extractVariable(eachUnit)...)
+	}
+	return ret, nil
+}
+
+// extractVariable assignment: left, [type], [value]
+func (extractor *Extractor) extractVariable(unit *core.Unit) []*object.Variable {
+	if len(unit.SubUnits) == 0 {
+		return nil
+	}
+	left := unit.SubUnits[0]
+	var typeDecl *core.Unit
+	var value *core.Unit
+	for _, each := range unit.SubUnits[1:] {
+		if each.Kind == KindPythonType && typeDecl == nil {
+			typeDecl = each
+			continue
+		}
+		value = each
+	}
+
+	scope := object.VariableScopeModule
+	var receiver string
+	owner := core.FindFirstByOneOfKindInParent(unit, KindPythonFunctionDefinition, KindPythonClassDefinition)
+	if owner != nil && owner.Kind == KindPythonClassDefinition {
+		scope = object.VariableScopeClass
+		receiver = core.FindFirstByKindInSubs(owner, KindPythonIdentifier).Content
+	} else if owner != nil {
+		scope = object.VariableScopeLocal
+	}
+
+	// a, b = 1, 2
+	var names []*core.Unit
+	switch left.Kind {
+	case KindPythonIdentifier:
+		names = append(names, left)
+	case KindPythonPatternList:
+		names = core.FindAllByKindInSubs(left, KindPythonIdentifier)
+	case KindPythonAttribute:
+		// instance attributes, `self.a = 1` in `__init__`
+		clazz := core.FindFirstByKindInParent(owner, KindPythonClassDefinition)
+		if clazz == nil || !isInit(owner) || len(left.SubUnits) != 2 || left.SubUnits[0].Content != "self" {
+			return nil
+		}
+		names = append(names, left.SubUnits[1])
+		scope = object.VariableScopeClass
+		receiver = core.FindFirstByKindInSubs(clazz, KindPythonIdentifier).Content
+	default:
+		return nil
+	}
+	var values []*core.Unit
+	if value != nil && value.Kind == KindPythonExpressionList {
+		values = value.SubUnits
+	}
+
+	ret := make([]*object.Variable, 0, len(names))
+	for i, each := range names {
+		v := object.NewVariable()
+		v.Name = each.Content
+		if typeDecl != nil {
+			v.Type = typeDecl.Content
+		}
+		switch {
+		case len(values) == len(names):
+			v.Value = values[i].Content
+		case value != nil:
+			v.Value = value.Content
+		}
+		switch {
+		// typing.Final, Final[int]
+		case strings.HasPrefix(strings.TrimPrefix(v.Type, "typing."), "Final"):
+			v.Mutability = object.MutabilityFinal
+		// constants by convention, eg: MAX_SIZE
+		case scope != object.VariableScopeLocal && isUpperSnake(v.Name):
+			v.Mutability = object.MutabilityConst
+		}
+		v.Scope = scope
+		v.Receiver = receiver
+		v.Span = unit.Span
+		v.Lang = extractor.GetLang()
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+func isInit(funcDef *core.Unit) bool {
+	name := core.FindFirstByKindInSubs(funcDef, KindPythonIdentifier)
+	return name != nil && name.Content == "__init__"
+}
+
+func isUpperSnake(name string) bool {
+	hasLetter := false
+	for _, each := range name {
+		if unicode.IsLower(each) {
+			return false
+		}
+		if unicode.IsUpper(each) {
+			hasLetter = true
+		}
+	}
+	return hasLetter
+}
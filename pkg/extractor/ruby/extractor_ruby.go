@@ -0,0 +1,89 @@
+package ruby
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+// https://github.com/tree-sitter/tree-sitter-ruby/blob/master/src/node-types.json
+const (
+	KindRubyProgram            core.KindRepr = "program"
+	KindRubyMethod             core.KindRepr = "method"
+	KindRubySingletonMethod    core.KindRepr = "singleton_method"
+	KindRubySingletonClass     core.KindRepr = "singleton_class"
+	KindRubyClass              core.KindRepr = "class"
+	KindRubyModule             core.KindRepr = "module"
+	KindRubySuperclass         core.KindRepr = "superclass"
+	KindRubyBodyStatement      core.KindRepr = "body_statement"
+	KindRubyConstant           core.KindRepr = "constant"
+	KindRubyScopeResolution    core.KindRepr = "scope_resolution"
+	KindRubyIdentifier         core.KindRepr = "identifier"
+	KindRubySelf               core.KindRepr = "self"
+	KindRubyMethodParameters   core.KindRepr = "method_parameters"
+	KindRubyOptionalParameter  core.KindRepr = "optional_parameter"
+	KindRubySplatParameter     core.KindRepr = "splat_parameter"
+	KindRubyHashSplatParameter core.KindRepr = "hash_splat_parameter"
+	KindRubyBlockParameter     core.KindRepr = "block_parameter"
+	KindRubyKeywordParameter   core.KindRepr = "keyword_parameter"
+	KindRubyCall               core.KindRepr = "call"
+	KindRubyArgumentList       core.KindRepr = "argument_list"
+	KindRubyBlock              core.KindRepr = "block"
+	KindRubyDoBlock            core.KindRepr = "do_block"
+	KindRubyComment            core.KindRepr = "comment"
+	KindRubyString             core.KindRepr = "string"
+	KindRubyAssignment         core.KindRepr = "assignment"
+	KindRubyClassVariable      core.KindRepr = "class_variable"
+	KindRubyInstanceVariable   core.KindRepr = "instance_variable"
+	KindRubyLambda             core.KindRepr = "lambda"
+	KindRubyIf                 core.KindRepr = "if"
+	KindRubyElsif              core.KindRepr = "elsif"
+	KindRubyUnless             core.KindRepr = "unless"
+	KindRubyIfModifier         core.KindRepr = "if_modifier"
+	KindRubyUnlessModifier     core.KindRepr = "unless_modifier"
+	KindRubyWhile              core.KindRepr = "while"
+	KindRubyUntil              core.KindRepr = "until"
+	KindRubyWhileModifier      core.KindRepr = "while_modifier"
+	KindRubyUntilModifier      core.KindRepr = "until_modifier"
+	KindRubyFor                core.KindRepr = "for"
+	KindRubyWhen               core.KindRepr = "when"
+	KindRubyRescue             core.KindRepr = "rescue"
+	KindRubyConditional        core.KindRepr = "conditional"
+	KindRubyCase               core.KindRepr = "case"
+	KindRubyBinary             core.KindRepr = "binary"
+	KindRubyReturn             core.KindRepr = "return"
+	ScopeSplit                               = "::"
+)
+
+type Extractor struct {
+}
+
+func (extractor *Extractor) GetLang() core.LangType {
+	return core.LangRuby
+}
+
+// findScopes names of all the classes and modules around this unit, outer first
+// `class A::B` will be split into two scopes
+func findScopes(unit *core.Unit) []string {
+	var ret []string
+	for cur := unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		if cur.Kind != KindRubyClass && cur.Kind != KindRubyModule {
+			continue
+		}
+		name := findScopeName(cur)
+		if name == nil {
+			continue
+		}
+		ret = append(strings.Split(name.Content, ScopeSplit), ret...)
+	}
+	return ret
+}
+
+func findScopeName(unit *core.Unit) *core.Unit {
+	for _, each := range unit.SubUnits {
+		if each.Kind == KindRubyConstant || each.Kind == KindRubyScopeResolution {
+			return each
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ruby
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsCall(unit *core.Unit) bool {
+	if unit.Kind == KindRubyCall {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractCalls(units []*core.Unit) ([]*object.Call, error) {
+	var ret []*object.Call
+	for _, eachUnit := range units {
+		if !extractor.IsCall(eachUnit) {
+			continue
+		}
+
+		eachCall, err := extractor.unit2Call(eachUnit)
+		if err != nil {
+			core.Log.Warnf("err: %v", err)
+			continue
+		}
+		ret = append(ret, eachCall)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
+	funcUnit := core.FindFirstByOneOfKindInParent(unit, KindRubyMethod, KindRubySingletonMethod)
+	var srcFunc *object.Function
+	var err error
+	if funcUnit != nil {
+		srcFunc, err = extractor.ExtractFunction(funcUnit)
+		if err != nil {
+			return nil, errors.New("convert func failed: " + funcUnit.Content)
+		}
+	}
+
+	// headless, give up (temp
+	if srcFunc == nil {
+		return nil, errors.New("headless call")
+	}
+
+	// receiver.method args { block }
+	var callerParts []string
+	var arguments []string
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindRubyArgumentList:
+			for _, eachArg := range each.SubUnits {
+				arguments = append(arguments, eachArg.Content)
+			}
+		case KindRubyBlock, KindRubyDoBlock:
+			continue
+		default:
+			callerParts = append(callerParts, each.Content)
+		}
+	}
+
+	ret := &object.Call{
+		Src:       srcFunc.GetSignature(),
+		Caller:    strings.Join(callerParts, "."),
+		Arguments: arguments,
+		Span:      unit.Span,
+	}
+	return ret, nil
+}
@@ -0,0 +1,98 @@
+package ruby
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type ClassExtras struct {
+	// class or module
+	Kind       string `json:"kind"`
+	Superclass string `json:"superclass"`
+	// mixins by include, extend and prepend
+	Mixins []string `json:"mixins"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
+var mixinMethods = map[string]struct{}{
+	"include": {},
+	"extend":  {},
+	"prepend": {},
+}
+
+func (extractor *Extractor) IsClass(unit *core.Unit) bool {
+	if unit.Kind == KindRubyClass || unit.Kind == KindRubyModule {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
+	var ret []*object.Clazz
+	for _, eachUnit := range units {
+		if !extractor.IsClass(eachUnit) {
+			continue
+		}
+		eachClazz, err := extractor.ExtractClass(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachClazz)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error) {
+	clazz := object.NewClazz()
+	clazz.Span = unit.Span
+	clazz.Lang = extractor.GetLang()
+	clazz.Unit = unit
+
+	name := findScopeName(unit)
+	if name == nil {
+		return nil, errors.New("no class name found in " + unit.Content)
+	}
+	// class A::B -> module A, name B
+	scopes := append(findScopes(unit), strings.Split(name.Content, ScopeSplit)...)
+	clazz.Name = scopes[len(scopes)-1]
+	clazz.Module = strings.Join(scopes[:len(scopes)-1], ScopeSplit)
+
+	extras := &ClassExtras{
+		Kind: unit.Kind,
+	}
+	// members are wrapped in body_statement
+	members := unit.SubUnits
+	if body := core.FindFirstByKindInSubs(unit, KindRubyBodyStatement); body != nil {
+		members = append(members[:len(members):len(members)], body.SubUnits...)
+	}
+	for _, each := range members {
+		switch each.Kind {
+		case KindRubySuperclass:
+			if len(each.SubUnits) != 0 {
+				extras.Superclass = each.SubUnits[0].Content
+			}
+		case KindRubyCall:
+			method := core.FindFirstByKindInSubs(each, KindRubyIdentifier)
+			if method == nil {
+				continue
+			}
+			if _, ok := mixinMethods[method.Content]; !ok {
+				continue
+			}
+			args := core.FindFirstByKindInSubs(each, KindRubyArgumentList)
+			if args == nil {
+				continue
+			}
+			for _, eachArg := range args.SubUnits {
+				extras.Mixins = append(extras.Mixins, eachArg.Content)
+			}
+		}
+	}
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	clazz.Extras = extras
+	return clazz, nil
+}
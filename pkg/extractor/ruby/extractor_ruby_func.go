@@ -0,0 +1,110 @@
+package ruby
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type FunctionExtras struct {
+	// `def self.xxx` or defined in `class << self`
+	Singleton bool `json:"singleton"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
+	if unit.Kind == KindRubyMethod || unit.Kind == KindRubySingletonMethod {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Function, error) {
+	var ret []*object.Function
+	for _, eachUnit := range units {
+		if !extractor.IsFunction(eachUnit) {
+			continue
+		}
+		eachFunc, err := extractor.ExtractFunction(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachFunc)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
+	funcUnit.Span = unit.Span
+	funcUnit.Unit = unit
+	funcUnit.Lang = extractor.GetLang()
+
+	// Billing::Core::Invoice -> namespace Billing::Core, receiver Invoice
+	scopes := findScopes(unit)
+	if len(scopes) != 0 {
+		funcUnit.Receiver = scopes[len(scopes)-1]
+		funcUnit.Namespace = strings.Join(scopes[:len(scopes)-1], ScopeSplit)
+	}
+
+	extras := &FunctionExtras{
+		Singleton: unit.Kind == KindRubySingletonMethod ||
+			core.FindFirstByKindInParent(unit, KindRubySingletonClass) != nil,
+	}
+
+	// def name, or def self.name
+	nameIndex := 0
+	if unit.Kind == KindRubySingletonMethod {
+		nameIndex = 1
+	}
+	if len(unit.SubUnits) <= nameIndex {
+		return nil, errors.New("no func name found in " + unit.Content)
+	}
+	funcName := unit.SubUnits[nameIndex]
+	funcUnit.Name = funcName.Content
+	funcUnit.DefLine = int(funcName.Span.Start.Row + 1)
+
+	params := core.FindFirstByKindInSubs(unit, KindRubyMethodParameters)
+	if params != nil {
+		for _, each := range params.SubUnits {
+			// ruby has no type declaration
+			valueUnit := &object.ValueUnit{}
+			switch each.Kind {
+			case KindRubyIdentifier:
+				valueUnit.Name = each.Content
+			case KindRubySplatParameter, KindRubyHashSplatParameter, KindRubyBlockParameter:
+				// keep `*`, `**` and `&`
+				valueUnit.Name = each.Content
+			default:
+				name := core.FindFirstByKindInSubs(each, KindRubyIdentifier)
+				if name == nil {
+					continue
+				}
+				valueUnit.Name = name.Content
+			}
+			funcUnit.Parameters = append(funcUnit.Parameters, valueUnit)
+		}
+	}
+
+	// the rest of children, body_statement or a single expression
+	var body []*core.Unit
+	for _, each := range unit.SubUnits[nameIndex+1:] {
+		if each.Kind != KindRubyMethodParameters {
+			body = append(body, each)
+		}
+	}
+	if len(body) != 0 {
+		funcUnit.BodySpan = core.Span{
+			Start: body[0].Span.Start,
+			End:   body[len(body)-1].Span.End,
+		}
+	}
+
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	funcUnit.Extras = extras
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
+	return funcUnit, nil
+}
@@ -0,0 +1,41 @@
+package ruby
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+// ruby has no import statement, files are loaded by method calls
+var requireMethods = []string{"require", "require_relative", "load"}
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	if unit.Kind != KindRubyCall || len(unit.SubUnits) < 2 {
+		return false
+	}
+	method := unit.SubUnits[0]
+	return method.Kind == KindRubyIdentifier && slices.Contains(requireMethods, method.Content)
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) {
+			continue
+		}
+		// require 'json'
+		file := core.FindFirstByKindInSubs(eachUnit.SubUnits[1], KindRubyString)
+		if file == nil {
+			continue
+		}
+		imp := object.NewImport()
+		imp.Span = eachUnit.Span
+		imp.Lang = extractor.GetLang()
+		imp.Source = strings.Trim(file.Content, "'\"")
+		imp.Wildcard = true
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
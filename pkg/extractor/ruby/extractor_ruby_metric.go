@@ -0,0 +1,57 @@
+package ruby
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindRubyIf,
+			KindRubyElsif,
+			KindRubyUnless,
+			KindRubyIfModifier,
+			KindRubyUnlessModifier,
+			KindRubyWhile,
+			KindRubyUntil,
+			KindRubyWhileModifier,
+			KindRubyUntilModifier,
+			KindRubyFor,
+			KindRubyWhen,
+			KindRubyRescue,
+			KindRubyConditional,
+		},
+		FlowKinds: []core.KindRepr{
+			KindRubyIf,
+			KindRubyUnless,
+			KindRubyIfModifier,
+			KindRubyUnlessModifier,
+			KindRubyWhile,
+			KindRubyUntil,
+			KindRubyWhileModifier,
+			KindRubyUntilModifier,
+			KindRubyFor,
+			KindRubyCase,
+			KindRubyRescue,
+			KindRubyConditional,
+		},
+		ElseIfKinds: []core.KindRepr{
+			KindRubyElsif,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindRubyBlock,
+			KindRubyDoBlock,
+			KindRubyLambda,
+		},
+		BinaryKinds: []core.KindRepr{
+			KindRubyBinary,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindRubyReturn,
+		},
+		CommentKinds: []core.KindRepr{
+			KindRubyComment,
+		},
+	}
+}
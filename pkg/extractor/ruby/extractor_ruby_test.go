@@ -0,0 +1,264 @@
+package ruby
+
+import (
+	"testing"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
+)
+
+var rubyCode = `
+require 'json'
+
+module Billing
+  module Core
+    class Invoice < Base
+      include Comparable
+      attr_reader :total
+
+      def initialize(total, *items, currency: "USD", &block)
+        @total = total
+        validate!(total)
+      end
+
+      def self.build(opts = {})
+        new(opts[:total]).tap { |i| i.save }
+      end
+
+      class << self
+        def registry; @registry ||= {}; end
+      end
+    end
+  end
+end
+
+def top_level(x)
+  puts x
+  Billing::Core::Invoice.build(total: x)
+end
+
+class Billing::Refund; end
+`
+
+func TestExtractor_ExtractFunctions(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRuby)
+	units, err := parser.Parse([]byte(rubyCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 4)
+
+	initialize := funcs[0]
+	assert.Equal(t, "initialize", initialize.Name)
+	assert.Equal(t, "Invoice", initialize.Receiver)
+	assert.Equal(t, "Billing::Core", initialize.Namespace)
+	assert.Equal(t, 10, initialize.DefLine)
+	assert.Len(t, initialize.Parameters, 4)
+	assert.Equal(t, "*items", initialize.Parameters[1].Name)
+	assert.Equal(t, "currency", initialize.Parameters[2].Name)
+	assert.False(t, initialize.Extras.(*FunctionExtras).Singleton)
+
+	build := funcs[1]
+	assert.Equal(t, "build", build.Name)
+	assert.True(t, build.Extras.(*FunctionExtras).Singleton)
+	assert.Equal(t, "opts", build.Parameters[0].Name)
+
+	registry := funcs[2]
+	assert.Equal(t, "registry", registry.Name)
+	assert.True(t, registry.Extras.(*FunctionExtras).Singleton)
+
+	topLevel := funcs[3]
+	assert.Equal(t, "||top_level||", topLevel.GetSignature())
+	assert.Equal(t, "", topLevel.Receiver)
+}
+
+func TestExtractor_ExtractClasses(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRuby)
+	units, err := parser.Parse([]byte(rubyCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 4)
+
+	assert.Equal(t, "Billing", classes[0].Name)
+	assert.Equal(t, "module", classes[0].Extras.(*ClassExtras).Kind)
+	assert.Equal(t, "Billing", classes[1].Module)
+
+	invoice := classes[2]
+	assert.Equal(t, "Invoice", invoice.Name)
+	assert.Equal(t, "Billing::Core", invoice.Module)
+	extras := invoice.Extras.(*ClassExtras)
+	assert.Equal(t, "Base", extras.Superclass)
+	assert.Equal(t, []string{"Comparable"}, extras.Mixins)
+
+	refund := classes[3]
+	assert.Equal(t, "Refund", refund.Name)
+	assert.Equal(t, "Billing", refund.Module)
+}
+
+func TestExtractor_ExtractCalls(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRuby)
+	units, err := parser.Parse([]byte(rubyCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	assert.Len(t, calls, 6)
+	assert.Equal(t, "validate!", calls[0].Caller)
+	assert.Equal(t, []string{"total"}, calls[0].Arguments)
+	assert.Equal(t, "new(opts[:total]).tap", calls[1].Caller)
+	assert.Equal(t, "i.save", calls[3].Caller)
+	assert.Equal(t, "Billing::Core::Invoice.build", calls[5].Caller)
+}
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRuby)
+	units, err := parser.Parse([]byte("require 'json'\nrequire_relative \"lib/a\"\nputs 'x'\n"))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 2)
+	assert.Equal(t, "json", imports[0].Source)
+	assert.Equal(t, "lib/a", imports[1].Source)
+}
+
+var rubyCommentCode = `
+# A user.
+class User
+  # full name
+  def name
+  end
+end
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRuby)
+	units, err := parser.Parse([]byte(rubyCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "A user.", comments[0].Text)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "full name", functions[0].Extras.(*FunctionExtras).Doc)
+	assert.Equal(t, functions[0].GetSignature(), comments[1].Owner)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "A user.", classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var rubyVariableCode = `
+MAX = 10
+
+module Billing
+  class Invoice
+    RATE = 0.1
+    @@count = 0
+
+    def initialize
+      @total = 0
+    end
+
+    def pay
+      @paid = true
+      amount = 1
+    end
+  end
+end
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRuby)
+	units, err := parser.Parse([]byte(rubyVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 5)
+
+	assert.Equal(t, "MAX", variables[0].Name)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeModule, variables[0].Scope)
+
+	assert.Equal(t, "RATE", variables[1].Name)
+	assert.Equal(t, object.VariableScopeClass, variables[1].Scope)
+	assert.Equal(t, "Invoice", variables[1].Receiver)
+	assert.Equal(t, "Billing", variables[1].Namespace)
+
+	assert.Equal(t, "@@count", variables[2].Name)
+	assert.Equal(t, object.MutabilityVar, variables[2].Mutability)
+
+	// instance variables in initialize only
+	assert.Equal(t, "@total", variables[3].Name)
+	assert.Equal(t, object.VariableScopeClass, variables[3].Scope)
+
+	assert.Equal(t, "amount", variables[4].Name)
+	assert.Equal(t, object.VariableScopeLocal, variables[4].Scope)
+}
+
+var rubyMetricCode = `
+def f(a)
+  if a > 0 && a < 3 and a
+    return 1
+  elsif a < 0
+    return 2
+  else
+  end
+  unless a then end
+  for i in 0..3 do end
+  while a > 0 do end
+  until a > 0 do end
+  case a
+  when 1 then 1
+  else 2
+  end
+  begin
+  rescue StandardError
+  end
+  b = a > 0 ? 1 : 2
+  c = 1 if a
+  xs.each { |x| x }
+  xs.each do |x| x end
+  return 0
+end
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRuby)
+	units, err := parser.Parse([]byte(rubyMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 13, metrics.Cyclomatic)
+	assert.Equal(t, 12, metrics.Cognitive)
+	assert.Equal(t, 24, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
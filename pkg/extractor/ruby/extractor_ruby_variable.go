@@ -0,0 +1,76 @@
+package ruby
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	if unit.Kind != KindRubyAssignment || len(unit.SubUnits) == 0 {
+		return false
+	}
+	// multiple assignments are ignored, eg: `a, b = 1, 2`
+	switch unit.SubUnits[0].Kind {
+	case KindRubyConstant, KindRubyIdentifier, KindRubyClassVariable, KindRubyInstanceVariable:
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		if v := extractor.extractVariable(eachUnit); v != nil {
+			ret = append(ret, v)
+		}
+	}
+	return ret, nil
+}
+
+// extractVariable assignment: left, value
+func (extractor *Extractor) extractVariable(unit *core.Unit) *object.Variable {
+	left := unit.SubUnits[0]
+	v := object.NewVariable()
+	v.Span = unit.Span
+	v.Lang = extractor.GetLang()
+	v.Name = left.Content
+	if len(unit.SubUnits) > 1 {
+		v.Value = unit.SubUnits[len(unit.SubUnits)-1].Content
+	}
+	if left.Kind == KindRubyConstant {
+		v.Mutability = object.MutabilityConst
+	}
+
+	// Billing::Core::Invoice -> namespace Billing::Core, receiver Invoice
+	scopes := findScopes(unit)
+	if len(scopes) != 0 {
+		v.Receiver = scopes[len(scopes)-1]
+		v.Namespace = strings.Join(scopes[:len(scopes)-1], ScopeSplit)
+	}
+
+	owner := core.FindFirstByOneOfKindInParent(unit.ParentUnit, KindRubyMethod, KindRubySingletonMethod,
+		KindRubyBlock, KindRubyDoBlock, KindRubyLambda, KindRubyClass, KindRubyModule)
+	switch {
+	case left.Kind == KindRubyInstanceVariable:
+		// instance variables, `@a = 1` in `initialize`
+		name := core.FindFirstByKindInSubs(owner, KindRubyIdentifier)
+		if owner == nil || owner.Kind != KindRubyMethod || name == nil || name.Content != "initialize" {
+			return nil
+		}
+		v.Scope = object.VariableScopeClass
+	case left.Kind == KindRubyClassVariable:
+		v.Scope = object.VariableScopeClass
+	case owner == nil:
+	case owner.Kind == KindRubyClass || owner.Kind == KindRubyModule:
+		v.Scope = object.VariableScopeClass
+	default:
+		v.Scope = object.VariableScopeLocal
+		v.Receiver = ""
+	}
+	return v
+}
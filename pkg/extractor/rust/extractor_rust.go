@@ -0,0 +1,143 @@
+package rust
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+// https://github.com/tree-sitter/tree-sitter-rust/blob/master/src/node-types.json
+const (
+	KindRustSourceFile            core.KindRepr = "source_file"
+	KindRustModItem               core.KindRepr = "mod_item"
+	KindRustFunctionItem          core.KindRepr = "function_item"
+	KindRustFunctionSignatureItem core.KindRepr = "function_signature_item"
+	KindRustImplItem              core.KindRepr = "impl_item"
+	KindRustTraitItem             core.KindRepr = "trait_item"
+	KindRustStructItem            core.KindRepr = "struct_item"
+	KindRustEnumItem              core.KindRepr = "enum_item"
+	KindRustUnionItem             core.KindRepr = "union_item"
+	KindRustAttributeItem         core.KindRepr = "attribute_item"
+	KindRustVisibilityModifier    core.KindRepr = "visibility_modifier"
+	KindRustFunctionModifiers     core.KindRepr = "function_modifiers"
+	KindRustIdentifier            core.KindRepr = "identifier"
+	KindRustTypeIdentifier        core.KindRepr = "type_identifier"
+	KindRustTypeParameters        core.KindRepr = "type_parameters"
+	KindRustParameters            core.KindRepr = "parameters"
+	KindRustParameter             core.KindRepr = "parameter"
+	KindRustSelfParameter         core.KindRepr = "self_parameter"
+	KindRustVariadicParameter     core.KindRepr = "variadic_parameter"
+	KindRustWhereClause           core.KindRepr = "where_clause"
+	KindRustBlock                 core.KindRepr = "block"
+	KindRustDeclarationList       core.KindRepr = "declaration_list"
+	KindRustFieldDeclarationList  core.KindRepr = "field_declaration_list"
+	KindRustOrderedFieldDeclList  core.KindRepr = "ordered_field_declaration_list"
+	KindRustFieldDeclaration      core.KindRepr = "field_declaration"
+	KindRustFieldIdentifier       core.KindRepr = "field_identifier"
+	KindRustEnumVariantList       core.KindRepr = "enum_variant_list"
+	KindRustEnumVariant           core.KindRepr = "enum_variant"
+	KindRustCallExpression        core.KindRepr = "call_expression"
+	KindRustMacroInvocation       core.KindRepr = "macro_invocation"
+	KindRustArguments             core.KindRepr = "arguments"
+	KindRustTokenTree             core.KindRepr = "token_tree"
+	KindRustTraitBounds           core.KindRepr = "trait_bounds"
+	KindRustLineComment           core.KindRepr = "line_comment"
+	KindRustBlockComment          core.KindRepr = "block_comment"
+	KindRustUseDeclaration        core.KindRepr = "use_declaration"
+	KindRustScopedIdentifier      core.KindRepr = "scoped_identifier"
+	KindRustScopedUseList         core.KindRepr = "scoped_use_list"
+	KindRustUseList               core.KindRepr = "use_list"
+	KindRustUseAsClause           core.KindRepr = "use_as_clause"
+	KindRustUseWildcard           core.KindRepr = "use_wildcard"
+	KindRustConstItem             core.KindRepr = "const_item"
+	KindRustStaticItem            core.KindRepr = "static_item"
+	KindRustLetDeclaration        core.KindRepr = "let_declaration"
+	KindRustMutableSpecifier      core.KindRepr = "mutable_specifier"
+	KindRustScopedTypeIdentifier  core.KindRepr = "scoped_type_identifier"
+	KindRustIfExpression          core.KindRepr = "if_expression"
+	KindRustIfLetExpression       core.KindRepr = "if_let_expression"
+	KindRustForExpression         core.KindRepr = "for_expression"
+	KindRustWhileExpression       core.KindRepr = "while_expression"
+	KindRustWhileLetExpression    core.KindRepr = "while_let_expression"
+	KindRustMatchArm              core.KindRepr = "match_arm"
+	KindRustLoopExpression        core.KindRepr = "loop_expression"
+	KindRustMatchExpression       core.KindRepr = "match_expression"
+	KindRustClosureExpression     core.KindRepr = "closure_expression"
+	KindRustBinaryExpression      core.KindRepr = "binary_expression"
+	KindRustReturnExpression      core.KindRepr = "return_expression"
+	ModSplit                                    = "::"
+)
+
+type Extractor struct {
+}
+
+func (extractor *Extractor) GetLang() core.LangType {
+	return core.LangRust
+}
+
+// findModPath module path (`a::b`) of this unit inside current file
+func findModPath(unit *core.Unit) string {
+	var parts []string
+	for cur := unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		if cur.Kind != KindRustModItem {
+			continue
+		}
+		name := core.FindFirstByKindInSubs(cur, KindRustIdentifier)
+		if name == nil {
+			continue
+		}
+		parts = append([]string{name.Content}, parts...)
+	}
+	return strings.Join(parts, ModSplit)
+}
+
+// findAttributes collect the attributes which directly stick to this item
+// eg: `#[derive(Debug)]` -> `derive(Debug)`
+func findAttributes(unit *core.Unit) []string {
+	parent := unit.ParentUnit
+	if parent == nil {
+		return nil
+	}
+	index := -1
+	for i, each := range parent.SubUnits {
+		if each == unit {
+			index = i
+			break
+		}
+	}
+
+	var ret []string
+	for i := index - 1; i >= 0; i-- {
+		each := parent.SubUnits[i]
+		if each.Kind == KindRustLineComment || each.Kind == KindRustBlockComment {
+			continue
+		}
+		if each.Kind != KindRustAttributeItem {
+			break
+		}
+		content := strings.TrimSuffix(strings.TrimPrefix(each.Content, "#["), "]")
+		ret = append([]string{content}, ret...)
+	}
+	return ret
+}
+
+// splitImpl returns type and trait (can be nil) of an impl block
+// eg: `impl fmt::Display for Request<String>` -> Request<String>, fmt::Display
+func splitImpl(unit *core.Unit) (*core.Unit, *core.Unit) {
+	var types []*core.Unit
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindRustTypeParameters, KindRustWhereClause, KindRustDeclarationList,
+			KindRustVisibilityModifier, KindRustLineComment, KindRustBlockComment:
+			continue
+		}
+		types = append(types, each)
+	}
+	switch len(types) {
+	case 0:
+		return nil, nil
+	case 1:
+		return types[0], nil
+	}
+	return types[len(types)-1], types[len(types)-2]
+}
@@ -0,0 +1,74 @@
+package rust
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsCall(unit *core.Unit) bool {
+	if unit.Kind == KindRustCallExpression || unit.Kind == KindRustMacroInvocation {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractCalls(units []*core.Unit) ([]*object.Call, error) {
+	var ret []*object.Call
+	for _, eachUnit := range units {
+		if !extractor.IsCall(eachUnit) {
+			continue
+		}
+
+		eachCall, err := extractor.unit2Call(eachUnit)
+		if err != nil {
+			core.Log.Warnf("err: %v", err)
+			continue
+		}
+		ret = append(ret, eachCall)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
+	funcUnit := core.FindFirstByKindInParent(unit, KindRustFunctionItem)
+	var srcFunc *object.Function
+	var err error
+	if funcUnit != nil {
+		srcFunc, err = extractor.ExtractFunction(funcUnit)
+		if err != nil {
+			return nil, errors.New("convert func failed: " + funcUnit.Content)
+		}
+	}
+
+	// headless, give up (temp
+	if srcFunc == nil {
+		return nil, errors.New("headless call")
+	}
+	if len(unit.SubUnits) == 0 {
+		return nil, errors.New("invalid call: " + unit.Content)
+	}
+
+	caller := unit.SubUnits[0].Content
+	argKind := KindRustArguments
+	if unit.Kind == KindRustMacroInvocation {
+		// keep the `!` to separate macros from functions
+		caller = caller + "!"
+		argKind = KindRustTokenTree
+	}
+
+	var arguments []string
+	args := core.FindFirstByKindInSubs(unit, argKind)
+	for _, each := range core.FindAllByKindInSubs(args, KindRustIdentifier) {
+		arguments = append(arguments, each.Content)
+	}
+
+	ret := &object.Call{
+		Src:       srcFunc.GetSignature(),
+		Caller:    caller,
+		Arguments: arguments,
+		Span:      unit.Span,
+	}
+	return ret, nil
+}
@@ -0,0 +1,135 @@
+package rust
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type Field struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type ClassExtras struct {
+	// struct, enum, union or trait
+	Kind       string   `json:"kind"`
+	Attributes []string `json:"attributes"`
+	// struct fields, or enum variants
+	Fields []*Field `json:"fields"`
+	// method names declared in trait
+	Methods []string `json:"methods"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
+var classKinds = map[core.KindRepr]string{
+	KindRustStructItem: "struct",
+	KindRustEnumItem:   "enum",
+	KindRustUnionItem:  "union",
+	KindRustTraitItem:  "trait",
+}
+
+func (extractor *Extractor) IsClass(unit *core.Unit) bool {
+	_, ok := classKinds[unit.Kind]
+	return ok
+}
+
+func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
+	var ret []*object.Clazz
+	for _, eachUnit := range units {
+		if !extractor.IsClass(eachUnit) {
+			continue
+		}
+		eachClazz, err := extractor.ExtractClass(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachClazz)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error) {
+	clazz := object.NewClazz()
+	clazz.Span = unit.Span
+	clazz.Lang = extractor.GetLang()
+	clazz.Unit = unit
+	clazz.Module = findModPath(unit)
+
+	name := core.FindFirstByKindInSubs(unit, KindRustTypeIdentifier)
+	if name == nil {
+		return nil, errors.New("no class name found in " + unit.Content)
+	}
+	clazz.Name = name.Content
+
+	extras := &ClassExtras{
+		Kind:       classKinds[unit.Kind],
+		Attributes: findAttributes(unit),
+	}
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindRustFieldDeclarationList, KindRustOrderedFieldDeclList:
+			extras.Fields = extractFields(each)
+		case KindRustEnumVariantList:
+			for _, eachVariant := range core.FindAllByKindInSubs(each, KindRustEnumVariant) {
+				variantName := core.FindFirstByKindInSubs(eachVariant, KindRustIdentifier)
+				if variantName == nil {
+					continue
+				}
+				field := &Field{Name: variantName.Content}
+				// payload, `(String)` or `{ id: u32 }`
+				if len(eachVariant.SubUnits) > 1 {
+					field.Type = eachVariant.SubUnits[len(eachVariant.SubUnits)-1].Content
+				}
+				extras.Fields = append(extras.Fields, field)
+			}
+		case KindRustDeclarationList:
+			for _, eachItem := range each.SubUnits {
+				if eachItem.Kind != KindRustFunctionItem && eachItem.Kind != KindRustFunctionSignatureItem {
+					continue
+				}
+				methodName := core.FindFirstByKindInSubs(eachItem, KindRustIdentifier)
+				if methodName != nil {
+					extras.Methods = append(extras.Methods, methodName.Content)
+				}
+			}
+		}
+	}
+	extras.Doc = extractor.commentRule().FindDoc(unit)
+	clazz.Extras = extras
+	return clazz, nil
+}
+
+func extractFields(unit *core.Unit) []*Field {
+	var ret []*Field
+	if unit.Kind == KindRustOrderedFieldDeclList {
+		// tuple struct, names are their indexes
+		index := 0
+		for _, each := range unit.SubUnits {
+			if each.Kind == KindRustVisibilityModifier || each.Kind == KindRustAttributeItem {
+				continue
+			}
+			ret = append(ret, &Field{
+				Type: each.Content,
+				Name: strconv.Itoa(index),
+			})
+			index++
+		}
+		return ret
+	}
+
+	for _, each := range core.FindAllByKindInSubs(unit, KindRustFieldDeclaration) {
+		name := core.FindFirstByKindInSubs(each, KindRustFieldIdentifier)
+		if name == nil || len(each.SubUnits) == 0 {
+			continue
+		}
+		ret = append(ret, &Field{
+			Type: each.SubUnits[len(each.SubUnits)-1].Content,
+			Name: name.Content,
+		})
+	}
+	return ret
+}
@@ -0,0 +1,23 @@
+package rust
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsComment(unit *core.Unit) bool {
+	return unit.Kind == KindRustLineComment || unit.Kind == KindRustBlockComment
+}
+
+func (extractor *Extractor) ExtractComments(units []*core.Unit) ([]*object.Comment, error) {
+	return object.ExtractComments(extractor, extractor.commentRule(), units)
+}
+
+func (extractor *Extractor) commentRule() *object.CommentRule {
+	rule := object.NewCommentRule(extractor)
+	// `#[test]` between doc comments and functions
+	rule.IsSkipped = func(unit *core.Unit) bool {
+		return extractor.IsComment(unit) || unit.Kind == KindRustAttributeItem
+	}
+	return rule
+}
@@ -0,0 +1,142 @@
+package rust
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type FunctionExtras struct {
+	// eg: test, derive(Debug)
+	Attributes []string `json:"attributes"`
+	// eg: pub, async, unsafe
+	Modifiers []string `json:"modifiers"`
+	// trait implemented by the impl block
+	Trait          string `json:"trait"`
+	TypeParameters string `json:"typeParameters"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
+	if unit.Kind == KindRustFunctionItem || unit.Kind == KindRustFunctionSignatureItem {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Function, error) {
+	var ret []*object.Function
+	for _, eachUnit := range units {
+		if !extractor.IsFunction(eachUnit) {
+			continue
+		}
+		eachFunc, err := extractor.ExtractFunction(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachFunc)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
+	funcUnit.Span = unit.Span
+	funcUnit.Unit = unit
+	funcUnit.Lang = extractor.GetLang()
+	funcUnit.Namespace = findModPath(unit)
+
+	extras := &FunctionExtras{
+		Attributes: findAttributes(unit),
+	}
+
+	// receiver
+	container := core.FindFirstByOneOfKindInParent(unit, KindRustImplItem, KindRustTraitItem)
+	if container != nil {
+		switch container.Kind {
+		case KindRustImplItem:
+			implType, implTrait := splitImpl(container)
+			if implType != nil {
+				funcUnit.Receiver = implType.Content
+			}
+			if implTrait != nil {
+				extras.Trait = implTrait.Content
+			}
+		case KindRustTraitItem:
+			// default methods
+			traitName := core.FindFirstByKindInSubs(container, KindRustTypeIdentifier)
+			if traitName != nil {
+				funcUnit.Receiver = traitName.Content
+				extras.Trait = traitName.Content
+			}
+		}
+	}
+
+	funcName := core.FindFirstByKindInSubs(unit, KindRustIdentifier)
+	if funcName == nil {
+		return nil, errors.New("no func name found in " + unit.Content)
+	}
+	funcUnit.Name = funcName.Content
+	funcUnit.DefLine = int(funcName.Span.Start.Row + 1)
+
+	// fn [name] [type_parameters] [parameters] [return type] [where] [block]
+	afterParams := false
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindRustVisibilityModifier, KindRustFunctionModifiers:
+			extras.Modifiers = append(extras.Modifiers, each.Content)
+		case KindRustTypeParameters:
+			extras.TypeParameters = each.Content
+		case KindRustParameters:
+			funcUnit.Parameters = extractParameters(each)
+			afterParams = true
+		case KindRustBlock:
+			funcUnit.BodySpan = each.Span
+		case KindRustWhereClause, KindRustLineComment, KindRustBlockComment:
+			continue
+		default:
+			if afterParams && len(funcUnit.Returns) == 0 {
+				funcUnit.Returns = append(funcUnit.Returns, &object.ValueUnit{
+					Type: each.Content,
+				})
+			}
+		}
+	}
+
+	extras.Doc = extractor.commentRule().FindDoc(unit)
+	funcUnit.Extras = extras
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
+	return funcUnit, nil
+}
+
+func extractParameters(unit *core.Unit) []*object.ValueUnit {
+	var ret []*object.ValueUnit
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindRustSelfParameter:
+			ret = append(ret, &object.ValueUnit{
+				Type: each.Content,
+				Name: "self",
+			})
+		case KindRustParameter:
+			// pattern: type
+			// some patterns (like `_`) are anonymous nodes
+			if len(each.SubUnits) == 0 {
+				continue
+			}
+			name, _, _ := strings.Cut(each.Content, ":")
+			ret = append(ret, &object.ValueUnit{
+				Type: each.SubUnits[len(each.SubUnits)-1].Content,
+				Name: strings.TrimSpace(name),
+			})
+		case KindRustVariadicParameter:
+			ret = append(ret, &object.ValueUnit{
+				Type: each.Content,
+			})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,104 @@
+package rust
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+const pathSplit = "::"
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindRustUseDeclaration
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) {
+			continue
+		}
+		imp := object.NewImport()
+		imp.Span = eachUnit.Span
+		imp.Lang = extractor.GetLang()
+
+		// `pub` maybe the first one
+		var tree *core.Unit
+		for _, each := range eachUnit.SubUnits {
+			switch each.Kind {
+			case KindRustIdentifier, KindRustScopedIdentifier, KindRustScopedUseList,
+				KindRustUseList, KindRustUseAsClause, KindRustUseWildcard:
+				tree = each
+			}
+		}
+		if tree == nil {
+			continue
+		}
+
+		switch tree.Kind {
+		case KindRustIdentifier:
+			// use std;
+			imp.Source = tree.Content
+		case KindRustScopedIdentifier:
+			// use std::io;
+			source, name := splitPath(tree.Content)
+			imp.Source = source
+			imp.AddName(name, "")
+		case KindRustUseAsClause:
+			// use c::d as e;
+			if len(tree.SubUnits) < 2 {
+				continue
+			}
+			source, name := splitPath(tree.SubUnits[0].Content)
+			if source == "" {
+				imp.Source = name
+				imp.Alias = tree.SubUnits[1].Content
+			} else {
+				imp.Source = source
+				imp.AddName(name, tree.SubUnits[1].Content)
+			}
+		case KindRustUseWildcard:
+			// use a::b::*;
+			imp.Source = strings.TrimSuffix(strings.TrimSuffix(tree.Content, "*"), pathSplit)
+			imp.Wildcard = true
+		case KindRustScopedUseList:
+			// use std::collections::{HashMap, HashSet as Set};
+			if len(tree.SubUnits) < 2 {
+				continue
+			}
+			imp.Source = tree.SubUnits[0].Content
+			fillUseList(imp, tree.SubUnits[1])
+		case KindRustUseList:
+			// use {a, b};
+			fillUseList(imp, tree)
+		}
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
+
+func fillUseList(imp *object.Import, useList *core.Unit) {
+	for _, each := range useList.SubUnits {
+		switch each.Kind {
+		case KindRustUseAsClause:
+			if len(each.SubUnits) < 2 {
+				continue
+			}
+			imp.AddName(each.SubUnits[0].Content, each.SubUnits[1].Content)
+		case KindRustUseWildcard:
+			imp.Wildcard = true
+		default:
+			// nested paths are kept as they are, eg: `io::Read`
+			imp.AddName(each.Content, "")
+		}
+	}
+}
+
+// splitPath `a::b::c` -> `a::b`, `c`
+func splitPath(p string) (string, string) {
+	if i := strings.LastIndex(p, pathSplit); i != -1 {
+		return p[:i], p[i+len(pathSplit):]
+	}
+	return "", p
+}
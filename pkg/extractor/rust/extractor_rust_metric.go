@@ -0,0 +1,42 @@
+package rust
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindRustIfExpression,
+			KindRustIfLetExpression,
+			KindRustForExpression,
+			KindRustWhileExpression,
+			KindRustWhileLetExpression,
+			KindRustMatchArm,
+		},
+		FlowKinds: []core.KindRepr{
+			KindRustIfExpression,
+			KindRustIfLetExpression,
+			KindRustForExpression,
+			KindRustWhileExpression,
+			KindRustWhileLetExpression,
+			KindRustLoopExpression,
+			KindRustMatchExpression,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindRustClosureExpression,
+			KindRustFunctionItem,
+		},
+		BinaryKinds: []core.KindRepr{
+			KindRustBinaryExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindRustReturnExpression,
+		},
+		CommentKinds: []core.KindRepr{
+			KindRustLineComment,
+			KindRustBlockComment,
+		},
+	}
+}
@@ -0,0 +1,302 @@
+package rust
+
+import (
+	"testing"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
+)
+
+var rustCode = `
+use std::fmt;
+
+mod net {
+    pub mod http {
+        #[derive(Debug, Clone)]
+        pub struct Request<T> {
+            pub url: String,
+            body: Option<T>,
+        }
+
+        pub enum Method { Get, Post(String), Put { id: u32 } }
+
+        pub trait Handler {
+            fn handle(&self, req: &Request<String>) -> Result<(), Error>;
+            fn name(&self) -> &str { "h" }
+        }
+
+        impl<T> Request<T> {
+            pub fn new(url: &str) -> Self {
+                let s = String::from(url);
+                println!("{}", s);
+                Request { url: s, body: None }
+            }
+            pub async fn send(&mut self, retries: u32, _: bool) {
+                self.url.push_str("x");
+                helper::run(retries);
+            }
+        }
+
+        impl fmt::Display for Request<String> {
+            fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+                write!(f, "{}", self.url)
+            }
+        }
+    }
+}
+
+pub fn top<'a>(x: i32, y: &'a str) -> i32 { x }
+
+#[cfg(test)]
+mod tests {
+    #[test]
+    fn it_works() {
+        assert_eq!(2 + 2, 4);
+    }
+}
+struct Unit;
+struct Tup(i32, String);
+`
+
+func TestExtractor_ExtractFunctions(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRust)
+	units, err := parser.Parse([]byte(rustCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 7)
+
+	// trait
+	handle := funcs[0]
+	assert.Equal(t, "handle", handle.Name)
+	assert.Equal(t, "Handler", handle.Receiver)
+	assert.Equal(t, "net::http", handle.Namespace)
+	assert.Equal(t, "Result<(), Error>", handle.Returns[0].Type)
+
+	// impl
+	newFunc := funcs[2]
+	assert.Equal(t, "new", newFunc.Name)
+	assert.Equal(t, "Request<T>", newFunc.Receiver)
+	assert.Equal(t, "net::http|Request<T>|new|&str|Self", newFunc.GetSignature())
+
+	send := funcs[3]
+	assert.Equal(t, []string{"pub", "async"}, send.Extras.(*FunctionExtras).Modifiers)
+	assert.Len(t, send.Parameters, 3)
+	assert.Equal(t, "&mut self", send.Parameters[0].Type)
+	assert.Empty(t, send.Returns)
+
+	// trait impl
+	fmtFunc := funcs[4]
+	assert.Equal(t, "Request<String>", fmtFunc.Receiver)
+	assert.Equal(t, "fmt::Display", fmtFunc.Extras.(*FunctionExtras).Trait)
+
+	top := funcs[5]
+	assert.Equal(t, "", top.Namespace)
+	assert.Equal(t, "<'a>", top.Extras.(*FunctionExtras).TypeParameters)
+	assert.Equal(t, "i32", top.Returns[0].Type)
+
+	itWorks := funcs[6]
+	assert.Equal(t, "tests", itWorks.Namespace)
+	assert.Equal(t, []string{"test"}, itWorks.Extras.(*FunctionExtras).Attributes)
+}
+
+func TestExtractor_ExtractClasses(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRust)
+	units, err := parser.Parse([]byte(rustCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 5)
+
+	request := classes[0]
+	assert.Equal(t, "Request", request.Name)
+	assert.Equal(t, "net::http", request.Module)
+	extras := request.Extras.(*ClassExtras)
+	assert.Equal(t, []string{"derive(Debug, Clone)"}, extras.Attributes)
+	assert.Len(t, extras.Fields, 2)
+	assert.Equal(t, "Option<T>", extras.Fields[1].Type)
+
+	method := classes[1].Extras.(*ClassExtras)
+	assert.Equal(t, "enum", method.Kind)
+	assert.Len(t, method.Fields, 3)
+	assert.Equal(t, "(String)", method.Fields[1].Type)
+
+	handler := classes[2].Extras.(*ClassExtras)
+	assert.Equal(t, "trait", handler.Kind)
+	assert.Equal(t, []string{"handle", "name"}, handler.Methods)
+
+	tup := classes[4].Extras.(*ClassExtras)
+	assert.Equal(t, "0", tup.Fields[0].Name)
+	assert.Equal(t, "String", tup.Fields[1].Type)
+}
+
+func TestExtractor_ExtractCalls(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRust)
+	units, err := parser.Parse([]byte(rustCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	assert.Len(t, calls, 6)
+	assert.Equal(t, "String::from", calls[0].Caller)
+	assert.Equal(t, []string{"url"}, calls[0].Arguments)
+	assert.Equal(t, "println!", calls[1].Caller)
+	assert.Equal(t, "self.url.push_str", calls[2].Caller)
+	assert.Equal(t, "assert_eq!", calls[5].Caller)
+}
+
+var rustImportCode = `
+use std::io;
+use std::collections::{HashMap, HashSet as Set};
+pub use a::b::*;
+use c::d as e;
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRust)
+	units, err := parser.Parse([]byte(rustImportCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 4)
+	assert.Equal(t, "std", imports[0].Source)
+	assert.Equal(t, []string{"io"}, imports[0].Names)
+	assert.Equal(t, "std::collections", imports[1].Source)
+	assert.Equal(t, []string{"HashMap", "HashSet"}, imports[1].Names)
+	assert.Equal(t, "Set", imports[1].Aliases["HashSet"])
+	assert.Equal(t, "a::b", imports[2].Source)
+	assert.True(t, imports[2].Wildcard)
+	assert.Equal(t, "e", imports[3].Aliases["d"])
+}
+
+var rustCommentCode = `
+/// Adds one.
+#[inline]
+pub fn add_one(x: i32) -> i32 {
+    x + 1
+}
+
+/* a point */
+struct Point {
+    x: i32,
+}
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRust)
+	units, err := parser.Parse([]byte(rustCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "Adds one.", comments[0].Text)
+	assert.Equal(t, "||add_one|i32|i32", comments[0].Owner)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "Adds one.", functions[0].Extras.(*FunctionExtras).Doc)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "a point", classes[0].Extras.(*ClassExtras).Doc)
+}
+
+var rustVariableCode = `
+const MAX: u32 = 10;
+static mut COUNT: i32 = 0;
+
+struct Point {
+    x: i32,
+}
+
+impl Point {
+    const ORIGIN: i32 = 0;
+
+    fn f() {
+        let mut y = 1;
+        let (a, b) = (1, 2);
+    }
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRust)
+	units, err := parser.Parse([]byte(rustVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 5)
+
+	assert.Equal(t, "MAX", variables[0].Name)
+	assert.Equal(t, "u32", variables[0].Type)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeModule, variables[0].Scope)
+
+	assert.Equal(t, object.MutabilityVar, variables[1].Mutability)
+
+	assert.Equal(t, "x", variables[2].Name)
+	assert.Equal(t, object.VariableScopeClass, variables[2].Scope)
+	assert.Equal(t, "Point", variables[2].Receiver)
+
+	// associated const
+	assert.Equal(t, "ORIGIN", variables[3].Name)
+	assert.Equal(t, "Point", variables[3].Receiver)
+
+	assert.Equal(t, "y", variables[4].Name)
+	assert.Empty(t, variables[4].Type)
+	assert.Equal(t, "1", variables[4].Value)
+	assert.Equal(t, object.VariableScopeLocal, variables[4].Scope)
+}
+
+var rustMetricCode = `
+fn f(a: i32) -> i32 {
+    if a > 0 && a < 3 { return 1; } else if a < 0 { return 2; } else {}
+    for i in 0..3 {}
+    while a > 0 {}
+    loop { break; }
+    match a { 1 => {}, _ => {} }
+    if let Some(x) = o {}
+    while let Some(x) = o {}
+    let l = |x| x + 1;
+    let v = r?;
+    return 0;
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangRust)
+	units, err := parser.Parse([]byte(rustMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 10, metrics.Cyclomatic)
+	assert.Equal(t, 9, metrics.Cognitive)
+	assert.Equal(t, 12, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
@@ -0,0 +1,92 @@
+package rust
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	switch unit.Kind {
+	case KindRustConstItem, KindRustStaticItem, KindRustLetDeclaration:
+		// patterns are ignored, eg: `let (a, b) = (1, 2);`
+		return core.FindFirstByKindInSubs(unit, KindRustIdentifier) != nil
+	case KindRustFieldDeclaration:
+		return core.FindFirstByKindInSubs(unit, KindRustFieldIdentifier) != nil
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		ret = append(ret, extractor.extractVariable(eachUnit))
+	}
+	return ret, nil
+}
+
+// extractVariable [visibility], [mut], name, [type], [value]
+func (extractor *Extractor) extractVariable(unit *core.Unit) *object.Variable {
+	v := object.NewVariable()
+	v.Span = unit.Span
+	v.Lang = extractor.GetLang()
+	v.Namespace = findModPath(unit)
+
+	var mutable bool
+	var name *core.Unit
+	for _, each := range unit.SubUnits {
+		switch {
+		case each.Kind == KindRustMutableSpecifier:
+			mutable = true
+		case each.Kind == KindRustVisibilityModifier:
+		case name == nil && (each.Kind == KindRustIdentifier || each.Kind == KindRustFieldIdentifier):
+			name = each
+			v.Name = each.Content
+		case name == nil:
+			// pattern
+		case v.Type == "" && v.Value == "" && isType(each):
+			v.Type = each.Content
+		default:
+			v.Value = each.Content
+		}
+	}
+
+	switch unit.Kind {
+	case KindRustConstItem:
+		v.Mutability = object.MutabilityConst
+	case KindRustStaticItem, KindRustLetDeclaration:
+		if !mutable {
+			v.Mutability = object.MutabilityFinal
+		}
+	}
+
+	// associated consts and fields belong to types
+	owner := core.FindFirstByOneOfKindInParent(unit.ParentUnit,
+		KindRustBlock, KindRustImplItem, KindRustTraitItem, KindRustStructItem, KindRustEnumItem, KindRustUnionItem)
+	switch {
+	case owner == nil:
+	case owner.Kind == KindRustBlock:
+		v.Scope = object.VariableScopeLocal
+	case owner.Kind == KindRustImplItem:
+		v.Scope = object.VariableScopeClass
+		if implType, _ := splitImpl(owner); implType != nil {
+			v.Receiver = implType.Content
+		}
+	default:
+		v.Scope = object.VariableScopeClass
+		if typeName := core.FindFirstByKindInSubs(owner, KindRustTypeIdentifier); typeName != nil {
+			v.Receiver = typeName.Content
+		}
+	}
+	return v
+}
+
+func isType(unit *core.Unit) bool {
+	return strings.HasSuffix(unit.Kind, "_type") ||
+		unit.Kind == KindRustTypeIdentifier ||
+		unit.Kind == KindRustScopedTypeIdentifier
+}
@@ -0,0 +1,116 @@
+package scala
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+// https://github.com/tree-sitter/tree-sitter-scala/blob/master/src/node-types.json
+const (
+	KindScalaCompilationUnit        core.KindRepr = "compilation_unit"
+	KindScalaPackageClause          core.KindRepr = "package_clause"
+	KindScalaPackageIdentifier      core.KindRepr = "package_identifier"
+	KindScalaClassDefinition        core.KindRepr = "class_definition"
+	KindScalaObjectDefinition       core.KindRepr = "object_definition"
+	KindScalaTraitDefinition        core.KindRepr = "trait_definition"
+	KindScalaFunctionDefinition     core.KindRepr = "function_definition"
+	KindScalaFunctionDeclaration    core.KindRepr = "function_declaration"
+	KindScalaIdentifier             core.KindRepr = "identifier"
+	KindScalaAnnotation             core.KindRepr = "annotation"
+	KindScalaModifiers              core.KindRepr = "modifiers"
+	KindScalaTypeParameters         core.KindRepr = "type_parameters"
+	KindScalaParameters             core.KindRepr = "parameters"
+	KindScalaParameter              core.KindRepr = "parameter"
+	KindScalaClassParameters        core.KindRepr = "class_parameters"
+	KindScalaClassParameter         core.KindRepr = "class_parameter"
+	KindScalaExtendsClause          core.KindRepr = "extends_clause"
+	KindScalaCompoundType           core.KindRepr = "compound_type"
+	KindScalaTemplateBody           core.KindRepr = "template_body"
+	KindScalaValDefinition          core.KindRepr = "val_definition"
+	KindScalaVarDefinition          core.KindRepr = "var_definition"
+	KindScalaValDeclaration         core.KindRepr = "val_declaration"
+	KindScalaVarDeclaration         core.KindRepr = "var_declaration"
+	KindScalaBlock                  core.KindRepr = "block"
+	KindScalaCallExpression         core.KindRepr = "call_expression"
+	KindScalaArguments              core.KindRepr = "arguments"
+	KindScalaInstanceExpression     core.KindRepr = "instance_expression"
+	KindScalaComment                core.KindRepr = "comment"
+	KindScalaBlockComment           core.KindRepr = "block_comment"
+	KindScalaImportDeclaration      core.KindRepr = "import_declaration"
+	KindScalaStableIdentifier       core.KindRepr = "stable_identifier"
+	KindScalaNamespaceSelectors     core.KindRepr = "namespace_selectors"
+	KindScalaArrowRenamedIdentifier core.KindRepr = "arrow_renamed_identifier"
+	KindScalaAsRenamedIdentifier    core.KindRepr = "as_renamed_identifier"
+	KindScalaNamespaceWildcard      core.KindRepr = "namespace_wildcard"
+	KindScalaIfExpression           core.KindRepr = "if_expression"
+	KindScalaForExpression          core.KindRepr = "for_expression"
+	KindScalaWhileExpression        core.KindRepr = "while_expression"
+	KindScalaCaseClause             core.KindRepr = "case_clause"
+	KindScalaMatchExpression        core.KindRepr = "match_expression"
+	KindScalaCatchClause            core.KindRepr = "catch_clause"
+	KindScalaLambdaExpression       core.KindRepr = "lambda_expression"
+	KindScalaInfixExpression        core.KindRepr = "infix_expression"
+	KindScalaReturnExpression       core.KindRepr = "return_expression"
+)
+
+var classKinds = map[core.KindRepr]string{
+	KindScalaClassDefinition:  "class",
+	KindScalaObjectDefinition: "object",
+	KindScalaTraitDefinition:  "trait",
+}
+
+type Extractor struct {
+}
+
+func (extractor *Extractor) GetLang() core.LangType {
+	return core.LangScala
+}
+
+// findPackage supports chained package clauses:
+//
+//	package a.b
+//	package c
+func findPackage(unit *core.Unit) string {
+	var parts []string
+	for cur := unit; cur.ParentUnit != nil; cur = cur.ParentUnit {
+		var found []string
+		for _, each := range cur.ParentUnit.SubUnits {
+			if each == cur {
+				break
+			}
+			if each.Kind != KindScalaPackageClause {
+				continue
+			}
+			name := core.FindFirstByKindInSubs(each, KindScalaPackageIdentifier)
+			if name != nil {
+				found = append(found, name.Content)
+			}
+		}
+		// packaging with body: `package a { ... }`
+		if cur.ParentUnit.Kind == KindScalaPackageClause {
+			name := core.FindFirstByKindInSubs(cur.ParentUnit, KindScalaPackageIdentifier)
+			if name != nil {
+				found = append(found, name.Content)
+			}
+		}
+		parts = append(found, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+func findAnnotations(unit *core.Unit) []string {
+	var ret []string
+	for _, each := range core.FindAllByKindInSubs(unit, KindScalaAnnotation) {
+		ret = append(ret, each.Content)
+	}
+	return ret
+}
+
+func findModifiers(unit *core.Unit) []string {
+	modifiers := core.FindFirstByKindInSubs(unit, KindScalaModifiers)
+	if modifiers == nil {
+		return nil
+	}
+	return strings.Fields(modifiers.Content)
+}
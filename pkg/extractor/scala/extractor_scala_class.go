@@ -0,0 +1,129 @@
+package scala
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type ClassField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// val or var
+	Mutable bool `json:"mutable"`
+}
+
+type ClassExtras struct {
+	// class, object or trait
+	Kind           string        `json:"kind"`
+	IsCase         bool          `json:"isCase"`
+	Annotations    []string      `json:"annotations"`
+	Modifiers      []string      `json:"modifiers"`
+	TypeParameters string        `json:"typeParameters"`
+	Extends        []string      `json:"extends"`
+	Fields         []*ClassField `json:"fields"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsClass(unit *core.Unit) bool {
+	_, ok := classKinds[unit.Kind]
+	return ok
+}
+
+func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
+	var ret []*object.Clazz
+	for _, eachUnit := range units {
+		if !extractor.IsClass(eachUnit) {
+			continue
+		}
+		eachClazz, err := extractor.ExtractClass(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachClazz)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error) {
+	clazz := object.NewClazz()
+	clazz.Span = unit.Span
+	clazz.Lang = extractor.GetLang()
+	clazz.Unit = unit
+	clazz.Module = findPackage(unit)
+
+	name := core.FindFirstByKindInSubs(unit, KindScalaIdentifier)
+	if name == nil {
+		return nil, errors.New("no class name found in " + unit.Content)
+	}
+	clazz.Name = name.Content
+
+	extras := &ClassExtras{
+		Kind:        classKinds[unit.Kind],
+		Annotations: findAnnotations(unit),
+		Modifiers:   findModifiers(unit),
+	}
+	// `case` is an anonymous node, check the header: `case class A`
+	if index := strings.Index(unit.Content, extras.Kind+" "+clazz.Name); index != -1 {
+		extras.IsCase = strings.HasSuffix(strings.TrimSpace(unit.Content[:index]), "case")
+	}
+
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindScalaTypeParameters:
+			extras.TypeParameters = each.Content
+		case KindScalaClassParameters:
+			for _, eachParam := range core.FindAllByKindInSubs(each, KindScalaClassParameter) {
+				valueUnit := param2ValueUnit(eachParam)
+				if valueUnit == nil {
+					continue
+				}
+				extras.Fields = append(extras.Fields, &ClassField{
+					Name:    valueUnit.Name,
+					Type:    valueUnit.Type,
+					Mutable: strings.HasPrefix(eachParam.Content, "var "),
+				})
+			}
+		case KindScalaExtendsClause:
+			for _, eachType := range each.SubUnits {
+				if eachType.Kind == KindScalaCompoundType {
+					for _, eachSub := range eachType.SubUnits {
+						extras.Extends = append(extras.Extends, eachSub.Content)
+					}
+					continue
+				}
+				if eachType.Kind == KindScalaArguments {
+					// constructor args of super class
+					continue
+				}
+				extras.Extends = append(extras.Extends, eachType.Content)
+			}
+		case KindScalaTemplateBody:
+			for _, eachMember := range each.SubUnits {
+				switch eachMember.Kind {
+				case KindScalaValDefinition, KindScalaValDeclaration, KindScalaVarDefinition, KindScalaVarDeclaration:
+					field := param2ValueUnit(eachMember)
+					if field == nil {
+						continue
+					}
+					mutable := eachMember.Kind == KindScalaVarDefinition || eachMember.Kind == KindScalaVarDeclaration
+					// without explicit type, the next node is its value
+					if !strings.Contains(strings.SplitN(eachMember.Content, "=", 2)[0], ":") {
+						field.Type = ""
+					}
+					extras.Fields = append(extras.Fields, &ClassField{
+						Name:    field.Name,
+						Type:    field.Type,
+						Mutable: mutable,
+					})
+				}
+			}
+		}
+	}
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	clazz.Extras = extras
+	return clazz, nil
+}
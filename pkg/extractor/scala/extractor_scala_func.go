@@ -0,0 +1,142 @@
+package scala
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type FunctionExtras struct {
+	Annotations    []string `json:"annotations"`
+	Modifiers      []string `json:"modifiers"`
+	TypeParameters string   `json:"typeParameters"`
+	// class, object or trait
+	OwnerKind string `json:"ownerKind"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
+	if unit.Kind == KindScalaFunctionDefinition || unit.Kind == KindScalaFunctionDeclaration {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Function, error) {
+	var ret []*object.Function
+	for _, eachUnit := range units {
+		if !extractor.IsFunction(eachUnit) {
+			continue
+		}
+		eachFunc, err := extractor.ExtractFunction(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachFunc)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
+	funcUnit.Span = unit.Span
+	funcUnit.Unit = unit
+	funcUnit.Lang = extractor.GetLang()
+
+	extras := &FunctionExtras{
+		Annotations: findAnnotations(unit),
+		Modifiers:   findModifiers(unit),
+	}
+
+	pkgName := findPackage(unit)
+	funcUnit.Namespace = pkgName
+
+	// trace its class (the closest one
+	clazzDecl := core.FindFirstByOneOfKindInParent(unit.ParentUnit,
+		KindScalaClassDefinition, KindScalaObjectDefinition, KindScalaTraitDefinition)
+	if clazzDecl != nil {
+		clazzIdentifier := core.FindFirstByKindInSubs(clazzDecl, KindScalaIdentifier)
+		if clazzIdentifier != nil {
+			funcUnit.Receiver = clazzIdentifier.Content
+			if pkgName != "" {
+				funcUnit.Receiver = pkgName + "." + clazzIdentifier.Content
+			}
+			extras.OwnerKind = classKinds[clazzDecl.Kind]
+		}
+	}
+
+	funcIdentifier := core.FindFirstByKindInSubs(unit, KindScalaIdentifier)
+	if funcIdentifier == nil {
+		return nil, errors.New("no func id found in identifier" + unit.Content)
+	}
+	funcUnit.Name = funcIdentifier.Content
+	funcUnit.DefLine = int(funcIdentifier.Span.Start.Row + 1)
+
+	// def name[T](params)...(params): ret = body
+	var rest []*core.Unit
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindScalaAnnotation, KindScalaModifiers, KindScalaComment, KindScalaBlockComment:
+		case KindScalaTypeParameters:
+			extras.TypeParameters = each.Content
+		case KindScalaParameters:
+			// currying, all the lists are flattened
+			for _, eachParam := range core.FindAllByKindInSubs(each, KindScalaParameter) {
+				if valueUnit := param2ValueUnit(eachParam); valueUnit != nil {
+					funcUnit.Parameters = append(funcUnit.Parameters, valueUnit)
+				}
+			}
+		default:
+			if each != funcIdentifier {
+				rest = append(rest, each)
+			}
+		}
+	}
+
+	// the rest: [ret] [body]
+	var retUnit *core.Unit
+	if unit.Kind == KindScalaFunctionDeclaration {
+		if len(rest) != 0 {
+			retUnit = rest[0]
+		}
+	} else {
+		if len(rest) > 1 {
+			retUnit = rest[0]
+		}
+		if len(rest) != 0 {
+			funcUnit.BodySpan = rest[len(rest)-1].Span
+		}
+	}
+	if retUnit != nil {
+		funcUnit.Returns = append(funcUnit.Returns, &object.ValueUnit{
+			Type: retUnit.Content,
+			// scala has no named return value
+			Name: "",
+		})
+	}
+
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	funcUnit.Extras = extras
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
+	return funcUnit, nil
+}
+
+// param2ValueUnit `name: Type = default`
+func param2ValueUnit(unit *core.Unit) *object.ValueUnit {
+	name := core.FindFirstByKindInSubs(unit, KindScalaIdentifier)
+	if name == nil {
+		return nil
+	}
+	ret := &object.ValueUnit{
+		Name: name.Content,
+	}
+	for i, each := range unit.SubUnits {
+		if each == name && i+1 < len(unit.SubUnits) {
+			ret.Type = unit.SubUnits[i+1].Content
+			break
+		}
+	}
+	return ret
+}
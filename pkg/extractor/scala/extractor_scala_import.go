@@ -0,0 +1,60 @@
+package scala
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindScalaImportDeclaration
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) || len(eachUnit.SubUnits) == 0 {
+			continue
+		}
+		imp := object.NewImport()
+		imp.Span = eachUnit.Span
+		imp.Lang = extractor.GetLang()
+
+		// a.b.C, a.b._ or a.b.{c, d => e}
+		subUnits := eachUnit.SubUnits
+		last := subUnits[len(subUnits)-1]
+		var path []string
+		for _, each := range subUnits[:len(subUnits)-1] {
+			path = append(path, each.Content)
+		}
+		imp.Source = strings.Join(path, ".")
+		switch last.Kind {
+		case KindScalaNamespaceWildcard:
+			imp.Wildcard = true
+		case KindScalaNamespaceSelectors:
+			for _, each := range last.SubUnits {
+				switch each.Kind {
+				case KindScalaNamespaceWildcard:
+					imp.Wildcard = true
+				case KindScalaArrowRenamedIdentifier, KindScalaAsRenamedIdentifier:
+					if len(each.SubUnits) < 2 {
+						continue
+					}
+					imp.AddName(each.SubUnits[0].Content, each.SubUnits[1].Content)
+				default:
+					imp.AddName(each.Content, "")
+				}
+			}
+		default:
+			if imp.Source == "" {
+				// import a
+				imp.Source = last.Content
+			} else {
+				imp.AddName(last.Content, "")
+			}
+		}
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
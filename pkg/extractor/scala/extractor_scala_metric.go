@@ -0,0 +1,38 @@
+package scala
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindScalaIfExpression,
+			KindScalaForExpression,
+			KindScalaWhileExpression,
+			KindScalaCaseClause,
+		},
+		FlowKinds: []core.KindRepr{
+			KindScalaIfExpression,
+			KindScalaForExpression,
+			KindScalaWhileExpression,
+			KindScalaMatchExpression,
+			KindScalaCatchClause,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindScalaLambdaExpression,
+			KindScalaFunctionDefinition,
+		},
+		BinaryKinds: []core.KindRepr{
+			KindScalaInfixExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindScalaReturnExpression,
+		},
+		CommentKinds: []core.KindRepr{
+			KindScalaComment,
+			KindScalaBlockComment,
+		},
+	}
+}
@@ -0,0 +1,260 @@
+package scala
+
+import (
+	"testing"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
+)
+
+var scalaCode = `
+package com.acme.data
+package etl
+
+import scala.collection.mutable
+
+@deprecated("x", "1")
+case class Record(id: Int, name: String) extends Base with Serializable {
+  val size: Int = 3
+  var tags = List[String]()
+  def render(prefix: String, n: Int = 2): String = {
+    val s = helper(prefix)
+    println(s)
+    this.tags.map(_.trim)
+    s
+  }
+}
+
+object Record {
+  def apply(id: Int): Record = new Record(id, "")
+  private def helper[T](x: T)(implicit ev: Ordering[T]): T = x
+}
+
+trait Loader[T] {
+  def load(path: String): Seq[T]
+  def name: String = "l"
+}
+
+abstract class Job { def run(): Unit }
+
+def topLevel(x: Int*) = x.sum
+`
+
+func TestExtractor_ExtractFunctions(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangScala)
+	units, err := parser.Parse([]byte(scalaCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 7)
+
+	render := funcs[0]
+	assert.Equal(t, "render", render.Name)
+	assert.Equal(t, "com.acme.data.etl", render.Namespace)
+	assert.Equal(t, "com.acme.data.etl.Record", render.Receiver)
+	assert.Equal(t, 11, render.DefLine)
+	assert.Equal(t, "com.acme.data.etl|com.acme.data.etl.Record|render|String,Int|String", render.GetSignature())
+
+	apply := funcs[1]
+	assert.Equal(t, "object", apply.Extras.(*FunctionExtras).OwnerKind)
+	assert.Equal(t, "Record", apply.Returns[0].Type)
+
+	helper := funcs[2]
+	assert.Len(t, helper.Parameters, 2)
+	assert.Equal(t, "Ordering[T]", helper.Parameters[1].Type)
+	assert.Equal(t, "T", helper.Returns[0].Type)
+	assert.Equal(t, "[T]", helper.Extras.(*FunctionExtras).TypeParameters)
+	assert.Equal(t, []string{"private"}, helper.Extras.(*FunctionExtras).Modifiers)
+
+	load := funcs[3]
+	assert.Equal(t, "Seq[T]", load.Returns[0].Type)
+	assert.Equal(t, "trait", load.Extras.(*FunctionExtras).OwnerKind)
+
+	name := funcs[4]
+	assert.Empty(t, name.Parameters)
+	assert.Equal(t, "String", name.Returns[0].Type)
+
+	topLevel := funcs[6]
+	assert.Equal(t, "", topLevel.Receiver)
+	assert.Empty(t, topLevel.Returns)
+	assert.Equal(t, "Int*", topLevel.Parameters[0].Type)
+}
+
+func TestExtractor_ExtractClasses(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangScala)
+	units, err := parser.Parse([]byte(scalaCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 4)
+
+	record := classes[0]
+	assert.Equal(t, "Record", record.Name)
+	assert.Equal(t, "com.acme.data.etl", record.Module)
+	extras := record.Extras.(*ClassExtras)
+	assert.True(t, extras.IsCase)
+	assert.Equal(t, []string{"@deprecated(\"x\", \"1\")"}, extras.Annotations)
+	assert.Equal(t, []string{"Base", "Serializable"}, extras.Extends)
+	assert.Len(t, extras.Fields, 4)
+	assert.Equal(t, "Int", extras.Fields[2].Type)
+	assert.True(t, extras.Fields[3].Mutable)
+
+	assert.Equal(t, "object", classes[1].Extras.(*ClassExtras).Kind)
+	assert.Equal(t, "trait", classes[2].Extras.(*ClassExtras).Kind)
+	assert.False(t, classes[3].Extras.(*ClassExtras).IsCase)
+}
+
+func TestExtractor_ExtractCalls(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangScala)
+	units, err := parser.Parse([]byte(scalaCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	assert.Len(t, calls, 4)
+	assert.Equal(t, "helper", calls[0].Caller)
+	assert.Equal(t, []string{"prefix"}, calls[0].Arguments)
+	assert.Equal(t, "this.tags.map", calls[2].Caller)
+	assert.Equal(t, "Record", calls[3].Caller)
+}
+
+var scalaImportCode = `
+import a.b.C
+import a.b._
+import a.b.{c, d => e}
+`
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangScala)
+	units, err := parser.Parse([]byte(scalaImportCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 3)
+	assert.Equal(t, "a.b", imports[0].Source)
+	assert.Equal(t, []string{"C"}, imports[0].Names)
+	assert.True(t, imports[1].Wildcard)
+	assert.Equal(t, []string{"c", "d"}, imports[2].Names)
+	assert.Equal(t, "e", imports[2].Aliases["d"])
+}
+
+var scalaCommentCode = `
+package a
+
+/** A user. */
+@deprecated
+class User {
+  // full name
+  def name(): String = ""
+}
+`
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangScala)
+	units, err := parser.Parse([]byte(scalaCommentCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "A user.", comments[0].Text)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "full name", functions[0].Extras.(*FunctionExtras).Doc)
+	assert.Equal(t, functions[0].GetSignature(), comments[1].Owner)
+
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Equal(t, "A user.", classes[0].Extras.(*ClassExtras).Doc)
+	assert.Equal(t, classes[0].GetSignature(), comments[0].Owner)
+}
+
+var scalaVariableCode = `
+package a
+
+object Config {
+  final val Max = 10
+  var count: Int = 0
+}
+
+class A {
+  val x = 1
+  def f() = { var y = 2; y }
+}
+`
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangScala)
+	units, err := parser.Parse([]byte(scalaVariableCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.Len(t, variables, 4)
+
+	assert.Equal(t, "Max", variables[0].Name)
+	assert.Equal(t, "10", variables[0].Value)
+	assert.Equal(t, object.MutabilityConst, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeClass, variables[0].Scope)
+	assert.Equal(t, "a.Config", variables[0].Receiver)
+
+	assert.Equal(t, "Int", variables[1].Type)
+	assert.Equal(t, "0", variables[1].Value)
+	assert.Equal(t, object.MutabilityVar, variables[1].Mutability)
+
+	assert.Empty(t, variables[2].Type)
+	assert.Equal(t, object.MutabilityFinal, variables[2].Mutability)
+
+	assert.Equal(t, "y", variables[3].Name)
+	assert.Equal(t, object.VariableScopeLocal, variables[3].Scope)
+}
+
+var scalaMetricCode = `
+object A {
+  def f(a: Int): Int = {
+    if (a > 0 && a < 3) { return 1 } else if (a < 0) { return 2 }
+    for (i <- 0 to 3) {}
+    while (a > 0) {}
+    a match { case 1 => 1; case _ => 2 }
+    try {} catch { case e: Exception => }
+    val l = (x: Int) => x
+    return 0
+  }
+}
+`
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	parser := core.NewParser(core.LangScala)
+	units, err := parser.Parse([]byte(scalaMetricCode))
+	assert.Nil(t, err)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+
+	metrics := functions[0].Metrics
+	assert.Equal(t, 9, metrics.Cyclomatic)
+	assert.Equal(t, 7, metrics.Cognitive)
+	assert.Equal(t, 9, metrics.Loc)
+	assert.Equal(t, 1, metrics.MaxNesting)
+	assert.Equal(t, 1, metrics.ParamCount)
+	assert.Equal(t, 3, metrics.ReturnCount)
+}
@@ -0,0 +1,69 @@
+package scala
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	// patterns are ignored, eg: `val (a, b) = (1, 2)`
+	return (unit.Kind == KindScalaValDefinition || unit.Kind == KindScalaVarDefinition) &&
+		core.FindFirstByKindInSubs(unit, KindScalaIdentifier) != nil
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		ret = append(ret, extractor.extractVariable(eachUnit))
+	}
+	return ret, nil
+}
+
+// extractVariable [annotations], [modifiers], name, [type], value
+func (extractor *Extractor) extractVariable(unit *core.Unit) *object.Variable {
+	v := object.NewVariable()
+	v.Span = unit.Span
+	v.Lang = extractor.GetLang()
+	v.Namespace = findPackage(unit)
+
+	field := param2ValueUnit(unit)
+	v.Name = field.Name
+	// without explicit type, the next node is its value
+	if strings.Contains(strings.SplitN(unit.Content, "=", 2)[0], ":") {
+		v.Type = field.Type
+	}
+	if last := unit.SubUnits[len(unit.SubUnits)-1]; last.Content != v.Name && last.Content != v.Type {
+		v.Value = last.Content
+	}
+	if unit.Kind == KindScalaValDefinition {
+		v.Mutability = object.MutabilityFinal
+		// constant value definitions
+		if slices.Contains(findModifiers(unit), "final") {
+			v.Mutability = object.MutabilityConst
+		}
+	}
+
+	owner := core.FindFirstByOneOfKindInParent(unit.ParentUnit,
+		KindScalaBlock, KindScalaFunctionDefinition, KindScalaTemplateBody)
+	switch {
+	case owner == nil:
+	case owner.Kind == KindScalaTemplateBody:
+		v.Scope = object.VariableScopeClass
+		clazzIdentifier := core.FindFirstByKindInSubs(owner.ParentUnit, KindScalaIdentifier)
+		if clazzIdentifier != nil {
+			v.Receiver = clazzIdentifier.Content
+			if v.Namespace != "" {
+				v.Receiver = v.Namespace + "." + clazzIdentifier.Content
+			}
+		}
+	default:
+		v.Scope = object.VariableScopeLocal
+	}
+	return v
+}
@@ -0,0 +1,117 @@
+package swift
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+)
+
+// https://github.com/alex-pinkus/tree-sitter-swift/blob/main/src/node-types.json
+const (
+	KindSwiftSourceFile                  core.KindRepr = "source_file"
+	KindSwiftClassDeclaration            core.KindRepr = "class_declaration"
+	KindSwiftProtocolDeclaration         core.KindRepr = "protocol_declaration"
+	KindSwiftFunctionDeclaration         core.KindRepr = "function_declaration"
+	KindSwiftProtocolFunctionDeclaration core.KindRepr = "protocol_function_declaration"
+	KindSwiftInitDeclaration             core.KindRepr = "init_declaration"
+	KindSwiftDeinitDeclaration           core.KindRepr = "deinit_declaration"
+	KindSwiftPropertyDeclaration         core.KindRepr = "property_declaration"
+	KindSwiftProtocolPropertyDeclaration core.KindRepr = "protocol_property_declaration"
+	KindSwiftTypeIdentifier              core.KindRepr = "type_identifier"
+	KindSwiftSimpleIdentifier            core.KindRepr = "simple_identifier"
+	KindSwiftUserType                    core.KindRepr = "user_type"
+	KindSwiftModifiers                   core.KindRepr = "modifiers"
+	KindSwiftAttribute                   core.KindRepr = "attribute"
+	KindSwiftInheritanceSpecifier        core.KindRepr = "inheritance_specifier"
+	KindSwiftTypeParameters              core.KindRepr = "type_parameters"
+	KindSwiftTypeConstraints             core.KindRepr = "type_constraints"
+	KindSwiftTypeAnnotation              core.KindRepr = "type_annotation"
+	KindSwiftValueBindingPattern         core.KindRepr = "value_binding_pattern"
+	KindSwiftPattern                     core.KindRepr = "pattern"
+	KindSwiftParameter                   core.KindRepr = "parameter"
+	KindSwiftThrows                      core.KindRepr = "throws"
+	KindSwiftFunctionBody                core.KindRepr = "function_body"
+	KindSwiftClassBody                   core.KindRepr = "class_body"
+	KindSwiftEnumClassBody               core.KindRepr = "enum_class_body"
+	KindSwiftEnumEntry                   core.KindRepr = "enum_entry"
+	KindSwiftProtocolBody                core.KindRepr = "protocol_body"
+	KindSwiftCallExpression              core.KindRepr = "call_expression"
+	KindSwiftCallSuffix                  core.KindRepr = "call_suffix"
+	KindSwiftValueArguments              core.KindRepr = "value_arguments"
+	KindSwiftValueArgument               core.KindRepr = "value_argument"
+	KindSwiftComment                     core.KindRepr = "comment"
+	KindSwiftMultilineComment            core.KindRepr = "multiline_comment"
+	KindSwiftImportDeclaration           core.KindRepr = "import_declaration"
+	KindSwiftIdentifier                  core.KindRepr = "identifier"
+	KindSwiftComputedProperty            core.KindRepr = "computed_property"
+	KindSwiftLambdaLiteral               core.KindRepr = "lambda_literal"
+	KindSwiftIfStatement                 core.KindRepr = "if_statement"
+	KindSwiftGuardStatement              core.KindRepr = "guard_statement"
+	KindSwiftForStatement                core.KindRepr = "for_statement"
+	KindSwiftWhileStatement              core.KindRepr = "while_statement"
+	KindSwiftRepeatWhileStatement        core.KindRepr = "repeat_while_statement"
+	KindSwiftSwitchEntry                 core.KindRepr = "switch_entry"
+	KindSwiftCatchBlock                  core.KindRepr = "catch_block"
+	KindSwiftTernaryExpression           core.KindRepr = "ternary_expression"
+	KindSwiftSwitchStatement             core.KindRepr = "switch_statement"
+	KindSwiftConjunctionExpression       core.KindRepr = "conjunction_expression"
+	KindSwiftDisjunctionExpression       core.KindRepr = "disjunction_expression"
+	KindSwiftControlTransferStatement    core.KindRepr = "control_transfer_statement"
+)
+
+type Extractor struct {
+}
+
+func (extractor *Extractor) GetLang() core.LangType {
+	return core.LangSwift
+}
+
+// findTypeName name of class, struct, enum, extension or protocol
+func findTypeName(unit *core.Unit) *core.Unit {
+	for _, each := range unit.SubUnits {
+		// extension uses user_type
+		if each.Kind == KindSwiftTypeIdentifier || each.Kind == KindSwiftUserType {
+			return each
+		}
+	}
+	return nil
+}
+
+// findTypePath joined names of all the types around this unit, eg: Outer.Inner
+func findTypePath(unit *core.Unit) string {
+	var parts []string
+	for cur := unit.ParentUnit; cur != nil; cur = cur.ParentUnit {
+		if cur.Kind != KindSwiftClassDeclaration && cur.Kind != KindSwiftProtocolDeclaration {
+			continue
+		}
+		name := findTypeName(cur)
+		if name == nil {
+			continue
+		}
+		parts = append([]string{name.Content}, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+func findAttributes(unit *core.Unit) []string {
+	var ret []string
+	modifiers := core.FindFirstByKindInSubs(unit, KindSwiftModifiers)
+	for _, each := range core.FindAllByKindInSubs(modifiers, KindSwiftAttribute) {
+		ret = append(ret, each.Content)
+	}
+	return ret
+}
+
+func findModifiers(unit *core.Unit) []string {
+	var ret []string
+	modifiers := core.FindFirstByKindInSubs(unit, KindSwiftModifiers)
+	if modifiers == nil {
+		return ret
+	}
+	for _, each := range modifiers.SubUnits {
+		if each.Kind != KindSwiftAttribute {
+			ret = append(ret, each.Content)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,68 @@
+package swift
+
+import (
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsCall(unit *core.Unit) bool {
+	if unit.Kind == KindSwiftCallExpression {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractCalls(units []*core.Unit) ([]*object.Call, error) {
+	var ret []*object.Call
+	for _, eachUnit := range units {
+		if !extractor.IsCall(eachUnit) {
+			continue
+		}
+
+		eachCall, err := extractor.unit2Call(eachUnit)
+		if err != nil {
+			core.Log.Warnf("err: %v", err)
+			continue
+		}
+		ret = append(ret, eachCall)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) unit2Call(unit *core.Unit) (*object.Call, error) {
+	funcUnit := core.FindFirstByOneOfKindInParent(unit,
+		KindSwiftFunctionDeclaration, KindSwiftInitDeclaration, KindSwiftDeinitDeclaration)
+	var srcFunc *object.Function
+	var err error
+	if funcUnit != nil {
+		srcFunc, err = extractor.ExtractFunction(funcUnit)
+		if err != nil {
+			return nil, errors.New("convert func failed: " + funcUnit.Content)
+		}
+	}
+
+	// headless, give up (temp
+	if srcFunc == nil {
+		return nil, errors.New("headless call")
+	}
+	if len(unit.SubUnits) == 0 {
+		return nil, errors.New("invalid call: " + unit.Content)
+	}
+
+	var arguments []string
+	suffix := core.FindFirstByKindInSubs(unit, KindSwiftCallSuffix)
+	args := core.FindFirstByKindInSubs(suffix, KindSwiftValueArguments)
+	for _, each := range core.FindAllByKindInSubs(args, KindSwiftValueArgument) {
+		arguments = append(arguments, each.Content)
+	}
+
+	ret := &object.Call{
+		Src:       srcFunc.GetSignature(),
+		Caller:    unit.SubUnits[0].Content,
+		Arguments: arguments,
+		Span:      unit.Span,
+	}
+	return ret, nil
+}
@@ -0,0 +1,130 @@
+package swift
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type ClassField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// var or let
+	Mutable bool `json:"mutable"`
+}
+
+type ClassExtras struct {
+	// class, struct, enum, extension, actor or protocol
+	Kind           string        `json:"kind"`
+	Attributes     []string      `json:"attributes"`
+	Modifiers      []string      `json:"modifiers"`
+	TypeParameters string        `json:"typeParameters"`
+	Inherits       []string      `json:"inherits"`
+	Fields         []*ClassField `json:"fields"`
+	// Doc comments right before this class
+	Doc string `json:"doc"`
+}
+
+func (extractor *Extractor) IsClass(unit *core.Unit) bool {
+	if unit.Kind == KindSwiftClassDeclaration || unit.Kind == KindSwiftProtocolDeclaration {
+		return true
+	}
+	return false
+}
+
+func (extractor *Extractor) ExtractClasses(units []*core.Unit) ([]*object.Clazz, error) {
+	var ret []*object.Clazz
+	for _, eachUnit := range units {
+		if !extractor.IsClass(eachUnit) {
+			continue
+		}
+		eachClazz, err := extractor.ExtractClass(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachClazz)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractClass(unit *core.Unit) (*object.Clazz, error) {
+	clazz := object.NewClazz()
+	clazz.Span = unit.Span
+	clazz.Lang = extractor.GetLang()
+	clazz.Unit = unit
+
+	name := findTypeName(unit)
+	if name == nil {
+		return nil, errors.New("no class name found in " + unit.Content)
+	}
+	clazz.Name = name.Content
+	if outer := findTypePath(unit); outer != "" {
+		clazz.Name = outer + "." + clazz.Name
+	}
+
+	extras := &ClassExtras{
+		Kind:       "protocol",
+		Attributes: findAttributes(unit),
+		Modifiers:  findModifiers(unit),
+	}
+	if unit.Kind == KindSwiftClassDeclaration {
+		// the keyword is an anonymous node, right after modifiers
+		header := unit.Content
+		if modifiers := core.FindFirstByKindInSubs(unit, KindSwiftModifiers); modifiers != nil {
+			header = strings.TrimPrefix(header, modifiers.Content)
+		}
+		if fields := strings.Fields(header); len(fields) != 0 {
+			extras.Kind = fields[0]
+		}
+	}
+
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindSwiftTypeParameters:
+			extras.TypeParameters = each.Content
+		case KindSwiftInheritanceSpecifier:
+			extras.Inherits = append(extras.Inherits, each.Content)
+		case KindSwiftClassBody, KindSwiftProtocolBody:
+			for _, eachProp := range core.FindAllByKindsInSubs(each,
+				KindSwiftPropertyDeclaration, KindSwiftProtocolPropertyDeclaration) {
+				field := prop2Field(eachProp)
+				if field != nil {
+					extras.Fields = append(extras.Fields, field)
+				}
+			}
+		case KindSwiftEnumClassBody:
+			for _, eachEntry := range core.FindAllByKindInSubs(each, KindSwiftEnumEntry) {
+				for _, eachCase := range core.FindAllByKindInSubs(eachEntry, KindSwiftSimpleIdentifier) {
+					extras.Fields = append(extras.Fields, &ClassField{
+						Name: eachCase.Content,
+						Type: clazz.Name,
+					})
+				}
+			}
+		}
+	}
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	clazz.Extras = extras
+	return clazz, nil
+}
+
+func prop2Field(unit *core.Unit) *ClassField {
+	pattern := core.FindFirstByKindInSubs(unit, KindSwiftPattern)
+	if pattern == nil {
+		return nil
+	}
+	name := core.FindFirstByKindInSubsWithDfs(pattern, KindSwiftSimpleIdentifier)
+	if name == nil {
+		return nil
+	}
+	ret := &ClassField{
+		Name:    name.Content,
+		Mutable: strings.Contains(" "+unit.Content+" ", " var "),
+	}
+	if typeAnnotation := core.FindFirstByKindInSubs(unit, KindSwiftTypeAnnotation); typeAnnotation != nil {
+		ret.Type = strings.TrimSpace(strings.TrimPrefix(typeAnnotation.Content, ":"))
+	}
+	return ret
+}
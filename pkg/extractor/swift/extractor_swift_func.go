@@ -0,0 +1,144 @@
+package swift
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+type FunctionExtras struct {
+	// function, init or deinit
+	Kind       string   `json:"kind"`
+	Attributes []string `json:"attributes"`
+	Modifiers  []string `json:"modifiers"`
+	// full name with argument labels, eg: accelerate(by:_:)
+	Selector       string `json:"selector"`
+	TypeParameters string `json:"typeParameters"`
+	Throws         bool   `json:"throws"`
+	// Doc comments right before this function
+	Doc string `json:"doc"`
+}
+
+var funcKinds = map[core.KindRepr]string{
+	KindSwiftFunctionDeclaration:         "function",
+	KindSwiftProtocolFunctionDeclaration: "function",
+	KindSwiftInitDeclaration:             "init",
+	KindSwiftDeinitDeclaration:           "deinit",
+}
+
+func (extractor *Extractor) IsFunction(unit *core.Unit) bool {
+	_, ok := funcKinds[unit.Kind]
+	return ok
+}
+
+func (extractor *Extractor) ExtractFunctions(units []*core.Unit) ([]*object.Function, error) {
+	var ret []*object.Function
+	for _, eachUnit := range units {
+		if !extractor.IsFunction(eachUnit) {
+			continue
+		}
+		eachFunc, err := extractor.ExtractFunction(eachUnit)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, eachFunc)
+	}
+	return ret, nil
+}
+
+func (extractor *Extractor) ExtractFunction(unit *core.Unit) (*object.Function, error) {
+	funcUnit := object.NewFunction()
+	funcUnit.Span = unit.Span
+	funcUnit.Unit = unit
+	funcUnit.Lang = extractor.GetLang()
+	// swift has no namespace in source files, module is decided by build targets
+	funcUnit.Receiver = findTypePath(unit)
+
+	extras := &FunctionExtras{
+		Kind:       funcKinds[unit.Kind],
+		Attributes: findAttributes(unit),
+		Modifiers:  findModifiers(unit),
+	}
+
+	switch unit.Kind {
+	case KindSwiftInitDeclaration, KindSwiftDeinitDeclaration:
+		// keyword only
+		funcUnit.Name = extras.Kind
+		funcUnit.DefLine = int(unit.Span.Start.Row + 1)
+	default:
+		funcName := core.FindFirstByKindInSubs(unit, KindSwiftSimpleIdentifier)
+		if funcName == nil {
+			return nil, errors.New("no func name found in " + unit.Content)
+		}
+		funcUnit.Name = funcName.Content
+		funcUnit.DefLine = int(funcName.Span.Start.Row + 1)
+	}
+
+	var labels []string
+	var body *core.Unit
+	var last *core.Unit
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindSwiftParameter:
+			param, label := param2ValueUnit(each)
+			funcUnit.Parameters = append(funcUnit.Parameters, param)
+			labels = append(labels, label+":")
+		case KindSwiftTypeParameters:
+			extras.TypeParameters = each.Content
+		case KindSwiftThrows:
+			extras.Throws = true
+		case KindSwiftFunctionBody:
+			body = each
+			funcUnit.BodySpan = each.Span
+		case KindSwiftTypeConstraints, KindSwiftComment:
+		default:
+			if body == nil {
+				last = each
+			}
+		}
+	}
+	extras.Selector = funcUnit.Name + "(" + strings.Join(labels, "") + ")"
+
+	// return type is the last node before body, after `->`
+	if last != nil {
+		header := unit.Content
+		if body != nil {
+			header = header[:strings.LastIndex(header, body.Content)]
+		}
+		header = strings.TrimSpace(header)
+		if constraints := core.FindFirstByKindInSubs(unit, KindSwiftTypeConstraints); constraints != nil {
+			header = strings.TrimSpace(strings.TrimSuffix(header, constraints.Content))
+		}
+		if strings.HasSuffix(header, last.Content) {
+			before := strings.TrimSpace(strings.TrimSuffix(header, last.Content))
+			if strings.HasSuffix(before, "->") {
+				funcUnit.Returns = append(funcUnit.Returns, &object.ValueUnit{
+					Type: last.Content,
+					// swift has no named return value
+					Name: "",
+				})
+			}
+		}
+	}
+
+	extras.Doc = object.NewCommentRule(extractor).FindDoc(unit)
+	funcUnit.Extras = extras
+	funcUnit.Metrics = extractor.metricRule().Compute(funcUnit)
+	return funcUnit, nil
+}
+
+// param2ValueUnit `label name: Type`, returns param and its argument label
+func param2ValueUnit(unit *core.Unit) (*object.ValueUnit, string) {
+	names, typeName, _ := strings.Cut(unit.Content, ":")
+	parts := strings.Fields(names)
+	ret := &object.ValueUnit{
+		Type: strings.TrimSpace(typeName),
+	}
+	if len(parts) == 0 {
+		return ret, "_"
+	}
+	ret.Name = parts[len(parts)-1]
+	return ret, parts[0]
+}
@@ -0,0 +1,53 @@
+package swift
+
+import (
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"golang.org/x/exp/slices"
+)
+
+// import kinds, eg: `import struct Swift.Array`
+var importKinds = []string{"typealias", "struct", "class", "enum", "protocol", "let", "var", "func"}
+
+func (extractor *Extractor) IsImport(unit *core.Unit) bool {
+	return unit.Kind == KindSwiftImportDeclaration
+}
+
+func (extractor *Extractor) ExtractImports(units []*core.Unit) ([]*object.Import, error) {
+	ret := make([]*object.Import, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsImport(eachUnit) {
+			continue
+		}
+		identifier := core.FindFirstByKindInSubs(eachUnit, KindSwiftIdentifier)
+		if identifier == nil {
+			continue
+		}
+		imp := object.NewImport()
+		imp.Span = eachUnit.Span
+		imp.Lang = extractor.GetLang()
+
+		// keywords are not nodes
+		var kind string
+		fields := strings.Fields(eachUnit.Content)
+		for i, each := range fields {
+			if each == "import" && i+1 < len(fields) && slices.Contains(importKinds, fields[i+1]) {
+				kind = fields[i+1]
+			}
+		}
+		path := identifier.Content
+		if i := strings.LastIndex(path, "."); kind != "" && i != -1 {
+			// import struct Swift.Array
+			imp.Source = path[:i]
+			imp.AddName(path[i+1:], "")
+		} else {
+			// import Foundation
+			imp.Source = path
+			imp.Wildcard = true
+		}
+		ret = append(ret, imp)
+	}
+	return ret, nil
+}
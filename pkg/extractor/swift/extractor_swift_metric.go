@@ -0,0 +1,45 @@
+package swift
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) metricRule() *object.MetricRule {
+	return &object.MetricRule{
+		PathKinds: []core.KindRepr{
+			KindSwiftIfStatement,
+			KindSwiftGuardStatement,
+			KindSwiftForStatement,
+			KindSwiftWhileStatement,
+			KindSwiftRepeatWhileStatement,
+			KindSwiftSwitchEntry,
+			KindSwiftCatchBlock,
+			KindSwiftTernaryExpression,
+		},
+		FlowKinds: []core.KindRepr{
+			KindSwiftIfStatement,
+			KindSwiftGuardStatement,
+			KindSwiftForStatement,
+			KindSwiftWhileStatement,
+			KindSwiftRepeatWhileStatement,
+			KindSwiftSwitchStatement,
+			KindSwiftCatchBlock,
+			KindSwiftTernaryExpression,
+		},
+		LambdaKinds: []core.KindRepr{
+			KindSwiftLambdaLiteral,
+		},
+		LogicalKinds: []core.KindRepr{
+			KindSwiftConjunctionExpression,
+			KindSwiftDisjunctionExpression,
+		},
+		ReturnKinds: []core.KindRepr{
+			KindSwiftControlTransferStatement,
+		},
+		CommentKinds: []core.KindRepr{
+			KindSwiftComment,
+			KindSwiftMultilineComment,
+		},
+	}
+}
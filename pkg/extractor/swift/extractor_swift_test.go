@@ -0,0 +1,213 @@
+package swift
+
+import (
+	"testing"
+
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+	"github.com/stretchr/testify/assert"
+)
+
+var swiftCode = `
+import Foundation
+
+@objc public class Vehicle: NSObject, Drivable {
+    private(set) var speed: Int = 0
+    let name: String
+
+    init(name: String, speed: Int = 0) {
+        self.name = name
+        super.init()
+    }
+
+    /// Speed up.
+    @discardableResult
+    public func accelerate(by delta: Int, _ force: Bool) -> Int {
+        speed += delta
+        log("x")
+        self.engine.start(level: 2)
+        return speed
+    }
+
+    static func make() -> Vehicle { Vehicle(name: "a") }
+
+    deinit {}
+}
+
+struct Point<T>: Equatable {
+    var x: T
+    func dist(to other: Point) throws -> Double { 0 }
+}
+
+protocol Drivable {
+    var speed: Int { get }
+    func accelerate(by delta: Int, _ force: Bool) -> Int
+}
+
+extension Vehicle {
+    func honk() {}
+}
+
+enum Direction: String { case north, south }
+
+func topLevel(_ values: Int...) async -> [Int] { values }
+`
+
+func parseSwift(t *testing.T) []*core.Unit {
+	parser := core.NewParser(core.LangSwift)
+	units, err := parser.Parse([]byte(swiftCode))
+	assert.Nil(t, err)
+	return units
+}
+
+func TestExtractor_ExtractFunctions(t *testing.T) {
+	t.Parallel()
+	units := parseSwift(t)
+
+	extractor := &Extractor{}
+	funcs, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 8)
+
+	init := funcs[0]
+	assert.Equal(t, "init", init.Name)
+	assert.Equal(t, "Vehicle", init.Receiver)
+	assert.Empty(t, init.Returns)
+	assert.Len(t, init.Parameters, 2)
+
+	accelerate := funcs[1]
+	assert.Equal(t, "accelerate", accelerate.Name)
+	assert.Equal(t, 15, accelerate.DefLine)
+	assert.Equal(t, "|Vehicle|accelerate|Int,Bool|Int", accelerate.GetSignature())
+	extras := accelerate.Extras.(*FunctionExtras)
+	assert.Equal(t, "accelerate(by:_:)", extras.Selector)
+	assert.Equal(t, []string{"@discardableResult"}, extras.Attributes)
+	assert.Equal(t, []string{"public"}, extras.Modifiers)
+
+	assert.Equal(t, "Vehicle", funcs[2].Returns[0].Type)
+	assert.Equal(t, "deinit", funcs[3].Name)
+
+	dist := funcs[4]
+	assert.Equal(t, "Point", dist.Receiver)
+	assert.True(t, dist.Extras.(*FunctionExtras).Throws)
+	assert.Equal(t, "Double", dist.Returns[0].Type)
+
+	// protocol requirement
+	assert.Equal(t, "Drivable", funcs[5].Receiver)
+	assert.Equal(t, "Int", funcs[5].Returns[0].Type)
+
+	// extension
+	assert.Equal(t, "Vehicle", funcs[6].Receiver)
+
+	topLevel := funcs[7]
+	assert.Equal(t, "", topLevel.Receiver)
+	assert.Equal(t, "Int...", topLevel.Parameters[0].Type)
+	assert.Equal(t, "[Int]", topLevel.Returns[0].Type)
+}
+
+func TestExtractor_ExtractClasses(t *testing.T) {
+	t.Parallel()
+	units := parseSwift(t)
+
+	extractor := &Extractor{}
+	classes, err := extractor.ExtractClasses(units)
+	assert.Nil(t, err)
+	assert.Len(t, classes, 5)
+
+	vehicle := classes[0]
+	assert.Equal(t, "Vehicle", vehicle.Name)
+	extras := vehicle.Extras.(*ClassExtras)
+	assert.Equal(t, "class", extras.Kind)
+	assert.Equal(t, []string{"@objc"}, extras.Attributes)
+	assert.Equal(t, []string{"NSObject", "Drivable"}, extras.Inherits)
+	assert.Len(t, extras.Fields, 2)
+	assert.True(t, extras.Fields[0].Mutable)
+	assert.False(t, extras.Fields[1].Mutable)
+	assert.Equal(t, "String", extras.Fields[1].Type)
+
+	assert.Equal(t, "struct", classes[1].Extras.(*ClassExtras).Kind)
+	assert.Equal(t, "protocol", classes[2].Extras.(*ClassExtras).Kind)
+	assert.Equal(t, "extension", classes[3].Extras.(*ClassExtras).Kind)
+	assert.Equal(t, "enum", classes[4].Extras.(*ClassExtras).Kind)
+	assert.Len(t, classes[4].Extras.(*ClassExtras).Fields, 2)
+}
+
+func TestExtractor_ExtractCalls(t *testing.T) {
+	t.Parallel()
+	units := parseSwift(t)
+
+	extractor := &Extractor{}
+	calls, err := extractor.ExtractCalls(units)
+	assert.Nil(t, err)
+	assert.Len(t, calls, 4)
+	assert.Equal(t, "super.init", calls[0].Caller)
+	assert.Equal(t, "log", calls[1].Caller)
+	assert.Equal(t, "self.engine.start", calls[2].Caller)
+	assert.Equal(t, []string{"level: 2"}, calls[2].Arguments)
+}
+
+func TestExtractor_ExtractImports(t *testing.T) {
+	t.Parallel()
+	units := parseSwift(t)
+
+	extractor := &Extractor{}
+	imports, err := extractor.ExtractImports(units)
+	assert.Nil(t, err)
+	assert.Len(t, imports, 1)
+	assert.Equal(t, "Foundation", imports[0].Source)
+	assert.True(t, imports[0].Wildcard)
+}
+
+func TestExtractor_ExtractComments(t *testing.T) {
+	t.Parallel()
+	units := parseSwift(t)
+
+	extractor := &Extractor{}
+	comments, err := extractor.ExtractComments(units)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Speed up.", comments[0].Text)
+
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	for _, each := range functions {
+		if each.Name == "accelerate" && each.Receiver == "Vehicle" {
+			assert.Equal(t, "Speed up.", each.Extras.(*FunctionExtras).Doc)
+			assert.Equal(t, each.GetSignature(), comments[0].Owner)
+		}
+	}
+}
+
+func TestExtractor_ExtractVariables(t *testing.T) {
+	t.Parallel()
+	units := parseSwift(t)
+
+	extractor := &Extractor{}
+	variables, err := extractor.ExtractVariables(units)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, variables)
+
+	assert.Equal(t, "speed", variables[0].Name)
+	assert.Equal(t, "Int", variables[0].Type)
+	assert.Equal(t, "0", variables[0].Value)
+	assert.Equal(t, object.MutabilityVar, variables[0].Mutability)
+	assert.Equal(t, object.VariableScopeClass, variables[0].Scope)
+	assert.Equal(t, "Vehicle", variables[0].Receiver)
+
+	assert.Equal(t, "name", variables[1].Name)
+	assert.Equal(t, object.MutabilityFinal, variables[1].Mutability)
+}
+
+func TestExtractor_ExtractMetrics(t *testing.T) {
+	t.Parallel()
+	units := parseSwift(t)
+
+	extractor := &Extractor{}
+	functions, err := extractor.ExtractFunctions(units)
+	assert.Nil(t, err)
+	for _, each := range functions {
+		assert.NotNil(t, each.Metrics)
+		assert.GreaterOrEqual(t, each.Metrics.Cyclomatic, 1)
+		assert.Equal(t, len(each.Parameters), each.Metrics.ParamCount)
+	}
+}
@@ -0,0 +1,53 @@
+package swift
+
+import (
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/extractor/object"
+)
+
+func (extractor *Extractor) IsVariable(unit *core.Unit) bool {
+	return unit.Kind == KindSwiftPropertyDeclaration && prop2Field(unit) != nil
+}
+
+func (extractor *Extractor) ExtractVariables(units []*core.Unit) ([]*object.Variable, error) {
+	ret := make([]*object.Variable, 0)
+	for _, eachUnit := range units {
+		if !extractor.IsVariable(eachUnit) {
+			continue
+		}
+		ret = append(ret, extractor.extractVariable(eachUnit))
+	}
+	return ret, nil
+}
+
+// extractVariable [modifiers], let/var, pattern, [type_annotation], [value | computed_property]
+func (extractor *Extractor) extractVariable(unit *core.Unit) *object.Variable {
+	field := prop2Field(unit)
+	v := object.NewVariable()
+	v.Span = unit.Span
+	v.Lang = extractor.GetLang()
+	v.Name = field.Name
+	v.Type = field.Type
+	if !field.Mutable {
+		v.Mutability = object.MutabilityFinal
+	}
+	for _, each := range unit.SubUnits {
+		switch each.Kind {
+		case KindSwiftModifiers, KindSwiftValueBindingPattern, KindSwiftPattern, KindSwiftTypeAnnotation, KindSwiftComputedProperty:
+		default:
+			v.Value = each.Content
+		}
+	}
+
+	owner := core.FindFirstByOneOfKindInParent(unit.ParentUnit, KindSwiftFunctionBody, KindSwiftLambdaLiteral,
+		KindSwiftClassBody, KindSwiftEnumClassBody, KindSwiftProtocolBody)
+	switch {
+	case owner == nil:
+	case owner.Kind == KindSwiftFunctionBody || owner.Kind == KindSwiftLambdaLiteral:
+		v.Scope = object.VariableScopeLocal
+	default:
+		v.Scope = object.VariableScopeClass
+		v.Receiver = findTypePath(unit)
+	}
+	return v
+}
@@ -46,6 +46,7 @@ type driverCreate interface {
 	CreateFuncTag(wc *object.WorkspaceConfig, signature string, tag string, ctx context.Context) error
 	CreateFuncContext(wc *object.WorkspaceConfig, f *object.FunctionContextSlim, ctx context.Context) error
 	CreateClazzFile(wc *object.WorkspaceConfig, c *extractor.ClazzFileResult, ctx context.Context) error
+	CreateImportFile(wc *object.WorkspaceConfig, i *extractor.ImportFileResult, ctx context.Context) error
 	CreateWorkspace(wc *object.WorkspaceConfig, ctx context.Context) error
 }
 
@@ -66,6 +67,9 @@ type driverRead interface {
 	ReadClassesWithLines(wc *object.WorkspaceConfig, path string, lines []int, ctx context.Context) ([]*object.ClazzServiceDTO, error)
 	ReadClassesWithRule(wc *object.WorkspaceConfig, rule Rule, ctx context.Context) ([]*object.ClazzServiceDTO, error)
 
+	ReadImports(wc *object.WorkspaceConfig, path string, ctx context.Context) ([]*object.ImportServiceDTO, error)
+	ReadImportsWithRule(wc *object.WorkspaceConfig, rule Rule, ctx context.Context) ([]*object.ImportServiceDTO, error)
+
 	ReadFunctionContextsWithLines(wc *object.WorkspaceConfig, path string, lines []int, ctx context.Context) ([]*object.FuncCtxServiceDTO, error)
 	ReadFunctionContextsWithRule(wc *object.WorkspaceConfig, rule Rule, ctx context.Context) ([]*object.FuncCtxServiceDTO, error)
 	ReadFunctionContextWithSignature(wc *object.WorkspaceConfig, signature string, ctx context.Context) (*object.FuncCtxServiceDTO, error)
@@ -120,7 +120,7 @@ func (d *badgerDriver) CreateFuncFile(wc *object.WorkspaceConfig, f *extractor.F
 						Function: eachFunc,
 						Path:     f.Path,
 					},
-					Tags: make([]string, 0),
+					Tags: object.NewFuncTags(eachFunc),
 				},
 				Signature: eachFunc.GetSignature(),
 			}
@@ -323,3 +323,52 @@ func (d *badgerDriver) CreateFuncTag(wc *object.WorkspaceConfig, signature strin
 	}
 	return nil
 }
+
+func (d *badgerDriver) CreateImportFile(wc *object.WorkspaceConfig, i *extractor.ImportFileResult, ctx context.Context) error {
+	key, err := wc.Key()
+	if err != nil {
+		return err
+	}
+
+	err = d.db.Update(func(txn *badger.Txn) error {
+		fk := toFileKey(key, i.Path)
+		byteKey := []byte(fk.String())
+
+		// write file key
+		err = txn.Set(byteKey, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, eachImport := range i.Units {
+			eachImportKey := toImportKey(fk.RevHash, fk.FileHash, eachImport.Span)
+			eachImportDTO := &object.ImportServiceDTO{
+				ImportWithPath: &extractor.ImportWithPath{
+					Import: eachImport,
+					Path:   i.Path,
+				},
+			}
+			eachImportV, err := json.Marshal(eachImportDTO)
+			if err != nil {
+				continue
+			}
+			err = txn.Set([]byte(eachImportKey.String()), eachImportV)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// retry
+	if err == badger.ErrConflict {
+		r := rand.Intn(conflictRetryLimitMs)
+		time.Sleep(time.Duration(r) * time.Microsecond)
+		return d.CreateImportFile(wc, i, ctx)
+	}
+
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/opensibyl/sibyl2/pkg/server/object"
+	"github.com/tidwall/gjson"
+)
+
+func (d *badgerDriver) ReadImports(wc *object.WorkspaceConfig, path string, ctx context.Context) ([]*object.ImportServiceDTO, error) {
+	key, err := wc.Key()
+	if err != nil {
+		return nil, err
+	}
+	curFileKey := toFileKey(key, path)
+
+	searchResult := make([]*object.ImportServiceDTO, 0)
+	err = d.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		prefix := []byte(curFileKey.ToImportScanPrefix())
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			i := &object.ImportServiceDTO{}
+			err = it.Item().Value(func(val []byte) error {
+				err = json.Unmarshal(val, i)
+				if err != nil {
+					return fmt.Errorf("unmarshal import failed: %w", err)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			i.Path = path
+			searchResult = append(searchResult, i)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return searchResult, nil
+}
+
+func (d *badgerDriver) ReadImportsWithRule(wc *object.WorkspaceConfig, rule Rule, ctx context.Context) ([]*object.ImportServiceDTO, error) {
+	if len(rule) == 0 {
+		return nil, errors.New("rule is empty")
+	}
+
+	key, err := wc.Key()
+	if err != nil {
+		return nil, err
+	}
+	prefix := []byte(ToRevKey(key).ToFileScanPrefix())
+
+	searchResult := make([]*object.ImportServiceDTO, 0)
+	err = d.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			k := string(it.Item().Key())
+			if !strings.Contains(k, importEndPrefix) {
+				continue
+			}
+			err = it.Item().Value(func(val []byte) error {
+				for rk, verify := range rule {
+					v := gjson.GetBytes(val, rk)
+					if !verify(v.String()) {
+						// failed and ignore this item
+						return nil
+					}
+				}
+				// all the rules passed
+				i := &object.ImportServiceDTO{}
+				err = json.Unmarshal(val, i)
+				if err != nil {
+					return err
+				}
+				searchResult = append(searchResult, i)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return searchResult, nil
+}
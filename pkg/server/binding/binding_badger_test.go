@@ -3,11 +3,14 @@ package binding
 import (
 	"context"
 	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/opensibyl/sibyl2"
 	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"github.com/opensibyl/sibyl2/pkg/extractor/golang"
+	object2 "github.com/opensibyl/sibyl2/pkg/extractor/object"
 	"github.com/opensibyl/sibyl2/pkg/server/object"
 	"github.com/stretchr/testify/assert"
 )
@@ -140,6 +143,328 @@ func TestBadgerFunc(t *testing.T) {
 	assert.Len(t, fs, 0)
 }
 
+func TestBadgerFuncDoc(t *testing.T) {
+	d := initBadgerDriver(getBadgerTestConfig())
+	ctx := context.TODO()
+	err := d.InitDriver(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	defer d.DeferDriver()
+	defer d.DeleteWorkspace(wc, ctx)
+	err = d.CreateWorkspace(wc, ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	function := extractor.BaseFileResult[*extractor.Function]{
+		Path:     "abc/de/f.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractFunction,
+		Units: []*extractor.Function{
+			{
+				Name:   "fn",
+				Extras: &golang.FuncExtras{Doc: "fn does something.\nDeprecated: use fn1 instead."},
+			},
+			{
+				Name:   "fn1",
+				Extras: &golang.FuncExtras{Doc: "fn1 does something."},
+			},
+		},
+	}
+	err = d.CreateFuncFile(wc, &function, ctx)
+	assert.Nil(t, err)
+
+	// functions whose doc mentions "deprecated"
+	rule := make(Rule)
+	regex, err := regexp.Compile("(?i)deprecated")
+	assert.Nil(t, err)
+	rule["extras.doc"] = func(s string) bool {
+		return regex.Match([]byte(s))
+	}
+	funcs, err := d.ReadFunctionsWithRule(wc, rule, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 1)
+	assert.Equal(t, "fn", funcs[0].Name)
+}
+
+func TestBadgerFuncMetrics(t *testing.T) {
+	d := initBadgerDriver(getBadgerTestConfig())
+	ctx := context.TODO()
+	err := d.InitDriver(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	defer d.DeferDriver()
+	defer d.DeleteWorkspace(wc, ctx)
+	err = d.CreateWorkspace(wc, ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	function := extractor.BaseFileResult[*extractor.Function]{
+		Path:     "abc/de/f.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractFunction,
+		Units: []*extractor.Function{
+			{
+				Name:    "fn",
+				Metrics: &object2.FuncMetrics{Cyclomatic: 20},
+			},
+			{
+				Name:    "fn1",
+				Metrics: &object2.FuncMetrics{Cyclomatic: 3},
+			},
+		},
+	}
+	err = d.CreateFuncFile(wc, &function, ctx)
+	assert.Nil(t, err)
+
+	// functions with complexity > 15
+	rule := make(Rule)
+	rule["metrics.cyclomatic"] = func(s string) bool {
+		complexity, err := strconv.Atoi(s)
+		return err == nil && complexity > 15
+	}
+	funcs, err := d.ReadFunctionsWithRule(wc, rule, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 1)
+	assert.Equal(t, "fn", funcs[0].Name)
+	assert.Equal(t, 20, funcs[0].Metrics.Cyclomatic)
+}
+
+func TestBadgerFuncHash(t *testing.T) {
+	d := initBadgerDriver(getBadgerTestConfig())
+	ctx := context.TODO()
+	err := d.InitDriver(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	defer d.DeferDriver()
+	defer d.DeleteWorkspace(wc, ctx)
+	err = d.CreateWorkspace(wc, ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	function := extractor.BaseFileResult[*extractor.Function]{
+		Path:     "abc/de/f.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractFunction,
+		Units: []*extractor.Function{
+			{
+				Name:          "fn",
+				BodyHash:      "aaa",
+				SignatureHash: "bbb",
+			},
+			{
+				Name:          "fn1",
+				BodyHash:      "ccc",
+				SignatureHash: "ddd",
+			},
+		},
+	}
+	err = d.CreateFuncFile(wc, &function, ctx)
+	assert.Nil(t, err)
+
+	rule := make(Rule)
+	rule["bodyHash"] = func(s string) bool {
+		return s == "aaa"
+	}
+	funcs, err := d.ReadFunctionsWithRule(wc, rule, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 1)
+	assert.Equal(t, "fn", funcs[0].Name)
+	assert.Equal(t, "bbb", funcs[0].SignatureHash)
+}
+
+func TestBadgerCompareRevs(t *testing.T) {
+	d := initBadgerDriver(getBadgerTestConfig())
+	ctx := context.TODO()
+	err := d.InitDriver(ctx)
+	if err != nil {
+		panic(err)
+	}
+	defer d.DeferDriver()
+
+	newWc := &object.WorkspaceConfig{
+		RepoId:  wc.RepoId,
+		RevHash: "67890f",
+	}
+	for _, each := range []*object.WorkspaceConfig{wc, newWc} {
+		defer d.DeleteWorkspace(each, ctx)
+		err = d.CreateWorkspace(each, ctx)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	newFunc := func(name string, bodyHash string) *extractor.Function {
+		f := &extractor.Function{Name: name, BodyHash: bodyHash}
+		f.SignatureHash = object2.Hash(f.GetSignature())
+		return f
+	}
+	err = d.CreateFuncFile(wc, &extractor.FunctionFileResult{
+		Path:     "abc/de/f.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractFunction,
+		Units:    []*extractor.Function{newFunc("kept", "a"), newFunc("changed", "b"), newFunc("removed", "c")},
+	}, ctx)
+	assert.Nil(t, err)
+	err = d.CreateFuncFile(newWc, &extractor.FunctionFileResult{
+		Path:     "abc/de/f.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractFunction,
+		Units:    []*extractor.Function{newFunc("kept", "a"), newFunc("changed", "bb"), newFunc("added", "d")},
+	}, ctx)
+	assert.Nil(t, err)
+	// same signature in another file
+	err = d.CreateFuncFile(wc, &extractor.FunctionFileResult{
+		Path:     "abc/de/g.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractFunction,
+		Units:    []*extractor.Function{newFunc("kept", "e")},
+	}, ctx)
+	assert.Nil(t, err)
+
+	// facts uploaded before hashes, compared by signatures
+	err = d.CreateClazzFile(wc, &extractor.ClazzFileResult{
+		Path:     "abc/de/f.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractClazz,
+		Units:    []*extractor.Clazz{{Name: "kept"}, {Name: "removed"}},
+	}, ctx)
+	assert.Nil(t, err)
+	err = d.CreateClazzFile(newWc, &extractor.ClazzFileResult{
+		Path:     "abc/de/f.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractClazz,
+		Units:    []*extractor.Clazz{{Name: "kept"}},
+	}, ctx)
+	assert.Nil(t, err)
+
+	diff, err := CompareRevs(d, wc, newWc, ctx)
+	assert.Nil(t, err)
+	newDiffed := func(path string, signature string) *object.DiffedFactServiceDTO {
+		return &object.DiffedFactServiceDTO{Path: path, Signature: signature}
+	}
+	assert.Equal(t, []*object.DiffedFactServiceDTO{
+		newDiffed("abc/de/f.go", newFunc("added", "").GetSignature()),
+	}, diff.Functions.Added)
+	assert.Equal(t, []*object.DiffedFactServiceDTO{
+		newDiffed("abc/de/f.go", newFunc("removed", "").GetSignature()),
+		newDiffed("abc/de/g.go", newFunc("kept", "").GetSignature()),
+	}, diff.Functions.Removed)
+	assert.Equal(t, []*object.DiffedFactServiceDTO{
+		newDiffed("abc/de/f.go", newFunc("changed", "").GetSignature()),
+	}, diff.Functions.Changed)
+	assert.Empty(t, diff.Classes.Added)
+	assert.Equal(t, []*object.DiffedFactServiceDTO{
+		newDiffed("abc/de/f.go", (&extractor.Clazz{Name: "removed"}).GetSignature()),
+	}, diff.Classes.Removed)
+	assert.Empty(t, diff.Classes.Changed)
+
+	// nothing changed
+	diff, err = CompareRevs(d, newWc, newWc, ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, diff.Functions.Added)
+	assert.Empty(t, diff.Functions.Removed)
+	assert.Empty(t, diff.Functions.Changed)
+}
+
+func TestBadgerFuncTestTag(t *testing.T) {
+	d := initBadgerDriver(getBadgerTestConfig())
+	ctx := context.TODO()
+	err := d.InitDriver(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	defer d.DeferDriver()
+	defer d.DeleteWorkspace(wc, ctx)
+	err = d.CreateWorkspace(wc, ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	function := extractor.BaseFileResult[*extractor.Function]{
+		Path:     "abc/de/f_test.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractFunction,
+		Units: []*extractor.Function{
+			{
+				Name:   "TestFn",
+				Extras: map[string]any{"testFramework": object2.TestFrameworkGo},
+			},
+			{
+				Name: "fn",
+			},
+		},
+	}
+	err = d.CreateFuncFile(wc, &function, ctx)
+	assert.Nil(t, err)
+
+	funcs, err := d.ReadFunctionsWithTag(wc, object.FuncTagTest, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 1)
+	funcs, err = d.ReadFunctionsWithTag(wc, object.FuncTagTest+":"+object2.TestFrameworkGo, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 1)
+}
+
+func TestBadgerFuncEntryTag(t *testing.T) {
+	d := initBadgerDriver(getBadgerTestConfig())
+	ctx := context.TODO()
+	err := d.InitDriver(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	defer d.DeferDriver()
+	defer d.DeleteWorkspace(wc, ctx)
+	err = d.CreateWorkspace(wc, ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	function := extractor.BaseFileResult[*extractor.Function]{
+		Path:     "abc/de/f.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractFunction,
+		Units: []*extractor.Function{
+			{
+				Name: "listUsers",
+				EntryPoints: []*object2.EntryPoint{{
+					Kind:      object2.EntryKindHttp,
+					Framework: object2.EntryFrameworkGin,
+					Method:    "GET",
+					Route:     "/users",
+				}},
+			},
+			{
+				Name: "fn",
+			},
+		},
+	}
+	err = d.CreateFuncFile(wc, &function, ctx)
+	assert.Nil(t, err)
+
+	funcs, err := d.ReadFunctionsWithTag(wc, object.FuncTagEntry, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 1)
+	funcs, err = d.ReadFunctionsWithTag(wc, object.FuncTagEntry+":"+object2.EntryKindHttp, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, funcs, 1)
+
+	f, err := d.ReadFunctionWithSignature(wc, funcs[0], ctx)
+	assert.Nil(t, err)
+	assert.Len(t, f.EntryPoints, 1)
+	assert.Equal(t, "/users", f.EntryPoints[0].Route)
+}
+
 func TestBadgerClazz(t *testing.T) {
 	d := initBadgerDriver(getBadgerTestConfig())
 	ctx := context.TODO()
@@ -192,6 +517,55 @@ func TestBadgerClazz(t *testing.T) {
 	assert.Equal(t, 1, len(classes))
 }
 
+func TestBadgerImport(t *testing.T) {
+	d := initBadgerDriver(getBadgerTestConfig())
+	ctx := context.TODO()
+	err := d.InitDriver(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	defer d.DeferDriver()
+	defer d.DeleteWorkspace(wc, ctx)
+	err = d.CreateWorkspace(wc, ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	imports := extractor.BaseFileResult[*extractor.Import]{
+		Path:     "abc/de/f.go",
+		Language: core.LangGo,
+		Type:     extractor.TypeExtractImport,
+		Units: []*extractor.Import{
+			{
+				Source: "fmt",
+				Span:   core.Span{Start: core.Point{Row: 3}},
+			},
+			{
+				Source: "github.com/opensibyl/sibyl2/pkg/core",
+				Span:   core.Span{Start: core.Point{Row: 4}},
+			},
+		},
+	}
+
+	err = d.CreateImportFile(wc, &imports, ctx)
+	assert.Nil(t, err)
+
+	// check
+	ret, err := d.ReadImports(wc, imports.Path, ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ret))
+	assert.Equal(t, "fmt", ret[0].Source)
+
+	rule := make(Rule)
+	rule["source"] = func(s string) bool {
+		return s == "fmt"
+	}
+	ret, err = d.ReadImportsWithRule(wc, rule, ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ret))
+}
+
 func TestBadgerFuncCtx(t *testing.T) {
 	d := initBadgerDriver(getBadgerTestConfig())
 	ctx := context.TODO()
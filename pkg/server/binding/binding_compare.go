@@ -0,0 +1,143 @@
+package binding
+
+import (
+	"context"
+	"sort"
+
+	"github.com/opensibyl/sibyl2/pkg/server/object"
+)
+
+// hashedFact the parts of functions and classes which are used in comparing
+type hashedFact struct {
+	path          string
+	signature     string
+	signatureHash string
+	bodyHash      string
+}
+
+// key path and signatureHash, or signature for the facts uploaded before hashes.
+// Same signatures can be found in different files, eg: `init` of different go files in a package.
+func (f *hashedFact) key() string {
+	if f.signatureHash != "" {
+		return f.path + "#" + f.signatureHash
+	}
+	return f.path + "#" + f.signature
+}
+
+func (f *hashedFact) toDTO() *object.DiffedFactServiceDTO {
+	return &object.DiffedFactServiceDTO{
+		Path:      f.path,
+		Signature: f.signature,
+	}
+}
+
+// ruleAll reads all the facts of a rev
+var ruleAll = Rule{
+	"name": func(string) bool { return true },
+}
+
+/*
+CompareRevs functions and classes added, removed or changed from one rev to another.
+
+Only the stored hashes are compared, no source code needed:
+- path and signatureHash tell whether two facts are the same one
+- bodyHash tells whether its body changed, formats and comments are ignored
+*/
+func CompareRevs(driver Driver, from *object.WorkspaceConfig, to *object.WorkspaceConfig, ctx context.Context) (*object.RevDiffServiceDTO, error) {
+	fromFunctions, err := readHashedFunctions(driver, from, ctx)
+	if err != nil {
+		return nil, err
+	}
+	toFunctions, err := readHashedFunctions(driver, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+	fromClasses, err := readHashedClasses(driver, from, ctx)
+	if err != nil {
+		return nil, err
+	}
+	toClasses, err := readHashedClasses(driver, to, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &object.RevDiffServiceDTO{
+		Functions: diffHashedFacts(fromFunctions, toFunctions),
+		Classes:   diffHashedFacts(fromClasses, toClasses),
+	}, nil
+}
+
+func readHashedFunctions(driver Driver, wc *object.WorkspaceConfig, ctx context.Context) ([]*hashedFact, error) {
+	functions, err := driver.ReadFunctionsWithRule(wc, ruleAll, ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*hashedFact, 0, len(functions))
+	for _, each := range functions {
+		ret = append(ret, &hashedFact{
+			path:          each.Path,
+			signature:     each.GetSignature(),
+			signatureHash: each.SignatureHash,
+			bodyHash:      each.BodyHash,
+		})
+	}
+	return ret, nil
+}
+
+func readHashedClasses(driver Driver, wc *object.WorkspaceConfig, ctx context.Context) ([]*hashedFact, error) {
+	classes, err := driver.ReadClassesWithRule(wc, ruleAll, ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*hashedFact, 0, len(classes))
+	for _, each := range classes {
+		ret = append(ret, &hashedFact{
+			path:          each.Path,
+			signature:     each.GetSignature(),
+			signatureHash: each.SignatureHash,
+			bodyHash:      each.BodyHash,
+		})
+	}
+	return ret, nil
+}
+
+// diffHashedFacts sorted by paths and signatures, changed ones are the ones in `to`
+func diffHashedFacts(from []*hashedFact, to []*hashedFact) *object.SignatureDiffServiceDTO {
+	ret := &object.SignatureDiffServiceDTO{
+		Added:   make([]*object.DiffedFactServiceDTO, 0),
+		Removed: make([]*object.DiffedFactServiceDTO, 0),
+		Changed: make([]*object.DiffedFactServiceDTO, 0),
+	}
+	fromMap := make(map[string]*hashedFact, len(from))
+	for _, each := range from {
+		fromMap[each.key()] = each
+	}
+	toMap := make(map[string]*hashedFact, len(to))
+	for _, each := range to {
+		toMap[each.key()] = each
+	}
+
+	for k, each := range toMap {
+		old, ok := fromMap[k]
+		switch {
+		case !ok:
+			ret.Added = append(ret.Added, each.toDTO())
+		case old.bodyHash != each.bodyHash:
+			ret.Changed = append(ret.Changed, each.toDTO())
+		}
+	}
+	for k, each := range fromMap {
+		if _, ok := toMap[k]; !ok {
+			ret.Removed = append(ret.Removed, each.toDTO())
+		}
+	}
+	for _, each := range [][]*object.DiffedFactServiceDTO{ret.Added, ret.Removed, ret.Changed} {
+		sort.Slice(each, func(i, j int) bool {
+			if each[i].Path != each[j].Path {
+				return each[i].Path < each[j].Path
+			}
+			return each[i].Signature < each[j].Signature
+		})
+	}
+	return ret
+}
@@ -1,8 +1,10 @@
 package binding
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/opensibyl/sibyl2/pkg/core"
 	"github.com/opensibyl/sibyl2/pkg/server/object"
 )
 
@@ -12,6 +14,7 @@ storage:
 - rev_<hash>_file|<hash>:
 - rev_<hash>_file_<hash>_func|<hash>: func details map
 - rev_<hash>_file_<hash>_funcctx|<hash>: func ctx details map
+- rev_<hash>_file_<hash>_import|<row:column>: import details map
 
 mean:
 - |: type def end
@@ -27,6 +30,7 @@ const (
 	funcEndPrefix    = "func" + flagEnd
 	clazzEndPrefix   = "clazz" + flagEnd
 	funcctxEndPrefix = "funcctx" + flagEnd
+	importEndPrefix  = "import" + flagEnd
 	flagConnect      = "_"
 	flagEnd          = "|"
 )
@@ -93,6 +97,10 @@ func (f *fileKey) ToFuncScanPrefix() string {
 	return f.ToScanPrefix() + funcEndPrefix
 }
 
+func (f *fileKey) ToImportScanPrefix() string {
+	return f.ToScanPrefix() + importEndPrefix
+}
+
 func toFileKey(revHash string, fileHash string) *fileKey {
 	return &fileKey{revHash, fileHash}
 }
@@ -150,3 +158,19 @@ func (f *funcCtxKey) String() string {
 func (f *funcCtxKey) StringWithoutFile() string {
 	return revSearchPrefix + f.revHash + flagConnect + ptrSearchPrefix + funcctxEndPrefix + f.funcHash
 }
+
+// imports have no signatures, use their positions instead.
+// padded for keeping the original order in scanning.
+type importKey struct {
+	revHash    string
+	fileHash   string
+	importHash string
+}
+
+func toImportKey(revHash string, fileHash string, span core.Span) *importKey {
+	return &importKey{revHash, fileHash, fmt.Sprintf("%08d:%08d", span.Start.Row, span.Start.Column)}
+}
+
+func (i *importKey) String() string {
+	return revSearchPrefix + i.revHash + flagConnect + fileSearchPrefix + i.fileHash + flagConnect + importEndPrefix + i.importHash
+}
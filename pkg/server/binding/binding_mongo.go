@@ -63,6 +63,20 @@ func (c *MongoFactClazz) ToClazzDTO() *object.ClazzServiceDTO {
 	}
 }
 
+type MongoFactImport struct {
+	*MongoFactBase `bson:",inline"`
+	Import         *object2.Import `bson:"import"`
+}
+
+func (i *MongoFactImport) ToImportDTO() *object.ImportServiceDTO {
+	return &object.ImportServiceDTO{
+		ImportWithPath: &extractor.ImportWithPath{
+			Import: i.Import,
+			Path:   i.Path,
+		},
+	}
+}
+
 type MongoRelFuncCtx struct {
 	*MongoFactBase `bson:",inline"`
 	FuncCtx        *object.FunctionContextSlim `bson:"funcctx"`
@@ -99,6 +113,10 @@ const (
 	mongoKeyClazzRowStart = mongoKeyClazz + "." + idxRowStartSuffix
 	mongoKeyClazzRowEnd   = mongoKeyClazz + "." + idxRowEndSuffix
 
+	mongoKeyImport         = "import"
+	mongoKeyImportRowStart = mongoKeyImport + "." + idxRowStartSuffix
+	mongoKeyImportColStart = mongoKeyImport + ".span.start.column"
+
 	mongoKeyFuncCtx         = "funcctx"
 	mongoKeyFuncCtxRowStart = mongoKeyFuncCtx + "." + idxRowStartSuffix
 	mongoKeyFuncCtxRowEnd   = mongoKeyFuncCtx + "." + idxRowEndSuffix
@@ -106,6 +124,7 @@ const (
 	mongoCollectionClazz   = "fact_clazz"
 	mongoCollectionFunc    = "fact_func"
 	mongoCollectionFuncCtx = "rel_funcctx"
+	mongoCollectionImport  = "fact_import"
 )
 
 type mongoDriver struct {
@@ -176,6 +195,21 @@ func (d *mongoDriver) InitDriver(ctx context.Context) error {
 	}
 	_, _ = funcCtxCollection.Indexes().CreateOne(ctx, index)
 
+	// imports have no signatures, so path and position instead
+	importCollection := d.client.Database(d.config.MongoDbName).Collection(mongoCollectionImport)
+	keys = bson.D{
+		{Key: mongoKeyRepo, Value: 1},
+		{Key: mongoKeyRev, Value: 1},
+		{Key: mongoKeyPath, Value: 1},
+		{Key: mongoKeyImportRowStart, Value: 1},
+		{Key: mongoKeyImportColStart, Value: 1},
+	}
+	index = mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetUnique(true),
+	}
+	_, _ = importCollection.Indexes().CreateOne(ctx, index)
+
 	return nil
 }
 
@@ -25,7 +25,7 @@ func (d *mongoDriver) CreateFuncFile(wc *object.WorkspaceConfig, f *extractor.Fu
 				RevHash:   wc.RevHash,
 				Path:      f.Path,
 				Signature: eachFunc.GetSignature(),
-				Tags:      []string{},
+				Tags:      object.NewFuncTags(eachFunc),
 			},
 			Func: eachFunc,
 		}
@@ -124,6 +124,34 @@ func (d *mongoDriver) CreateClazzFile(wc *object.WorkspaceConfig, c *extractor.C
 	return nil
 }
 
+func (d *mongoDriver) CreateImportFile(wc *object.WorkspaceConfig, i *extractor.ImportFileResult, ctx context.Context) error {
+	if i.IsEmpty() {
+		return nil
+	}
+
+	collection := d.client.Database(d.config.MongoDbName).Collection(mongoCollectionImport)
+
+	models := make([]mongo.WriteModel, 0, len(i.Units))
+	for _, eachImport := range i.Units {
+		doc := &MongoFactImport{
+			MongoFactBase: &MongoFactBase{
+				RepoId:    wc.RepoId,
+				RevHash:   wc.RevHash,
+				Path:      i.Path,
+				Signature: eachImport.Source,
+				Tags:      []string{},
+			},
+			Import: eachImport,
+		}
+		models = append(models, mongo.NewInsertOneModel().SetDocument(doc))
+	}
+	_, err := collection.BulkWrite(ctx, models)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	return nil
+}
+
 func (d *mongoDriver) CreateWorkspace(wc *object.WorkspaceConfig, ctx context.Context) error {
 	// no need
 	return nil
@@ -11,6 +11,7 @@ func (d *mongoDriver) DeleteWorkspace(wc *object.WorkspaceConfig, ctx context.Co
 	funcCollection := d.client.Database(d.config.MongoDbName).Collection(mongoCollectionFunc)
 	clazzCollection := d.client.Database(d.config.MongoDbName).Collection(mongoCollectionClazz)
 	funcctxCollection := d.client.Database(d.config.MongoDbName).Collection(mongoCollectionFuncCtx)
+	importCollection := d.client.Database(d.config.MongoDbName).Collection(mongoCollectionImport)
 
 	filter := bson.M{
 		mongoKeyRepo: wc.RepoId,
@@ -29,6 +30,10 @@ func (d *mongoDriver) DeleteWorkspace(wc *object.WorkspaceConfig, ctx context.Co
 	if err != nil {
 		return err
 	}
+	_, err = importCollection.DeleteMany(ctx, filter)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
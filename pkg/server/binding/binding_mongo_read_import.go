@@ -0,0 +1,46 @@
+package binding
+
+import (
+	"context"
+	"errors"
+
+	"github.com/opensibyl/sibyl2/pkg/server/object"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (d *mongoDriver) ReadImports(wc *object.WorkspaceConfig, path string, ctx context.Context) ([]*object.ImportServiceDTO, error) {
+	collection := d.client.Database(d.config.MongoDbName).Collection(mongoCollectionImport)
+
+	filter := bson.M{
+		mongoKeyRepo: wc.RepoId,
+		mongoKeyRev:  wc.RevHash,
+		mongoKeyPath: path,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: mongoKeyImportRowStart, Value: 1}, {Key: mongoKeyImportColStart, Value: 1}})
+
+	cur, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	imports := make([]*object.ImportServiceDTO, 0)
+	for cur.Next(ctx) {
+		doc := &MongoFactImport{}
+		err := cur.Decode(doc)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, doc.ToImportDTO())
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return imports, nil
+}
+
+func (d *mongoDriver) ReadImportsWithRule(wc *object.WorkspaceConfig, rule Rule, ctx context.Context) ([]*object.ImportServiceDTO, error) {
+	return nil, errors.New("implement me")
+}
@@ -55,6 +55,50 @@ func (t *tikvDriver) CreateClazzFile(wc *object.WorkspaceConfig, c *extractor.Cl
 	return nil
 }
 
+func (t *tikvDriver) CreateImportFile(wc *object.WorkspaceConfig, i *extractor.ImportFileResult, ctx context.Context) error {
+	key, err := wc.Key()
+	if err != nil {
+		return err
+	}
+
+	fk := toFileKey(key, i.Path)
+	byteKey := []byte(fk.String())
+
+	txn, err := t.client.Begin()
+	if err != nil {
+		return err
+	}
+
+	// tikv does not allow set nil value
+	err = txn.Set(byteKey, byteKey)
+	if err != nil {
+		return err
+	}
+
+	for _, eachImport := range i.Units {
+		eachImportKey := toImportKey(fk.RevHash, fk.FileHash, eachImport.Span)
+		eachImportWithPath := &extractor.ImportWithPath{
+			Import: eachImport,
+			Path:   i.Path,
+		}
+		eachImportValue, err := json.Marshal(eachImportWithPath)
+		if err != nil {
+			continue
+		}
+		err = txn.Set([]byte(eachImportKey.String()), eachImportValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	// TiKV uses the optimistic transaction model by default
+	err = txn.Commit(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (t *tikvDriver) CreateFuncFile(wc *object.WorkspaceConfig, f *extractor.FunctionFileResult, ctx context.Context) error {
 	key, err := wc.Key()
 	if err != nil {
@@ -78,11 +122,14 @@ func (t *tikvDriver) CreateFuncFile(wc *object.WorkspaceConfig, f *extractor.Fun
 
 	for _, eachFunc := range f.Units {
 		eachFuncKey := toFuncKey(fk.RevHash, fk.FileHash, eachFunc.GetSignature())
-		eachFuncWithPath := &extractor.FunctionWithPath{
-			Function: eachFunc,
-			Path:     f.Path,
+		eachFuncWithTag := &object.FunctionWithTag{
+			FunctionWithPath: &extractor.FunctionWithPath{
+				Function: eachFunc,
+				Path:     f.Path,
+			},
+			Tags: object.NewFuncTags(eachFunc),
 		}
-		eachFuncV, err := json.Marshal(eachFuncWithPath)
+		eachFuncV, err := json.Marshal(eachFuncWithTag)
 		if err != nil {
 			continue
 		}
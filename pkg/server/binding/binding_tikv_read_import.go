@@ -0,0 +1,95 @@
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/opensibyl/sibyl2/pkg/server/object"
+	"github.com/tidwall/gjson"
+	"github.com/tikv/client-go/v2/kv"
+)
+
+func (t *tikvDriver) ReadImports(wc *object.WorkspaceConfig, path string, ctx context.Context) ([]*object.ImportServiceDTO, error) {
+	key, err := wc.Key()
+	if err != nil {
+		return nil, err
+	}
+	fk := toFileKey(key, path)
+
+	searchResult := make([]*object.ImportServiceDTO, 0)
+	prefix := []byte(fk.ToImportScanPrefix())
+
+	txn := t.client.GetSnapshot(math.MaxUint64)
+	iter, err := txn.Iter(prefix, kv.PrefixNextKey(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	for iter.Valid() {
+		i := &object.ImportServiceDTO{}
+		err = json.Unmarshal(iter.Value(), i)
+		if err != nil {
+			return nil, err
+		}
+		i.Path = path
+		searchResult = append(searchResult, i)
+		err = iter.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return searchResult, nil
+}
+
+func (t *tikvDriver) ReadImportsWithRule(wc *object.WorkspaceConfig, rule Rule, ctx context.Context) ([]*object.ImportServiceDTO, error) {
+	if len(rule) == 0 {
+		return nil, errors.New("rule is empty")
+	}
+
+	key, err := wc.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	searchResult := make([]*object.ImportServiceDTO, 0)
+	prefix := []byte(ToRevKey(key).ToFileScanPrefix())
+
+	txn := t.client.GetSnapshot(math.MaxUint64)
+	iter, err := txn.Iter(prefix, kv.PrefixNextKey(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	for iter.Valid() {
+		k := string(iter.Key())
+		if strings.Contains(k, importEndPrefix) {
+			rawImport := iter.Value()
+			for rk, verify := range rule {
+				v := gjson.GetBytes(rawImport, rk)
+				if !verify(v.String()) {
+					// failed and ignore this item
+					goto nextIter
+				}
+			}
+			// all the rules passed
+			i := &object.ImportServiceDTO{}
+			err = json.Unmarshal(rawImport, i)
+			if err != nil {
+				return nil, err
+			}
+			searchResult = append(searchResult, i)
+		}
+
+	nextIter:
+		err = iter.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return searchResult, nil
+}
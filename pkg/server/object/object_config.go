@@ -46,6 +46,8 @@ type QueueConfigPart struct {
 	KafkaFuncCtxConsumerGroup string    `mapstructure:"kafkaFuncCtxConsumerGroup"`
 	KafkaClazzTopic           string    `mapstructure:"kafkaClazzTopic"`
 	KafkaClazzConsumerGroup   string    `mapstructure:"kafkaClazzConsumerGroup"`
+	KafkaImportTopic          string    `mapstructure:"kafkaImportTopic"`
+	KafkaImportConsumerGroup  string    `mapstructure:"kafkaImportConsumerGroup"`
 }
 
 type ExecuteConfig struct {
@@ -83,6 +85,8 @@ func DefaultExecuteConfig() ExecuteConfig {
 			"sibyl-consumer-funcctx",
 			"sibyl-upload-clazz",
 			"sibyl-consumer-clazz",
+			"sibyl-upload-import",
+			"sibyl-consumer-import",
 		},
 	}
 }
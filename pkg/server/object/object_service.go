@@ -3,6 +3,7 @@ package object
 import (
 	"github.com/opensibyl/sibyl2"
 	"github.com/opensibyl/sibyl2/pkg/extractor"
+	"golang.org/x/exp/slices"
 )
 
 type FunctionServiceDTO struct {
@@ -15,6 +16,10 @@ type ClazzServiceDTO struct {
 	Signature                string `json:"signature" bson:"signature"`
 }
 
+type ImportServiceDTO struct {
+	*extractor.ImportWithPath `bson:",inline"`
+}
+
 type FuncCtxServiceDTO struct {
 	*FunctionContextSlim `bson:",inline"`
 	Signature            string `json:"signature" bson:"signature"`
@@ -22,6 +27,31 @@ type FuncCtxServiceDTO struct {
 
 type FuncTag = string
 
+const (
+	// FuncTagTest test functions, tagged automatically when uploading
+	FuncTagTest FuncTag = "test"
+	// FuncTagEntry entry points, eg: http handlers and scheduled jobs, tagged automatically when uploading
+	FuncTagEntry FuncTag = "entry"
+)
+
+// NewFuncTags tags which can be told from the function itself, eg: `test` and `test:junit` for junit tests
+func NewFuncTags(f *extractor.Function) []FuncTag {
+	ret := make([]FuncTag, 0)
+	if framework := f.GetTestFramework(); framework != "" {
+		ret = append(ret, FuncTagTest, FuncTagTest+":"+framework)
+	}
+	if f.IsEntryPoint() {
+		ret = append(ret, FuncTagEntry)
+		for _, each := range f.EntryPoints {
+			tag := FuncTagEntry + ":" + each.Kind
+			if !slices.Contains(ret, tag) {
+				ret = append(ret, tag)
+			}
+		}
+	}
+	return ret
+}
+
 type FunctionWithTag struct {
 	*extractor.FunctionWithPath `bson:",inline"`
 	Tags                        []FuncTag `json:"tags" bson:"tags"`
@@ -52,3 +82,22 @@ func CompressFunctionContext(f *sibyl2.FunctionContext) *FunctionContextSlim {
 	}
 	return slim
 }
+
+// SignatureDiffServiceDTO functions or classes added, removed or changed from one rev to another
+type SignatureDiffServiceDTO struct {
+	Added   []*DiffedFactServiceDTO `json:"added"`
+	Removed []*DiffedFactServiceDTO `json:"removed"`
+	// Changed same signatures in the same files with different bodies
+	Changed []*DiffedFactServiceDTO `json:"changed"`
+}
+
+// DiffedFactServiceDTO a function or class in diff, same signatures can be found in different files
+type DiffedFactServiceDTO struct {
+	Path      string `json:"path"`
+	Signature string `json:"signature"`
+}
+
+type RevDiffServiceDTO struct {
+	Functions *SignatureDiffServiceDTO `json:"functions"`
+	Classes   *SignatureDiffServiceDTO `json:"classes"`
+}
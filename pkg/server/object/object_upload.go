@@ -24,6 +24,11 @@ type ClazzUploadUnit struct {
 	ClazzFileResult *extractor.ClazzFileResult `json:"clazzFileResult"`
 }
 
+type ImportUploadUnit struct {
+	WorkspaceConfig  *WorkspaceConfig            `json:"workspace"`
+	ImportFileResult *extractor.ImportFileResult `json:"importFileResult"`
+}
+
 func SerializeUploadUnit(u interface{}) ([]byte, error) {
 	return json.Marshal(u)
 }
@@ -54,3 +59,12 @@ func DeserializeClazzUploadUnit(data []byte) (*ClazzUploadUnit, error) {
 	}
 	return u, nil
 }
+
+func DeserializeImportUploadUnit(data []byte) (*ImportUploadUnit, error) {
+	u := &ImportUploadUnit{}
+	err := json.Unmarshal(data, u)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
@@ -12,9 +12,11 @@ type Queue interface {
 	SubmitFunc(unit *object.FunctionUploadUnit) (err error)
 	SubmitFuncCtx(unit *object.FunctionContextUploadUnit) (err error)
 	SubmitClazz(unit *object.ClazzUploadUnit) (err error)
+	SubmitImport(unit *object.ImportUploadUnit) (err error)
 	WatchFunc(chan<- *object.FunctionUploadUnit)
 	WatchFuncCtx(chan<- *object.FunctionContextUploadUnit)
 	WatchClazz(chan<- *object.ClazzUploadUnit)
+	WatchImport(chan<- *object.ImportUploadUnit)
 }
 
 func InitQueue(config object.ExecuteConfig, ctx context.Context) Queue {
@@ -14,15 +14,18 @@ type KafkaQueue struct {
 	funcPushList    []chan<- *object.FunctionUploadUnit
 	funcCtxPushList []chan<- *object.FunctionContextUploadUnit
 	clazzPushList   []chan<- *object.ClazzUploadUnit
+	importPushList  []chan<- *object.ImportUploadUnit
 
 	ctx                context.Context
 	kafkaWriter        *kafka.Writer
 	kafkaFuncReader    *kafka.Reader
 	kafkaFuncCtxReader *kafka.Reader
 	kafkaClazzReader   *kafka.Reader
+	kafkaImportReader  *kafka.Reader
 	funcTopic          string
 	funcCtxTopic       string
 	clazzTopic         string
+	importTopic        string
 }
 
 func (k *KafkaQueue) GetType() object.QueueType {
@@ -91,6 +94,25 @@ func (k *KafkaQueue) SubmitClazz(unit *object.ClazzUploadUnit) (err error) {
 	return nil
 }
 
+func (k *KafkaQueue) SubmitImport(unit *object.ImportUploadUnit) (err error) {
+	v, err := object.SerializeUploadUnit(unit)
+	if err != nil {
+		core.Log.Errorf("error when serialize upload unit: %v", err)
+		return err
+	}
+
+	err = k.kafkaWriter.WriteMessages(k.ctx, kafka.Message{
+		Topic: k.importTopic,
+		Value: v,
+		Time:  time.Time{},
+	})
+	if err != nil {
+		core.Log.Errorf("error when write kafka msg: %v", err)
+		return err
+	}
+	return nil
+}
+
 func (k *KafkaQueue) WatchFunc(units chan<- *object.FunctionUploadUnit) {
 	go func() {
 		for {
@@ -145,6 +167,24 @@ func (k *KafkaQueue) WatchClazz(units chan<- *object.ClazzUploadUnit) {
 	}()
 }
 
+func (k *KafkaQueue) WatchImport(units chan<- *object.ImportUploadUnit) {
+	go func() {
+		for {
+			m, err := k.kafkaImportReader.ReadMessage(k.ctx)
+			core.Log.Debugf("rece new import: %d", m.Offset)
+			if err != nil {
+				core.Log.Errorf("kafka read failed: %v", err)
+				break
+			}
+			unit, err := object.DeserializeImportUploadUnit(m.Value)
+			if err != nil {
+				core.Log.Warnf("not a valid import upload object: %v", err)
+			}
+			units <- unit
+		}
+	}()
+}
+
 func newKafkaQueue(config object.ExecuteConfig, ctx context.Context) *KafkaQueue {
 	addr := strings.Split(config.KafkaAddrs, ",")
 
@@ -168,6 +208,11 @@ func newKafkaQueue(config object.ExecuteConfig, ctx context.Context) *KafkaQueue
 		Topic:   config.KafkaClazzTopic,
 		GroupID: config.KafkaClazzConsumerGroup,
 	})
+	importReader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: addr,
+		Topic:   config.KafkaImportTopic,
+		GroupID: config.KafkaImportConsumerGroup,
+	})
 
 	return &KafkaQueue{
 		kafkaWriter:        funcWriter,
@@ -175,8 +220,10 @@ func newKafkaQueue(config object.ExecuteConfig, ctx context.Context) *KafkaQueue
 		funcTopic:          config.KafkaFuncTopic,
 		funcCtxTopic:       config.KafkaFuncCtxTopic,
 		clazzTopic:         config.KafkaClazzTopic,
+		importTopic:        config.KafkaImportTopic,
 		kafkaFuncReader:    funcReader,
 		kafkaFuncCtxReader: funcCtxReader,
 		kafkaClazzReader:   clazzReader,
+		kafkaImportReader:  importReader,
 	}
 }
@@ -6,6 +6,7 @@ type MemoryQueue struct {
 	funcPushList    []chan<- *object.FunctionUploadUnit
 	funcCtxPushList []chan<- *object.FunctionContextUploadUnit
 	clazzPushList   []chan<- *object.ClazzUploadUnit
+	importPushList  []chan<- *object.ImportUploadUnit
 }
 
 func (q *MemoryQueue) GetType() object.QueueType {
@@ -29,6 +30,10 @@ func (q *MemoryQueue) WatchClazz(c chan<- *object.ClazzUploadUnit) {
 	q.clazzPushList = append(q.clazzPushList, c)
 }
 
+func (q *MemoryQueue) WatchImport(c chan<- *object.ImportUploadUnit) {
+	q.importPushList = append(q.importPushList, c)
+}
+
 func (q *MemoryQueue) SubmitFunc(unit *object.FunctionUploadUnit) error {
 	for _, each := range q.funcPushList {
 		each <- unit
@@ -50,6 +55,13 @@ func (q *MemoryQueue) SubmitClazz(unit *object.ClazzUploadUnit) (err error) {
 	return nil
 }
 
+func (q *MemoryQueue) SubmitImport(unit *object.ImportUploadUnit) (err error) {
+	for _, each := range q.importPushList {
+		each <- unit
+	}
+	return nil
+}
+
 func newMemoryQueue() *MemoryQueue {
 	return &MemoryQueue{}
 }
@@ -23,11 +23,13 @@ func injectV1Group(v1group *gin.RouterGroup) {
 	uploadGroup.Handle(http.MethodPost, "/func", service.HandleFunctionUpload)
 	uploadGroup.Handle(http.MethodPost, "/funcctx", service.HandleFunctionContextUpload)
 	uploadGroup.Handle(http.MethodPost, "/clazz", service.HandleClazzUpload)
+	uploadGroup.Handle(http.MethodPost, "/import", service.HandleImportUpload)
 	// basic
 	basicGroup := v1group.Group("/")
 	basicGroup.Handle(http.MethodGet, "/func", service.HandleFunctionsQuery)
 	basicGroup.Handle(http.MethodGet, "/funcctx", service.HandleFunctionContextsQuery)
 	basicGroup.Handle(http.MethodGet, "/clazz", service.HandleClazzesQuery)
+	basicGroup.Handle(http.MethodGet, "/import", service.HandleImportsQuery)
 
 	// query by signature
 	signatureGroup := v1group.Group("signature")
@@ -40,7 +42,12 @@ func injectV1Group(v1group *gin.RouterGroup) {
 	regexGroup := v1group.Group("regex")
 	regexGroup.Handle(http.MethodGet, "/func", service.HandleRegexFunc)
 	regexGroup.Handle(http.MethodGet, "/clazz", service.HandleRegexClazz)
+	regexGroup.Handle(http.MethodGet, "/import", service.HandleRegexImport)
 	regexGroup.Handle(http.MethodGet, "/funcctx", service.HandleRegexFuncctx)
+	// query by comparing numbers, eg: metrics
+	compareGroup := v1group.Group("compare")
+	compareGroup.Handle(http.MethodGet, "/func", service.HandleCompareFunc)
+	compareGroup.Handle(http.MethodGet, "/rev", service.HandleCompareRev)
 	// query by reference
 	referenceGroup := v1group.Group("reference")
 	countGroup := referenceGroup.Group("count")
@@ -50,6 +57,10 @@ func injectV1Group(v1group *gin.RouterGroup) {
 	tagGroup := v1group.Group("tag")
 	tagGroup.Handle(http.MethodGet, "/func", service.HandleFuncTagQuery)
 	tagGroup.Handle(http.MethodPost, "/func", service.HandleFuncTagCreate)
+	// entry points
+	entryGroup := v1group.Group("entry")
+	entryGroup.Handle(http.MethodGet, "/func", service.HandleEntryFuncQuery)
+	entryGroup.Handle(http.MethodGet, "/affected", service.HandleEntryAffectedQuery)
 	// query by stat
 	v1group.Handle(http.MethodGet, "/rev/stat", service.HandleRevStatQuery)
 }
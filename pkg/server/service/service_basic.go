@@ -146,3 +146,32 @@ func handleClazzQuery(repo string, rev string, file string) ([]*object.ClazzServ
 	}
 	return classes, nil
 }
+
+// @Summary import query
+// @Param   repo query string true "repo"
+// @Param   rev  query string true "rev"
+// @Param   file query string true "file"
+// @Produce json
+// @Success 200 {array} object.ImportServiceDTO
+// @Router  /api/v1/import [get]
+// @Tags    BasicQuery
+func HandleImportsQuery(c *gin.Context) {
+	repo := c.Query("repo")
+	rev := c.Query("rev")
+	file := c.Query("file")
+
+	wc := &object.WorkspaceConfig{
+		RepoId:  repo,
+		RevHash: rev,
+	}
+	if err := wc.Verify(); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	imports, err := sharedDriver.ReadImports(wc, file, sharedContext)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, imports)
+}
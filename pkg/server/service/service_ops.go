@@ -12,6 +12,7 @@ type UploadStats struct {
 	FuncUnitTodo    int `json:"funcUnitTodo"`
 	FuncCtxUnitTodo int `json:"funcCtxUnitTodo"`
 	ClazzUnitTodo   int `json:"clazzUnitTodo"`
+	ImportUnitTodo  int `json:"importUnitTodo"`
 }
 
 // @BasePath /
@@ -25,6 +26,7 @@ func HandleStatusUpload(c *gin.Context) {
 		FuncUnitTodo:    worker.GetFuncQueueTodoCount(),
 		FuncCtxUnitTodo: worker.GetFuncCtxQueueTodoCount(),
 		ClazzUnitTodo:   worker.GetClazzQueueTodoCount(),
+		ImportUnitTodo:  worker.GetImportQueueTodoCount(),
 	}
 	c.JSON(http.StatusOK, stat)
 }
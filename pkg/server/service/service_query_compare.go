@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opensibyl/sibyl2/pkg/server/binding"
+	"github.com/opensibyl/sibyl2/pkg/server/object"
+)
+
+// @Summary func query by comparing numbers
+// @Param   repo  query string true "repo"
+// @Param   rev   query string true "rev"
+// @Param   field query string true "field, eg: metrics.cyclomatic, metrics.loc"
+// @Param   op    query string true "operator: gt, ge, lt, le, eq, ne"
+// @Param   value query number true "value"
+// @Produce json
+// @Success 200 {array} object.FunctionServiceDTO
+// @Router  /api/v1/compare/func [get]
+// @Tags    CompareQuery
+func HandleCompareFunc(c *gin.Context) {
+	repo := c.Query("repo")
+	rev := c.Query("rev")
+	field := c.Query("field")
+	op := c.Query("op")
+	value := c.Query("value")
+
+	wc := &object.WorkspaceConfig{
+		RepoId:  repo,
+		RevHash: rev,
+	}
+	if err := wc.Verify(); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	verify, err := newCompareVerify(op, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	ruleMap := make(binding.Rule)
+	ruleMap[field] = verify
+
+	functions, err := sharedDriver.ReadFunctionsWithRule(wc, ruleMap, sharedContext)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, functions)
+}
+
+// newCompareVerify `gt 15` -> value > 15. Fields which are not numbers never pass.
+func newCompareVerify(op string, value string) (func(string) bool, error) {
+	target, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	var compare func(float64) bool
+	switch op {
+	case "gt":
+		compare = func(f float64) bool { return f > target }
+	case "ge":
+		compare = func(f float64) bool { return f >= target }
+	case "lt":
+		compare = func(f float64) bool { return f < target }
+	case "le":
+		compare = func(f float64) bool { return f <= target }
+	case "eq":
+		compare = func(f float64) bool { return f == target }
+	case "ne":
+		compare = func(f float64) bool { return f != target }
+	default:
+		return nil, fmt.Errorf("invalid op: %s", op)
+	}
+	return func(s string) bool {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return false
+		}
+		return compare(f)
+	}, nil
+}
+
+// @Summary compare two revs by the stored hashes of functions and classes
+// @Param   repo query string true "repo"
+// @Param   from query string true "base rev"
+// @Param   to   query string true "target rev"
+// @Produce json
+// @Success 200 {object} object.RevDiffServiceDTO
+// @Router  /api/v1/compare/rev [get]
+// @Tags    CompareQuery
+func HandleCompareRev(c *gin.Context) {
+	repo := c.Query("repo")
+	from := &object.WorkspaceConfig{
+		RepoId:  repo,
+		RevHash: c.Query("from"),
+	}
+	to := &object.WorkspaceConfig{
+		RepoId:  repo,
+		RevHash: c.Query("to"),
+	}
+	for _, each := range []*object.WorkspaceConfig{from, to} {
+		if err := each.Verify(); err != nil {
+			c.JSON(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	diff, err := binding.CompareRevs(sharedDriver, from, to, sharedContext)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
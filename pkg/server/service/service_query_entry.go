@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opensibyl/sibyl2/pkg/core"
+	"github.com/opensibyl/sibyl2/pkg/server/object"
+)
+
+// @Summary entry points query, eg: http handlers and scheduled jobs
+// @Param   repo query string true "repo"
+// @Param   rev  query string true "rev"
+// @Produce json
+// @Success 200 {array} object.FunctionServiceDTO
+// @Router  /api/v1/entry/func [get]
+// @Tags    EntryQuery
+func HandleEntryFuncQuery(c *gin.Context) {
+	repo := c.Query("repo")
+	rev := c.Query("rev")
+
+	wc := &object.WorkspaceConfig{
+		RepoId:  repo,
+		RevHash: rev,
+	}
+	if err := wc.Verify(); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	signatures, err := sharedDriver.ReadFunctionsWithTag(wc, object.FuncTagEntry, sharedContext)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+	ret := make([]*object.FunctionServiceDTO, 0, len(signatures))
+	for _, each := range signatures {
+		f, err := sharedDriver.ReadFunctionWithSignature(wc, each, sharedContext)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return
+		}
+		ret = append(ret, f)
+	}
+	c.JSON(http.StatusOK, ret)
+}
+
+// @Summary entry points which can reach the changed lines, by reverse calls
+// @Param   repo  query string true  "repo"
+// @Param   rev   query string true  "rev"
+// @Param   file  query string true  "file"
+// @Param   lines query string true  "changed lines"
+// @Param   depth query int    false "depth of reverse calls, unlimited by default"
+// @Produce json
+// @Success 200 {array} object.FunctionServiceDTO
+// @Router  /api/v1/entry/affected [get]
+// @Tags    EntryQuery
+func HandleEntryAffectedQuery(c *gin.Context) {
+	repo := c.Query("repo")
+	rev := c.Query("rev")
+	file := c.Query("file")
+	lines := c.Query("lines")
+	depth := c.DefaultQuery("depth", "-1")
+
+	depthNum, err := strconv.Atoi(depth)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Errorf("invalid depth: %w", err))
+		return
+	}
+	if lines == "" {
+		c.JSON(http.StatusBadRequest, "lines is required")
+		return
+	}
+	changed, err := handleFunctionQuery(repo, rev, file, lines)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	wc := &object.WorkspaceConfig{
+		RepoId:  repo,
+		RevHash: rev,
+	}
+	ret, err := searchAffectedEntries(wc, changed, depthNum)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, ret)
+}
+
+// searchAffectedEntries walk through reverse calls from the changed functions, and keep the entry points
+func searchAffectedEntries(wc *object.WorkspaceConfig, changed []*object.FunctionServiceDTO, depthLimit int) ([]*object.FunctionServiceDTO, error) {
+	entrySignatures, err := sharedDriver.ReadFunctionsWithTag(wc, object.FuncTagEntry, sharedContext)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]struct{}, len(entrySignatures))
+	for _, each := range entrySignatures {
+		entries[each] = struct{}{}
+	}
+
+	ret := make([]*object.FunctionServiceDTO, 0)
+	visited := make(map[string]struct{})
+	var cur []string
+	for _, each := range changed {
+		cur = append(cur, each.GetSignature())
+	}
+	for depth := 0; len(cur) != 0 && (depthLimit < 0 || depth <= depthLimit); depth++ {
+		var next []string
+		for _, each := range cur {
+			if _, ok := visited[each]; ok {
+				continue
+			}
+			visited[each] = struct{}{}
+
+			if _, ok := entries[each]; ok {
+				f, err := sharedDriver.ReadFunctionWithSignature(wc, each, sharedContext)
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, f)
+			}
+			calls, err := readRCalls(wc, each)
+			if err != nil {
+				// no context uploaded for this function
+				core.Log.Warnf("failed to read reverse calls of %s: %v", each, err)
+				continue
+			}
+			next = append(next, calls...)
+		}
+		cur = next
+	}
+	return ret, nil
+}
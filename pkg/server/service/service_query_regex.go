@@ -13,7 +13,7 @@ import (
 // @Summary func query
 // @Param   repo  query string true "repo"
 // @Param   rev   query string true "rev"
-// @Param   field query string true "field"
+// @Param   field query string true "field, eg: name, extras.doc"
 // @Param   regex query string true "regex"
 // @Produce json
 // @Success 200 {array} object.FunctionServiceDTO
@@ -57,7 +57,7 @@ func HandleRegexFunc(c *gin.Context) {
 // @Summary clazz query
 // @Param   repo  query string true "repo"
 // @Param   rev   query string true "rev"
-// @Param   field query string true "field"
+// @Param   field query string true "field, eg: name, extras.doc"
 // @Param   regex query string true "regex"
 // @Produce json
 // @Success 200 {array} object.ClazzServiceDTO
@@ -98,6 +98,50 @@ func HandleRegexClazz(c *gin.Context) {
 	c.JSON(http.StatusOK, classes)
 }
 
+// @Summary import query
+// @Param   repo  query string true "repo"
+// @Param   rev   query string true "rev"
+// @Param   field query string true "field"
+// @Param   regex query string true "regex"
+// @Produce json
+// @Success 200 {array} object.ImportServiceDTO
+// @Router  /api/v1/regex/import [get]
+// @Tags    RegexQuery
+func HandleRegexImport(c *gin.Context) {
+	repo := c.Query("repo")
+	rev := c.Query("rev")
+	field := c.Query("field")
+	regex := c.Query("regex")
+
+	wc := &object.WorkspaceConfig{
+		RepoId:  repo,
+		RevHash: rev,
+	}
+	if err := wc.Verify(); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	newRegex, err := regexp.Compile(regex)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Errorf("invalid regex: %w", err))
+		return
+	}
+	// regex fn
+	verify := func(s string) bool {
+		return newRegex.Match([]byte(s))
+	}
+	ruleMap := make(binding.Rule)
+	ruleMap[field] = verify
+
+	imports, err := sharedDriver.ReadImportsWithRule(wc, ruleMap, sharedContext)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	c.JSON(http.StatusOK, imports)
+}
+
 // @Summary func ctx query
 // @Param   repo  query string true "repo"
 // @Param   rev   query string true "rev"
@@ -119,3 +119,36 @@ func HandleClazzUpload(c *gin.Context) {
 	go sharedQueue.SubmitClazz(result)
 	c.JSON(http.StatusOK, "received")
 }
+
+// @Summary upload import
+// @Accept  json
+// @Produce json
+// @Success 200
+// @Param   payload body object.ImportUploadUnit true "Payload description"
+// @Router  /api/v1/import [post]
+// @Tags    Upload
+func HandleImportUpload(c *gin.Context) {
+	result := &object.ImportUploadUnit{}
+	if c.GetHeader("Content-Type") == object.BodyTypeMsgpack {
+		err := extractBodyWithMsgpack(c.Request.Body, result)
+		if err != nil {
+			core.Log.Errorf("error when parse msgpack: %v\n", err)
+			c.JSON(http.StatusBadRequest, fmt.Sprintf("parse msgpack error: %v", err))
+			return
+		}
+	} else {
+		err := c.BindJSON(result)
+		if err != nil {
+			core.Log.Errorf("error when parse json: %v\n", err)
+			c.JSON(http.StatusBadRequest, fmt.Sprintf("parse json error: %v", err))
+			return
+		}
+	}
+
+	if err := result.WorkspaceConfig.Verify(); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	go sharedQueue.SubmitImport(result)
+	c.JSON(http.StatusOK, "received")
+}
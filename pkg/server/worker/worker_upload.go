@@ -12,6 +12,7 @@ import (
 var funcUnitQueue chan *object.FunctionUploadUnit
 var funcCtxUnitQueue chan *object.FunctionContextUploadUnit
 var clazzUnitQueue chan *object.ClazzUploadUnit
+var importUnitQueue chan *object.ImportUploadUnit
 
 // worker count, db connections count
 var workerCount int
@@ -30,10 +31,12 @@ func InitWorker(config object.ExecuteConfig, context context.Context, driver bin
 	funcUnitQueue = make(chan *object.FunctionUploadUnit, workerQueueSize)
 	funcCtxUnitQueue = make(chan *object.FunctionContextUploadUnit, workerQueueSize)
 	clazzUnitQueue = make(chan *object.ClazzUploadUnit, workerQueueSize)
+	importUnitQueue = make(chan *object.ImportUploadUnit, workerQueueSize)
 
 	q.WatchFunc(funcUnitQueue)
 	q.WatchFuncCtx(funcCtxUnitQueue)
 	q.WatchClazz(clazzUnitQueue)
+	q.WatchImport(importUnitQueue)
 
 	initWorkers(context, driver)
 }
@@ -50,6 +53,10 @@ func GetClazzQueueTodoCount() int {
 	return len(clazzUnitQueue)
 }
 
+func GetImportQueueTodoCount() int {
+	return len(importUnitQueue)
+}
+
 func initWorkers(ctx context.Context, driver binding.Driver) {
 	for i := 0; i < workerCount; i++ {
 		go func() {
@@ -97,6 +104,16 @@ func startWorker(ctx context.Context, driver binding.Driver) {
 				core.Log.Errorf("error when upload class: %v\n", err)
 			}
 
+		case result := <-importUnitQueue:
+			// failure allowed
+			// todo: waste 1 txn
+			_ = driver.CreateWorkspace(result.WorkspaceConfig, ctx)
+
+			err := driver.CreateImportFile(result.WorkspaceConfig, result.ImportFileResult, ctx)
+			if err != nil {
+				core.Log.Errorf("error when upload import: %v\n", err)
+			}
+
 		case <-ctx.Done():
 			return
 		}